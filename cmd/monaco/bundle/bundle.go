@@ -0,0 +1,119 @@
+/*
+ * @license
+ * Copyright 2025 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package bundle
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/cmd/monaco/cmdutils"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/cmd/monaco/completion"
+	deploycmd "github.com/dynatrace/dynatrace-configuration-as-code/v2/cmd/monaco/deploy"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/cmd/monaco/dynatrace"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/errutils"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/files"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/log"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/bundle"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy"
+	manifestloader "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/manifest/loader"
+	project "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/project/v2"
+)
+
+func GetBundleCommand(fs afero.Fs) (bundleCmd *cobra.Command) {
+	var outputFile string
+
+	bundleCmd = &cobra.Command{
+		Use:   "bundle <manifest.yaml>",
+		Short: "Bundle a manifest and its projects into an archive to deploy",
+		Long: "Validates the manifest, its projects and their templates like a dry-run, and writes the manifest together with all files of its projects into one zip archive. " +
+			"The archive contains the hash of its content, which is verified when deploying it using 'monaco deploy --bundle'. " +
+			"This way, exactly the validated and reviewed content is deployed.",
+		Example:           "monaco bundle manifest.yaml -o release.zip",
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completion.SingleArgumentManifestFileCompletion,
+		PreRun:            cmdutils.SilenceUsageCommand(),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			manifestName := args[0]
+
+			if !files.IsYamlFileExtension(manifestName) {
+				return fmt.Errorf("wrong format for manifest file! expected a .yaml file, but got %s", manifestName)
+			}
+
+			return createBundle(cmd.Context(), fs, manifestName, outputFile)
+		},
+	}
+
+	bundleCmd.Flags().StringVarP(&outputFile, "output-file", "o", "bundle.zip", "File to write the bundle to.")
+
+	return bundleCmd
+}
+
+// createBundle validates the given manifest and its projects, and writes them to a bundle. Files referenced by the
+// configs must be located in the directory of the manifest.
+func createBundle(ctx context.Context, fs afero.Fs, manifestName string, outputFile string) error {
+	absManifestPath, err := deploycmd.AbsPath(manifestName)
+	if err != nil {
+		return fmt.Errorf("error while finding absolute path for `%s`: %w", manifestName, err)
+	}
+
+	// environment variables of the manifest are only required to deploy the bundle
+	loadedManifest, errs := manifestloader.Load(&manifestloader.Context{
+		Fs:           fs,
+		ManifestPath: absManifestPath,
+		Opts:         manifestloader.Options{DoNotResolveEnvVars: true, RequireEnvironmentGroups: true},
+	})
+	if len(errs) > 0 {
+		errutils.PrintErrors(errs)
+		return errors.New("error while loading manifest")
+	}
+
+	loadedProjects, err := deploycmd.LoadProjects(ctx, fs, absManifestPath, &loadedManifest, nil)
+	if err != nil {
+		return err
+	}
+
+	if err := deploycmd.ValidateProjectsWithEnvironments(ctx, loadedProjects, loadedManifest.Environments); err != nil {
+		return err
+	}
+
+	clientSets, err := dynatrace.CreateEnvironmentClients(ctx, loadedManifest.Environments, true)
+	if err != nil {
+		return fmt.Errorf("failed to create API clients: %w", err)
+	}
+	if err := deploy.Deploy(ctx, loadedProjects, clientSets, deploy.DeployConfigsOptions{DryRun: true}); err != nil {
+		return fmt.Errorf("validation failed - check logs for details: %w", err)
+	}
+
+	bundledFiles := project.ReferencedFiles(loadedProjects)
+	for _, p := range loadedManifest.Projects {
+		bundledFiles = append(bundledFiles, p.Path)
+	}
+
+	manifestDir := filepath.Dir(absManifestPath)
+	hash, err := bundle.Create(fs, outputFile, afero.NewBasePathFs(fs, manifestDir), filepath.Base(absManifestPath), bundledFiles)
+	if err != nil {
+		return fmt.Errorf("failed to create bundle: %w", err)
+	}
+
+	log.Info("Created bundle %q with content hash %s", outputFile, hash)
+	return nil
+}
@@ -0,0 +1,120 @@
+//go:build unit
+
+// @license
+// Copyright 2022 Dynatrace LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bundle
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	deploycmd "github.com/dynatrace/dynatrace-configuration-as-code/v2/cmd/monaco/deploy"
+)
+
+func Test_BundleAndDeployBundle(t *testing.T) {
+	t.Setenv("ENV_TOKEN", "mock env token")
+
+	manifestYaml := `manifestVersion: "1.0"
+projects:
+- name: project
+environmentGroups:
+- name: default
+  environments:
+  - name: env
+    url:
+      value: https://abcde.dev.dynatracelabs.com
+    auth:
+      token:
+        type: environment
+        name: ENV_TOKEN
+`
+	configYaml := `configs:
+- id: profile
+  config:
+    name: alerting-profile
+    template: ../../shared/profile.json
+  type:
+    api: alerting-profile
+`
+	srcFs := afero.NewMemMapFs()
+	configPath, _ := filepath.Abs("project/alerting-profile/profile.yaml")
+	require.NoError(t, afero.WriteFile(srcFs, configPath, []byte(configYaml), 0644))
+	templatePath, _ := filepath.Abs("shared/profile.json")
+	require.NoError(t, afero.WriteFile(srcFs, templatePath, []byte(`{"name": "{{ .name }}"}`), 0644))
+	manifestPath, _ := filepath.Abs("manifest.yaml")
+	require.NoError(t, afero.WriteFile(srcFs, manifestPath, []byte(manifestYaml), 0644))
+
+	require.NoError(t, createBundle(t.Context(), srcFs, manifestPath, "bundle.zip"))
+
+	// the bundle is deployed without any of the files it was created from
+	content, err := afero.ReadFile(srcFs, "bundle.zip")
+	require.NoError(t, err)
+	testFs := afero.NewMemMapFs()
+	require.NoError(t, afero.WriteFile(testFs, "bundle.zip", content, 0644))
+
+	t.Run("deploys bundle", func(t *testing.T) {
+		cmd := deploycmd.GetDeployCommand(testFs)
+		cmd.SetArgs([]string{"--bundle", "bundle.zip", "--environment", "env", "--dry-run"})
+		err := cmd.ExecuteContext(t.Context())
+		assert.NoError(t, err)
+	})
+
+	t.Run("fails for missing bundle", func(t *testing.T) {
+		cmd := deploycmd.GetDeployCommand(testFs)
+		cmd.SetArgs([]string{"--bundle", "missing.zip", "--dry-run"})
+		err := cmd.ExecuteContext(t.Context())
+		assert.Error(t, err)
+	})
+}
+
+func Test_createBundle_FailsForInvalidProjects(t *testing.T) {
+	manifestYaml := `manifestVersion: "1.0"
+projects:
+- name: project
+environmentGroups:
+- name: default
+  environments:
+  - name: env
+    url:
+      value: https://abcde.dev.dynatracelabs.com
+    auth:
+      token:
+        name: ENV_TOKEN
+`
+	configYaml := `configs:
+- id: profile
+  config:
+    name: alerting-profile
+    template: missing.json
+  type:
+    api: alerting-profile
+`
+	fs := afero.NewMemMapFs()
+	configPath, _ := filepath.Abs("project/alerting-profile/profile.yaml")
+	require.NoError(t, afero.WriteFile(fs, configPath, []byte(configYaml), 0644))
+	manifestPath, _ := filepath.Abs("manifest.yaml")
+	require.NoError(t, afero.WriteFile(fs, manifestPath, []byte(manifestYaml), 0644))
+
+	err := createBundle(t.Context(), fs, manifestPath, "bundle.zip")
+	assert.Error(t, err)
+
+	exists, err := afero.Exists(fs, "bundle.zip")
+	require.NoError(t, err)
+	assert.False(t, exists)
+}
@@ -0,0 +1,48 @@
+/*
+ * @license
+ * Copyright 2025 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmdutils
+
+import "errors"
+
+// ExitCodeError is returned by commands that must exit with a specific exit code. All other errors exit with code 1.
+type ExitCodeError struct {
+	// Code is the exit code
+	Code int
+	// Err is the error causing the exit code
+	Err error
+}
+
+func (e ExitCodeError) Error() string {
+	return e.Err.Error()
+}
+
+func (e ExitCodeError) Unwrap() error {
+	return e.Err
+}
+
+// ExitCode returns the exit code for the given error returned by a command. It is 0 if err is nil, the code of an
+// ExitCodeError, or 1 for all other errors.
+func ExitCode(err error) int {
+	if err == nil {
+		return 0
+	}
+	var exitCodeErr ExitCodeError
+	if errors.As(err, &exitCodeErr) {
+		return exitCodeErr.Code
+	}
+	return 1
+}
@@ -0,0 +1,129 @@
+/*
+ * @license
+ * Copyright 2025 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package deploy
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"maps"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/spf13/afero"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/log"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/log/field"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/coordinate"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/graph"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/manifest"
+	project "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/project/v2"
+)
+
+// selectChangedConfigs returns the given projects reduced to the configs affected by the files that changed according
+// to changedSince, together with all configs they depend on. If includeDependents is set, all configs depending on the
+// affected configs are kept as well.
+func selectChangedConfigs(ctx context.Context, fs afero.Fs, manifestPath string, man *manifest.Manifest, projects []project.Project, changedSince string, includeDependents bool) ([]project.Project, error) {
+	workingDir := filepath.Dir(manifestPath)
+	files, err := changedFiles(ctx, fs, changedSince, workingDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find files changed since %q: %w", changedSince, err)
+	}
+	log.Debug("Files changed since %q: %v", changedSince, files)
+
+	changed := project.FindConfigsOfFiles(ctx, fs, projectLoaderContext(manifestPath, man), projects, filepath.Base(manifestPath), files)
+	coordinates := slices.Collect(maps.Keys(changed))
+	log.Info("%d changed file(s) affect %d configuration(s)", len(files), len(coordinates))
+
+	g := graph.New(projects, man.Environments.Names())
+	selected := make(map[string]map[coordinate.Coordinate]struct{}, len(man.Environments))
+	for _, env := range man.Environments {
+		closure, err := g.GetDependencyClosure(env.Name, coordinates, includeDependents)
+		if err != nil {
+			return nil, err
+		}
+		selected[env.Name] = closure
+		log.WithFields(field.Environment(env.Name, env.Group)).Info("Selected %d configuration(s) to deploy to environment %q", len(closure), env.Name)
+	}
+
+	return filterConfigs(projects, selected), nil
+}
+
+// changedFiles returns the paths of all changed files relative to the given working directory. If changedSince is an
+// existing file, it is read as a list of changed files, one path per line. Otherwise, changedSince is treated as a git
+// revision, and all files that differ from it in the current directory are returned. In both cases, relative paths are
+// relative to the current directory.
+func changedFiles(ctx context.Context, fs afero.Fs, changedSince string, workingDir string) ([]string, error) {
+	var content []byte
+	if exists, err := afero.Exists(fs, changedSince); err != nil {
+		return nil, err
+	} else if exists {
+		if content, err = afero.ReadFile(fs, changedSince); err != nil {
+			return nil, err
+		}
+	} else {
+		var stderr bytes.Buffer
+		cmd := exec.CommandContext(ctx, "git", "diff", "--name-only", "--relative", changedSince, "--")
+		cmd.Stderr = &stderr
+		if content, err = cmd.Output(); err != nil {
+			return nil, fmt.Errorf("%q is neither a file nor a git revision: %w: %s", changedSince, err, strings.TrimSpace(stderr.String()))
+		}
+	}
+
+	var files []string
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+
+		path, err := filepath.Abs(line)
+		if err != nil {
+			return nil, err
+		}
+		if path, err = filepath.Rel(workingDir, path); err != nil {
+			return nil, err
+		}
+		files = append(files, path)
+	}
+	return files, scanner.Err()
+}
+
+// filterConfigs returns copies of the given projects that only contain the selected configs of each environment
+func filterConfigs(projects []project.Project, selected map[string]map[coordinate.Coordinate]struct{}) []project.Project {
+	result := make([]project.Project, 0, len(projects))
+	for _, p := range projects {
+		configs := make(project.ConfigsPerTypePerEnvironments, len(p.Configs))
+		for env, configsPerType := range p.Configs {
+			configs[env] = project.ConfigsPerType{}
+			for t, cs := range configsPerType {
+				for _, c := range cs {
+					if _, found := selected[env][c.Coordinate]; found {
+						configs[env][t] = append(configs[env][t], c)
+					}
+				}
+			}
+		}
+		p.Configs = configs
+		result = append(result, p)
+	}
+	return result
+}
@@ -0,0 +1,171 @@
+//go:build unit
+
+/*
+ * @license
+ * Copyright 2025 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package deploy
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/coordinate"
+	project "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/project/v2"
+)
+
+func Test_selectChangedConfigs(t *testing.T) {
+	t.Setenv("ENV_TOKEN", "mock env token")
+
+	manifestYaml := `manifestVersion: "1.0"
+projects:
+- name: project
+environmentGroups:
+- name: default
+  environments:
+  - name: env
+    url:
+      value: https://abcde.dev.dynatracelabs.com
+    auth:
+      token:
+        name: ENV_TOKEN
+`
+	configYaml := `configs:
+- id: zone
+  config:
+    name: zone
+    template: zone.json
+  type:
+    api: management-zone
+- id: profile
+  config:
+    name: profile
+    template: profile.json
+    parameters:
+      zoneId:
+        type: reference
+        configType: management-zone
+        configId: zone
+        property: id
+  type:
+    api: alerting-profile
+- id: other
+  config:
+    name: other
+    template: other.json
+  type:
+    api: alerting-profile
+`
+	testFs := afero.NewMemMapFs()
+	write := func(path, content string) string {
+		abs, err := filepath.Abs(path)
+		require.NoError(t, err)
+		require.NoError(t, afero.WriteFile(testFs, abs, []byte(content), 0644))
+		return abs
+	}
+	write("project/config.yaml", configYaml)
+	write("project/zone.json", "{}")
+	write("project/profile.json", "{}")
+	write("project/other.json", "{}")
+	manifestPath := write("manifest.yaml", manifestYaml)
+
+	man, err := LoadManifest(t.Context(), testFs, manifestPath, nil, nil)
+	require.NoError(t, err)
+	projects, err := LoadProjects(t.Context(), testFs, manifestPath, man, nil)
+	require.NoError(t, err)
+
+	coordinatesOf := func(projects []project.Project) []string {
+		var result []string
+		for _, p := range projects {
+			p.ForEveryConfigDo(func(c config.Config) { result = append(result, c.Coordinate.String()) })
+		}
+		return result
+	}
+
+	tests := []struct {
+		name              string
+		changedFiles      string
+		includeDependents bool
+		want              []string
+	}{
+		{
+			name:         "changed config with its dependencies",
+			changedFiles: "project/profile.json\n",
+			want:         []string{"project:alerting-profile:profile", "project:management-zone:zone"},
+		},
+		{
+			name:         "dependents are not included by default",
+			changedFiles: "project/zone.json\n",
+			want:         []string{"project:management-zone:zone"},
+		},
+		{
+			name:              "dependents are included",
+			changedFiles:      "project/zone.json\n",
+			includeDependents: true,
+			want:              []string{"project:alerting-profile:profile", "project:management-zone:zone"},
+		},
+		{
+			name:         "changed config file",
+			changedFiles: "project/config.yaml\n",
+			want:         []string{"project:alerting-profile:other", "project:alerting-profile:profile", "project:management-zone:zone"},
+		},
+		{
+			name:         "unrelated files",
+			changedFiles: "\nREADME.md\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			listFile := write("changed.txt", tt.changedFiles)
+
+			got, err := selectChangedConfigs(t.Context(), testFs, manifestPath, man, projects, listFile, tt.includeDependents)
+			require.NoError(t, err)
+			assert.ElementsMatch(t, tt.want, coordinatesOf(got))
+		})
+	}
+
+	t.Run("loaded projects are not modified", func(t *testing.T) {
+		assert.Len(t, coordinatesOf(projects), 3)
+	})
+}
+
+func Test_filterConfigs(t *testing.T) {
+	a := config.Config{Coordinate: coordinate.Coordinate{Project: "p", Type: "t", ConfigId: "a"}}
+	b := config.Config{Coordinate: coordinate.Coordinate{Project: "p", Type: "t", ConfigId: "b"}}
+
+	projects := []project.Project{
+		{
+			Id: "p",
+			Configs: project.ConfigsPerTypePerEnvironments{
+				"env1": {"t": {a, b}},
+				"env2": {"t": {a, b}},
+			},
+		},
+	}
+
+	got := filterConfigs(projects, map[string]map[coordinate.Coordinate]struct{}{
+		"env1": {a.Coordinate: {}},
+	})
+
+	require.Len(t, got, 1)
+	assert.Equal(t, []config.Config{a}, got[0].Configs["env1"]["t"])
+	assert.Empty(t, got[0].Configs["env2"]["t"])
+	assert.Equal(t, []config.Config{a, b}, projects[0].Configs["env1"]["t"])
+}
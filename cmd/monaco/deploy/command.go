@@ -29,49 +29,96 @@ import (
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/environment"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/files"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/log"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy/lock"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/report"
 	monacoVersion "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/version"
 )
 
 func GetDeployCommand(fs afero.Fs) (deployCmd *cobra.Command) {
-	var dryRun, continueOnError bool
-	var manifestName string
-	var environment, project, groups []string
+	opts := deployOpts{}
 
 	deployCmd = &cobra.Command{
-		Use:               "deploy <manifest.yaml>",
-		Short:             "Deploy configurations to Dynatrace environments",
-		Example:           "monaco deploy manifest.yaml -v -e dev-environment",
-		Args:              cobra.ExactArgs(1),
+		Use:     "deploy <manifest.yaml>",
+		Short:   "Deploy configurations to Dynatrace environments",
+		Example: "monaco deploy manifest.yaml -v -e dev-environment",
+		Args: func(cmd *cobra.Command, args []string) error {
+			// the manifest of a bundle is part of the bundle
+			if opts.bundle != "" {
+				return cobra.NoArgs(cmd, args)
+			}
+			return cobra.ExactArgs(1)(cmd, args)
+		},
 		ValidArgsFunction: completion.DeployCompletion,
 		PreRun:            cmdutils.SilenceUsageCommand(),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			manifestName = args[0]
+			if len(args) > 0 {
+				opts.manifestName = args[0]
+			}
 			ctx := createDeploymentContext(cmd.Context(), fs)
 			defer finishReport(ctx)
 
-			if !files.IsYamlFileExtension(manifestName) {
-				err := fmt.Errorf("wrong format for manifest file! expected a .yaml file, but got %s", manifestName)
+			if opts.bundle == "" && !files.IsYamlFileExtension(opts.manifestName) {
+				err := fmt.Errorf("wrong format for manifest file! expected a .yaml file, but got %s", opts.manifestName)
+				report.GetReporterFromContextOrDiscard(ctx).ReportLoading(report.StateError, err, "", nil)
+				return err
+			}
+
+			if opts.parallelEnvironments < 1 {
+				err := fmt.Errorf("invalid value %d for '--parallel-environments': at least one environment must be deployed at a time", opts.parallelEnvironments)
+				report.GetReporterFromContextOrDiscard(ctx).ReportLoading(report.StateError, err, "", nil)
+				return err
+			}
+
+			if opts.includeDependents && opts.changedSince == "" {
+				err := fmt.Errorf("'--include-dependents' can only be used together with '--changed-since'")
+				report.GetReporterFromContextOrDiscard(ctx).ReportLoading(report.StateError, err, "", nil)
+				return err
+			}
+
+			if opts.lockTTL <= 0 {
+				err := fmt.Errorf("invalid value %s for '--lock-ttl': the time to live of locks must be positive", opts.lockTTL)
+				report.GetReporterFromContextOrDiscard(ctx).ReportLoading(report.StateError, err, "", nil)
+				return err
+			}
+
+			if opts.forceUnlock && opts.lockDir == "" {
+				err := fmt.Errorf("'--force-unlock' can only be used together with '--lock-dir'")
 				report.GetReporterFromContextOrDiscard(ctx).ReportLoading(report.StateError, err, "", nil)
 				return err
 			}
 
-			return deployConfigs(ctx, fs, manifestName, groups, environment, project, continueOnError, dryRun)
+			return deployConfigs(ctx, fs, opts)
 		},
 	}
 
-	deployCmd.Flags().StringSliceVarP(&environment, "environment", "e", []string{},
+	deployCmd.Flags().StringSliceVarP(&opts.environments, "environment", "e", []string{},
 		"Specify one (or multiple) environment(s) to deploy to. "+
 			"To set multiple environments either repeat this flag, or separate them using a comma (,). "+
 			"This flag is mutually exclusive with '--group'.")
-	deployCmd.Flags().StringSliceVarP(&groups, "group", "g", []string{},
+	deployCmd.Flags().StringSliceVarP(&opts.environmentGroups, "group", "g", []string{},
 		"Specify one (or multiple) environmentGroup(s) to deploy to. "+
 			"To set multiple groups either repeat this flag, or separate them using a comma (,). "+
 			"If this flag is specified, all environments within this group will be used for deployment. "+
 			"This flag is mutually exclusive with '--environment'")
-	deployCmd.Flags().StringSliceVarP(&project, "project", "p", make([]string, 0), "Project configuration to deploy (also deploys any dependent configurations)")
-	deployCmd.Flags().BoolVarP(&dryRun, "dry-run", "d", false, "Validate the structure of your manifest, projects and configurations. Dry-run will resolve all configuration parameters and render JSON templates, but can not validate the content of JSON payloads. After a successful dry-run, deployments may still fail with Dynatrace API errors if the content of JSONs is not valid.")
-	deployCmd.Flags().BoolVarP(&continueOnError, "continue-on-error", "c", false, "Proceed deployment even if individual configuration deployments fail.")
+	deployCmd.Flags().StringSliceVarP(&opts.projects, "project", "p", make([]string, 0), "Project configuration to deploy (also deploys any dependent configurations)")
+	deployCmd.Flags().BoolVarP(&opts.dryRun, "dry-run", "d", false, "Validate the structure of your manifest, projects and configurations. Dry-run will resolve all configuration parameters and render JSON templates, but can not validate the content of JSON payloads. After a successful dry-run, deployments may still fail with Dynatrace API errors if the content of JSONs is not valid.")
+	deployCmd.Flags().BoolVarP(&opts.continueOnError, "continue-on-error", "c", false, "Proceed deployment even if individual configuration deployments fail.")
+	deployCmd.Flags().BoolVar(&opts.skipUnchanged, "skip-unchanged", false, "Fetch the current state of each configuration before deploying it, and skip writing configurations that are unchanged on the Dynatrace environment.")
+	deployCmd.Flags().StringVar(&opts.stateDir, "state-dir", "", "Directory of the local deployment state. If set, the remote object of each deployed configuration is recorded in one JSON file per environment, and preferred when looking up existing configurations in subsequent deployments.")
+	deployCmd.Flags().BoolVar(&opts.prune, "prune", false, "After a successful deployment, delete all objects that monaco deployed for the given projects earlier, but whose configurations were removed from the projects since. Such objects are found in the deployment state (see '--state-dir') and by the external ID of Settings objects. In a dry-run, the environments are only read to list the objects that would be deleted.")
+	deployCmd.Flags().StringVar(&opts.resume, "resume", "", "Checkpoint file to resume a failed deployment from. If the deployment fails, all successfully deployed configurations are recorded in the file. When running the deployment again with the same checkpoint, these configurations are not deployed again, while references to them are still resolved. The file is removed after a successful deployment. Note that changes to already deployed configurations are not deployed when resuming.")
+	deployCmd.Flags().BoolVar(&opts.rollbackOnFailure, "rollback-on-failure", false, "Fetch the current state of each configuration before deploying it. If any configuration of an environment fails to deploy, all configurations already deployed to that environment are rolled back: updated configurations are restored, and created configurations are deleted.")
+	deployCmd.Flags().IntVar(&opts.parallelEnvironments, "parallel-environments", 1, "Maximum number of environments that are deployed concurrently. Environments of different rollout stages are never deployed concurrently.")
+	deployCmd.Flags().StringSliceVar(&opts.selectors.configs, "config", []string{}, "Only deploy configurations whose coordinate 'project:type:configId' matches one of the given glob patterns, e.g. 'my-project:dashboard:*', together with all configurations they depend on.")
+	deployCmd.Flags().StringSliceVar(&opts.selectors.types, "type", []string{}, "Only deploy configurations whose type matches one of the given glob patterns, e.g. 'builtin:alerting.profile', together with all configurations they depend on.")
+	deployCmd.Flags().StringSliceVar(&opts.selectors.labels, "label", []string{}, "Only deploy configurations with at least one of the given labels, together with all configurations they depend on. If several of '--config', '--type' and '--label' are set, configurations must match all of them.")
+	deployCmd.Flags().StringVar(&opts.changedSince, "changed-since", "", "Only deploy configurations affected by changed files, together with all configurations they depend on. The value is either a file listing the changed files, one path per line, or a git revision to compare the current directory to. A configuration is affected if its YAML file, its template or a file used by one of its parameters changed.")
+	deployCmd.Flags().BoolVar(&opts.includeDependents, "include-dependents", false, "When used with '--changed-since', also deploy all configurations that depend on affected configurations.")
+	deployCmd.Flags().StringVar(&opts.lockDir, "lock-dir", "", "Directory shared by all deployments, e.g. on a network drive, in which each environment is locked for the duration of the deployment. If another deployment holds the lock of an environment, the deployment fails without deploying anything. Locks expire unless they are renewed by the deployment holding them (see '--lock-ttl').")
+	deployCmd.Flags().DurationVar(&opts.lockTTL, "lock-ttl", lock.DefaultTTL, "Time to live of the locks in '--lock-dir'. Locks are renewed every third of this time while the deployment runs. Expired locks, e.g. of killed deployments, are removed by the next deployment.")
+	deployCmd.Flags().BoolVar(&opts.forceUnlock, "force-unlock", false, "Remove existing locks in '--lock-dir' held by other deployments, even if they did not expire. Only use this if you are sure that no other deployment is running.")
+	deployCmd.Flags().StringVar(&opts.bundle, "bundle", "", "Deploy the manifest and projects of a bundle created by 'monaco bundle' instead of a manifest file. The content of the bundle is verified against its hash before deploying it. If this flag is set, no manifest must be given.")
+	deployCmd.Flags().BoolVar(&opts.plan, "plan", false, "Show which configurations would be created, updated or left unchanged, without deploying anything. In contrast to '--dry-run', the current state of all configurations is fetched from the Dynatrace environments and compared to the rendered JSON templates.")
 
 	err := deployCmd.RegisterFlagCompletionFunc("environment", completion.EnvironmentByManifestFlag)
 	if err != nil {
@@ -84,6 +131,18 @@ func GetDeployCommand(fs afero.Fs) (deployCmd *cobra.Command) {
 	}
 
 	deployCmd.MarkFlagsMutuallyExclusive("environment", "group")
+	deployCmd.MarkFlagsMutuallyExclusive("plan", "dry-run")
+	deployCmd.MarkFlagsMutuallyExclusive("plan", "prune")
+	deployCmd.MarkFlagsMutuallyExclusive("changed-since", "prune")
+	deployCmd.MarkFlagsMutuallyExclusive("config", "prune")
+	deployCmd.MarkFlagsMutuallyExclusive("type", "prune")
+	deployCmd.MarkFlagsMutuallyExclusive("label", "prune")
+	deployCmd.MarkFlagsMutuallyExclusive("resume", "dry-run")
+	deployCmd.MarkFlagsMutuallyExclusive("resume", "plan")
+	deployCmd.MarkFlagsMutuallyExclusive("resume", "rollback-on-failure")
+	deployCmd.MarkFlagsMutuallyExclusive("bundle", "changed-since")
+	deployCmd.MarkFlagsMutuallyExclusive("lock-dir", "dry-run")
+	deployCmd.MarkFlagsMutuallyExclusive("lock-dir", "plan")
 
 	return deployCmd
 }
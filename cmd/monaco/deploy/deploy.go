@@ -18,8 +18,11 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"os"
 	"path/filepath"
+	"slices"
 	"strings"
+	"time"
 
 	"github.com/spf13/afero"
 
@@ -29,9 +32,14 @@ import (
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/log"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/log/field"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/api"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/bundle"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/client"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/coordinate"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy/checkpoint"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy/lock"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy/state"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/manifest"
 	manifestloader "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/manifest/loader"
 	project "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/project/v2"
@@ -39,65 +47,357 @@ import (
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/report"
 )
 
-func deployConfigs(ctx context.Context, fs afero.Fs, manifestPath string, environmentGroups []string, specificEnvironments []string, specificProjects []string, continueOnErr bool, dryRun bool) error {
-	absManifestPath, err := absPath(manifestPath)
+type deployOpts struct {
+	manifestName         string
+	environmentGroups    []string
+	environments         []string
+	projects             []string
+	continueOnError      bool
+	dryRun               bool
+	plan                 bool
+	skipUnchanged        bool
+	stateDir             string
+	prune                bool
+	resume               string
+	rollbackOnFailure    bool
+	parallelEnvironments int
+	changedSince         string
+	includeDependents    bool
+	selectors            configSelectors
+	lockDir              string
+	lockTTL              time.Duration
+	forceUnlock          bool
+	bundle               string
+}
+
+func deployConfigs(ctx context.Context, fs afero.Fs, opts deployOpts) error {
+	// manifest and projects are loaded from the bundle, if any, while all other files, like states, are on fs
+	contentFs := fs
+	if opts.bundle != "" {
+		b, err := bundle.Open(fs, opts.bundle)
+		if err != nil {
+			report.GetReporterFromContextOrDiscard(ctx).ReportLoading(report.StateError, err, "", nil)
+			return err
+		}
+		log.Info("Deploying bundle %q with content hash %s", opts.bundle, b.Hash)
+		contentFs = b.Fs
+		opts.manifestName = b.ManifestPath
+	}
+
+	absManifestPath, err := AbsPath(opts.manifestName)
 	if err != nil {
-		formattedErr := fmt.Errorf("error while finding absolute path for `%s`: %w", manifestPath, err)
+		formattedErr := fmt.Errorf("error while finding absolute path for `%s`: %w", opts.manifestName, err)
 		report.GetReporterFromContextOrDiscard(ctx).ReportLoading(report.StateError, formattedErr, "", nil)
 		return formattedErr
 	}
 
-	loadedManifest, err := loadManifest(ctx, fs, absManifestPath, environmentGroups, specificEnvironments)
+	loadedManifest, err := LoadManifest(ctx, contentFs, absManifestPath, opts.environmentGroups, opts.environments)
 	if err != nil {
 		return err
 	}
 
-	ok := verifyEnvironmentGen(ctx, loadedManifest.Environments, dryRun)
+	ok := verifyEnvironmentGen(ctx, loadedManifest.Environments, opts.dryRun)
 	if !ok {
 		return fmt.Errorf("unable to verify Dynatrace environment generation")
 	}
 
-	loadedProjects, err := loadProjects(ctx, fs, absManifestPath, loadedManifest, specificProjects)
+	loadedProjects, err := LoadProjects(ctx, contentFs, absManifestPath, loadedManifest, opts.projects)
 	if err != nil {
 		return err
 	}
 
-	if err := validateProjectsWithEnvironments(ctx, loadedProjects, loadedManifest.Environments); err != nil {
+	if err := ValidateProjectsWithEnvironments(ctx, loadedProjects, loadedManifest.Environments); err != nil {
 		return err
 	}
 
+	if opts.changedSince != "" {
+		loadedProjects, err = selectChangedConfigs(ctx, fs, absManifestPath, loadedManifest, loadedProjects, opts.changedSince, opts.includeDependents)
+		if err != nil {
+			report.GetReporterFromContextOrDiscard(ctx).ReportLoading(report.StateError, err, "", nil)
+			return err
+		}
+	}
+
+	if !opts.selectors.isEmpty() {
+		loadedProjects, err = selectConfigs(loadedManifest, loadedProjects, opts.selectors)
+		if err != nil {
+			report.GetReporterFromContextOrDiscard(ctx).ReportLoading(report.StateError, err, "", nil)
+			return err
+		}
+	}
+
 	logging.LogProjectsInfo(loadedProjects)
 	logging.LogEnvironmentsInfo(loadedManifest.Environments)
 
-	err = validateAuthenticationWithProjectConfigs(loadedProjects, loadedManifest.Environments)
+	err = ValidateAuthenticationWithProjectConfigs(loadedProjects, loadedManifest.Environments)
 	if err != nil {
 		formattedErr := fmt.Errorf("manifest auth field misconfigured: %w", err)
 		report.GetReporterFromContextOrDiscard(ctx).ReportLoading(report.StateError, formattedErr, "", nil)
 		return formattedErr
 	}
 
-	clientSets, err := dynatrace.CreateEnvironmentClients(ctx, loadedManifest.Environments, dryRun)
+	clientSets, err := dynatrace.CreateEnvironmentClients(ctx, loadedManifest.Environments, opts.dryRun)
 	if err != nil {
 		formattedErr := fmt.Errorf("failed to create API clients: %w", err)
 		report.GetReporterFromContextOrDiscard(ctx).ReportLoading(report.StateError, formattedErr, "", nil)
 		return formattedErr
 	}
 
-	err = deploy.Deploy(ctx, loadedProjects, clientSets, deploy.DeployConfigsOptions{ContinueOnErr: continueOnErr, DryRun: dryRun})
+	if opts.plan {
+		return planConfigs(ctx, loadedProjects, clientSets)
+	}
+
+	states, err := loadStates(fs, opts, loadedManifest.Environments)
+	if err != nil {
+		report.GetReporterFromContextOrDiscard(ctx).ReportLoading(report.StateError, err, "", nil)
+		return err
+	}
+
+	cp, err := loadCheckpoint(fs, opts.resume)
+	if err != nil {
+		report.GetReporterFromContextOrDiscard(ctx).ReportLoading(report.StateError, err, "", nil)
+		return err
+	}
+
+	err = deploy.Deploy(ctx, loadedProjects, clientSets, deploy.DeployConfigsOptions{
+		ContinueOnErr:           opts.continueOnError,
+		DryRun:                  opts.dryRun,
+		SkipUnchanged:           opts.skipUnchanged,
+		States:                  states,
+		Checkpoint:              cp,
+		RollbackOnFailure:       opts.rollbackOnFailure,
+		Stages:                  rolloutStages(loadedManifest),
+		ParallelEnvironments:    opts.parallelEnvironments,
+		EnvironmentHooks:        environmentHooks(loadedManifest),
+		ProjectHooks:            projectHooks(loadedManifest),
+		DefaultDeploymentPolicy: toDeploymentPolicy(loadedManifest.Deployment.Default),
+		DeploymentPolicies:      deploymentPolicies(loadedManifest),
+		ConcurrentDeployments:   concurrentDeployments(loadedManifest),
+		MaxConcurrencyPerType:   maxConcurrencyPerType(loadedManifest),
+		Locker:                  newLocker(fs, opts),
+	})
+	if cpErr := updateCheckpoint(fs, opts.resume, cp, err); cpErr != nil {
+		log.WithFields(field.Error(cpErr)).Error("Failed to update checkpoint: %v", cpErr)
+		err = errors.Join(err, cpErr)
+	}
+	if opts.prune {
+		if err != nil {
+			log.Warn("Skipping pruning of removed configurations, as the %s failed", logging.GetOperationNounForLogging(opts.dryRun))
+		} else {
+			err = prune(ctx, loadedManifest, loadedProjects, clientSets, opts.dryRun, states)
+		}
+	}
+	if opts.dryRun {
+		states = nil
+	}
+	if stateErr := writeStates(fs, opts.stateDir, states); stateErr != nil {
+		log.WithFields(field.Error(stateErr)).Error("Failed to write deployment state: %v", stateErr)
+		err = errors.Join(err, stateErr)
+	}
+	if err != nil {
+		return fmt.Errorf("%v failed - check logs for details: %w", logging.GetOperationNounForLogging(opts.dryRun), err)
+	}
+
+	log.Info("%s finished without errors", logging.GetOperationNounForLogging(opts.dryRun))
+	return nil
+}
+
+// prune deletes the objects of removed configurations from all environments. The dummy clients of a dry-run don't return
+// any remote objects, so in a dry-run read-only clients are created to list the objects that would be deleted.
+func prune(ctx context.Context, man *manifest.Manifest, projects []project.Project, clientSets dynatrace.EnvironmentClients, dryRun bool, states map[string]*state.State) error {
+	if dryRun {
+		var err error
+		if clientSets, err = readOnlyClients(ctx, man.Environments); err != nil {
+			return fmt.Errorf("failed to create API clients: %w", err)
+		}
+	}
+	return deploy.Prune(ctx, projects, clientSets, deploy.PruneOptions{DryRun: dryRun, States: states})
+}
+
+// readOnlyClients returns clients for the given environments that can only read settings objects
+func readOnlyClients(ctx context.Context, environments manifest.Environments) (dynatrace.EnvironmentClients, error) {
+	clientSets, err := dynatrace.CreateEnvironmentClients(ctx, environments, false)
+	if err != nil {
+		return nil, err
+	}
+
+	readOnly := make(dynatrace.EnvironmentClients, len(clientSets))
+	for env, c := range clientSets {
+		readOnly[env] = &client.ClientSet{SettingsClient: client.ReadOnlySettingsClient{SettingsClient: c.SettingsClient}}
+	}
+	return readOnly, nil
+}
+
+// loadStates loads the deployment state of all given environments from the state directory.
+// No states are loaded if no state directory is configured. In a dry-run, states are only loaded to report which
+// configurations would be pruned.
+func loadStates(fs afero.Fs, opts deployOpts, environments manifest.Environments) (map[string]*state.State, error) {
+	if opts.stateDir == "" || (opts.dryRun && !opts.prune) {
+		return nil, nil
+	}
+
+	states := make(map[string]*state.State, len(environments))
+	for name := range environments {
+		s, err := state.Load(fs, opts.stateDir, name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load deployment state: %w", err)
+		}
+		states[name] = s
+	}
+	return states, nil
+}
+
+// rolloutStages returns the deployment stages of the rollout defined in the manifest, if any
+func rolloutStages(m *manifest.Manifest) []deploy.Stage {
+	if m.Rollout == nil {
+		return nil
+	}
+
+	stages := make([]deploy.Stage, 0, len(m.Rollout.Stages))
+	for _, s := range m.Rollout.Stages {
+		stage := deploy.Stage{Name: s.Name}
+		for _, env := range m.Environments {
+			if slices.Contains(s.Groups, env.Group) {
+				stage.Environments = append(stage.Environments, env.Name)
+			}
+		}
+
+		if s.Gate.Delay > 0 {
+			stage.Gates = append(stage.Gates, deploy.DelayGate{Delay: s.Gate.Delay})
+		}
+		if s.Gate.Command != "" {
+			stage.Gates = append(stage.Gates, deploy.CommandGate{Command: s.Gate.Command})
+		}
+		if s.Gate.Confirm {
+			stage.Gates = append(stage.Gates, deploy.ConfirmationGate{In: os.Stdin, Out: os.Stderr})
+		}
+		stages = append(stages, stage)
+	}
+	return stages
+}
+
+// environmentHooks returns the hooks of all environments defined in the manifest by environment name
+func environmentHooks(m *manifest.Manifest) map[string]config.Hooks {
+	result := make(map[string]config.Hooks, len(m.Environments))
+	for _, env := range m.Environments {
+		result[env.Name] = toHooks(env.Hooks)
+	}
+	return result
+}
+
+// projectHooks returns the hooks of all projects defined in the manifest by project ID
+func projectHooks(m *manifest.Manifest) map[string]config.Hooks {
+	result := make(map[string]config.Hooks, len(m.Projects))
+	for id, p := range m.Projects {
+		result[id] = toHooks(p.Hooks)
+	}
+	return result
+}
+
+func toHooks(h manifest.Hooks) config.Hooks {
+	return config.Hooks{
+		PreDeploy:  h.PreDeploy,
+		PostDeploy: h.PostDeploy,
+		OnError:    h.OnError,
+	}
+}
+
+// deploymentPolicies returns the deployment policies of all config types defined in the manifest by type
+func deploymentPolicies(m *manifest.Manifest) map[string]config.DeploymentPolicy {
+	result := make(map[string]config.DeploymentPolicy, len(m.Deployment.Types))
+	for t, p := range m.Deployment.Types {
+		result[t] = toDeploymentPolicy(p)
+	}
+	return result
+}
+
+func toDeploymentPolicy(p manifest.DeploymentPolicy) config.DeploymentPolicy {
+	return config.DeploymentPolicy{
+		MaxRetries:     p.MaxRetries,
+		Backoff:        p.Backoff,
+		RequestTimeout: p.RequestTimeout,
+		Deadline:       p.Deadline,
+	}
+}
+
+// maxConcurrencyPerType returns the maximum number of concurrent deployments of all config types with such a limit by
+// config type
+func maxConcurrencyPerType(m *manifest.Manifest) map[string]int {
+	result := make(map[string]int, len(m.Deployment.Types))
+	for t, p := range m.Deployment.Types {
+		if p.MaxConcurrency > 0 {
+			result[t] = p.MaxConcurrency
+		}
+	}
+	return result
+}
+
+// concurrentDeployments returns the maximum number of concurrent deployments of all environments defined in the
+// manifest with such a limit by environment name
+func concurrentDeployments(m *manifest.Manifest) map[string]int {
+	result := make(map[string]int, len(m.Environments))
+	for _, env := range m.Environments {
+		if env.Limits.ConcurrentDeployments > 0 {
+			result[env.Name] = env.Limits.ConcurrentDeployments
+		}
+	}
+	return result
+}
+
+// loadCheckpoint loads the checkpoint of a previous failed deployment from the given file.
+// No checkpoint is loaded if no file is configured.
+func loadCheckpoint(fs afero.Fs, path string) (*checkpoint.Checkpoint, error) {
+	if path == "" {
+		return nil, nil
+	}
+
+	cp, err := checkpoint.Load(fs, path)
 	if err != nil {
-		return fmt.Errorf("%v failed - check logs for details: %w", logging.GetOperationNounForLogging(dryRun), err)
+		return nil, fmt.Errorf("failed to load checkpoint: %w", err)
+	}
+	return cp, nil
+}
+
+// updateCheckpoint writes the checkpoint to the given file if the deployment failed, so that it can be resumed.
+// If the deployment succeeded, the checkpoint file is removed as it is not needed anymore.
+func updateCheckpoint(fs afero.Fs, path string, cp *checkpoint.Checkpoint, deployErr error) error {
+	if path == "" {
+		return nil
+	}
+
+	if deployErr == nil {
+		if err := fs.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("failed to remove checkpoint file %q: %w", path, err)
+		}
+		return nil
 	}
 
-	log.Info("%s finished without errors", logging.GetOperationNounForLogging(dryRun))
+	if err := checkpoint.Write(fs, path, cp); err != nil {
+		return err
+	}
+	log.Info("Checkpoint written to %q - fix the failed configurations and run the deployment again with '--resume %s' to continue", path, path)
 	return nil
 }
 
-func absPath(manifestPath string) (string, error) {
+func writeStates(fs afero.Fs, dir string, states map[string]*state.State) error {
+	var errs []error
+	for _, s := range states {
+		if err := state.Write(fs, dir, s); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// AbsPath returns the absolute path of the given manifest path
+func AbsPath(manifestPath string) (string, error) {
 	manifestPath = filepath.Clean(manifestPath)
 	return filepath.Abs(manifestPath)
 }
 
-func loadManifest(ctx context.Context, fs afero.Fs, manifestPath string, groups []string, environments []string) (*manifest.Manifest, error) {
+// LoadManifest loads the manifest at the given path, restricted to the given environment groups and environments.
+// Loading errors are printed and reported.
+func LoadManifest(ctx context.Context, fs afero.Fs, manifestPath string, groups []string, environments []string) (*manifest.Manifest, error) {
 	m, errs := manifestloader.Load(&manifestloader.Context{
 		Fs:           fs,
 		ManifestPath: manifestPath,
@@ -126,13 +426,9 @@ func verifyEnvironmentGen(ctx context.Context, environments manifest.Environment
 	return true
 }
 
-func loadProjects(ctx context.Context, fs afero.Fs, manifestPath string, man *manifest.Manifest, specificProjects []string) ([]project.Project, error) {
-	projects, errs := project.LoadProjects(ctx, fs, project.ProjectLoaderContext{
-		KnownApis:       api.NewAPIs().Filter(api.RemoveDisabled).GetApiNameLookup(),
-		WorkingDir:      filepath.Dir(manifestPath),
-		Manifest:        *man,
-		ParametersSerde: config.DefaultParameterParsers,
-	}, specificProjects)
+// LoadProjects loads the given projects of the manifest, or all projects if none are given. Loading errors are logged.
+func LoadProjects(ctx context.Context, fs afero.Fs, manifestPath string, man *manifest.Manifest, specificProjects []string) ([]project.Project, error) {
+	projects, errs := project.LoadProjects(ctx, fs, projectLoaderContext(manifestPath, man), specificProjects)
 
 	if errs != nil {
 		log.Error("Failed to load projects - %d errors occurred:", len(errs))
@@ -145,11 +441,22 @@ func loadProjects(ctx context.Context, fs afero.Fs, manifestPath string, man *ma
 	return projects, nil
 }
 
+func projectLoaderContext(manifestPath string, man *manifest.Manifest) project.ProjectLoaderContext {
+	return project.ProjectLoaderContext{
+		KnownApis:       api.NewAPIs().Filter(api.RemoveDisabled).GetApiNameLookup(),
+		WorkingDir:      filepath.Dir(manifestPath),
+		Manifest:        *man,
+		ParametersSerde: config.DefaultParameterParsers,
+	}
+}
+
 type KindCoordinates map[string][]coordinate.Coordinate
 type KindCoordinatesPerEnvironment map[string]KindCoordinates
 type CoordinatesPerEnvironment map[string][]coordinate.Coordinate
 
-func validateProjectsWithEnvironments(ctx context.Context, projects []project.Project, envs manifest.Environments) error {
+// ValidateProjectsWithEnvironments verifies that all environments the projects are deployed to are defined in the
+// manifest, and support the configs deployed to them
+func ValidateProjectsWithEnvironments(ctx context.Context, projects []project.Project, envs manifest.Environments) error {
 	undefinedEnvironments := map[string]struct{}{}
 	openPipelineKindCoordinatesPerEnvironment := KindCoordinatesPerEnvironment{}
 	platformCoordinatesPerEnvironment := CoordinatesPerEnvironment{}
@@ -270,9 +577,9 @@ func platformEnvironment(e manifest.EnvironmentDefinition) bool {
 	return e.Auth.OAuth != nil
 }
 
-// validateAuthenticationWithProjectConfigs validates each config entry against the manifest if required credentials are set
+// ValidateAuthenticationWithProjectConfigs validates each config entry against the manifest if required credentials are set
 // it takes into consideration the project, environments and the skip parameter in each config entry
-func validateAuthenticationWithProjectConfigs(projects []project.Project, environments manifest.Environments) error {
+func ValidateAuthenticationWithProjectConfigs(projects []project.Project, environments manifest.Environments) error {
 	for _, p := range projects {
 		for envName, env := range p.Configs {
 			for _, file := range env {
@@ -301,3 +608,11 @@ func validateAuthenticationWithProjectConfigs(projects []project.Project, enviro
 	}
 	return nil
 }
+
+// newLocker returns the locker for the lock directory, or nil if no lock directory is set
+func newLocker(fs afero.Fs, opts deployOpts) *lock.Locker {
+	if opts.lockDir == "" {
+		return nil
+	}
+	return lock.NewLocker(fs, opts.lockDir, opts.lockTTL, opts.forceUnlock)
+}
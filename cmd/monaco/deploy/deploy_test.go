@@ -17,11 +17,17 @@
 package deploy
 
 import (
+	"net/http"
+	"net/http/httptest"
 	"path/filepath"
+	"sync/atomic"
 	"testing"
 
 	"github.com/spf13/afero"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/idutils"
 
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/coordinate"
@@ -54,7 +60,7 @@ func Test_DoDeploy_InvalidManifest(t *testing.T) {
 	manifestPath, _ := filepath.Abs("manifest.yaml")
 	_ = afero.WriteFile(testFs, manifestPath, []byte(manifestYaml), 0644)
 
-	err := deployConfigs(t.Context(), testFs, manifestPath, []string{}, []string{}, []string{}, true, true)
+	err := deployConfigs(t.Context(), testFs, deployOpts{manifestName: manifestPath, continueOnError: true, dryRun: true})
 	assert.Error(t, err)
 }
 
@@ -95,26 +101,26 @@ environmentGroups:
 	_ = afero.WriteFile(testFs, manifestPath, []byte(manifestYaml), 0644)
 
 	t.Run("Wrong environment group", func(t *testing.T) {
-		err := deployConfigs(t.Context(), testFs, manifestPath, []string{"NOT_EXISTING_GROUP"}, []string{}, []string{}, true, true)
+		err := deployConfigs(t.Context(), testFs, deployOpts{manifestName: manifestPath, environmentGroups: []string{"NOT_EXISTING_GROUP"}, continueOnError: true, dryRun: true})
 		assert.Error(t, err)
 	})
 	t.Run("Wrong environment name", func(t *testing.T) {
-		err := deployConfigs(t.Context(), testFs, manifestPath, []string{"default"}, []string{"NOT_EXISTING_ENV"}, []string{}, true, true)
+		err := deployConfigs(t.Context(), testFs, deployOpts{manifestName: manifestPath, environmentGroups: []string{"default"}, environments: []string{"NOT_EXISTING_ENV"}, continueOnError: true, dryRun: true})
 		assert.Error(t, err)
 	})
 
 	t.Run("Wrong project name", func(t *testing.T) {
-		err := deployConfigs(t.Context(), testFs, manifestPath, []string{"default"}, []string{"project"}, []string{"NON_EXISTING_PROJECT"}, true, true)
+		err := deployConfigs(t.Context(), testFs, deployOpts{manifestName: manifestPath, environmentGroups: []string{"default"}, environments: []string{"project"}, projects: []string{"NON_EXISTING_PROJECT"}, continueOnError: true, dryRun: true})
 		assert.Error(t, err)
 	})
 
 	t.Run("no parameters", func(t *testing.T) {
-		err := deployConfigs(t.Context(), testFs, manifestPath, []string{}, []string{}, []string{}, true, true)
+		err := deployConfigs(t.Context(), testFs, deployOpts{manifestName: manifestPath, continueOnError: true, dryRun: true})
 		assert.NoError(t, err)
 	})
 
 	t.Run("correct parameters", func(t *testing.T) {
-		err := deployConfigs(t.Context(), testFs, manifestPath, []string{"default"}, []string{"project"}, []string{"project"}, true, true)
+		err := deployConfigs(t.Context(), testFs, deployOpts{manifestName: manifestPath, environmentGroups: []string{"default"}, environments: []string{"project"}, projects: []string{"project"}, continueOnError: true, dryRun: true})
 		assert.NoError(t, err)
 	})
 
@@ -145,7 +151,7 @@ func Test_checkEnvironments(t *testing.T) {
 	project2Id := "project2"
 
 	t.Run("defined environment in project succeeds", func(t *testing.T) {
-		err := validateProjectsWithEnvironments(
+		err := ValidateProjectsWithEnvironments(
 			t.Context(),
 			[]project.Project{
 				{
@@ -162,7 +168,7 @@ func Test_checkEnvironments(t *testing.T) {
 	})
 
 	t.Run("undefined environment in project fails", func(t *testing.T) {
-		err := validateProjectsWithEnvironments(
+		err := ValidateProjectsWithEnvironments(
 			t.Context(),
 			[]project.Project{
 				{
@@ -179,7 +185,7 @@ func Test_checkEnvironments(t *testing.T) {
 	})
 
 	t.Run("platform config with platform environment succeeds", func(t *testing.T) {
-		err := validateProjectsWithEnvironments(
+		err := ValidateProjectsWithEnvironments(
 			t.Context(),
 			[]project.Project{
 				{
@@ -198,7 +204,7 @@ func Test_checkEnvironments(t *testing.T) {
 	})
 
 	t.Run("platform config without platform environment fails", func(t *testing.T) {
-		err := validateProjectsWithEnvironments(
+		err := ValidateProjectsWithEnvironments(
 			t.Context(),
 			[]project.Project{
 				{
@@ -217,7 +223,7 @@ func Test_checkEnvironments(t *testing.T) {
 	})
 
 	t.Run("two different openpipeline configs in same project succceed", func(t *testing.T) {
-		err := validateProjectsWithEnvironments(
+		err := ValidateProjectsWithEnvironments(
 			t.Context(),
 			[]project.Project{
 				{
@@ -237,7 +243,7 @@ func Test_checkEnvironments(t *testing.T) {
 	})
 
 	t.Run("two different openpipeline configs in different projects succceed", func(t *testing.T) {
-		err := validateProjectsWithEnvironments(
+		err := ValidateProjectsWithEnvironments(
 			t.Context(),
 			[]project.Project{
 				{
@@ -266,7 +272,7 @@ func Test_checkEnvironments(t *testing.T) {
 	})
 
 	t.Run("two identical openpipeline configs in same project but different environments succceed", func(t *testing.T) {
-		err := validateProjectsWithEnvironments(
+		err := ValidateProjectsWithEnvironments(
 			t.Context(),
 			[]project.Project{
 				{
@@ -293,7 +299,7 @@ func Test_checkEnvironments(t *testing.T) {
 	})
 
 	t.Run("two identical openpipeline configs in different projects and environments succceed", func(t *testing.T) {
-		err := validateProjectsWithEnvironments(
+		err := ValidateProjectsWithEnvironments(
 			t.Context(),
 			[]project.Project{
 				{
@@ -325,7 +331,7 @@ func Test_checkEnvironments(t *testing.T) {
 	})
 
 	t.Run("two identical openpipeline configs in same project and environments fail", func(t *testing.T) {
-		err := validateProjectsWithEnvironments(
+		err := ValidateProjectsWithEnvironments(
 			t.Context(),
 			[]project.Project{
 				{
@@ -348,7 +354,7 @@ func Test_checkEnvironments(t *testing.T) {
 	})
 
 	t.Run("two identical openpipeline configs in different projects and same environments fail", func(t *testing.T) {
-		err := validateProjectsWithEnvironments(
+		err := ValidateProjectsWithEnvironments(
 			t.Context(),
 			[]project.Project{
 				{
@@ -529,7 +535,7 @@ func Test_ValidateAuthenticationWithProjectConfigs(t *testing.T) {
 
 	for _, tc := range success_tests {
 		t.Run(tc.name, func(t *testing.T) {
-			err := validateAuthenticationWithProjectConfigs(
+			err := ValidateAuthenticationWithProjectConfigs(
 				[]project.Project{
 					{
 						Id: "some id",
@@ -547,3 +553,64 @@ func Test_ValidateAuthenticationWithProjectConfigs(t *testing.T) {
 		})
 	}
 }
+
+func Test_DoDeploy_DryRunPruneListsSettingsObjectsOfEnvironment(t *testing.T) {
+	removed := coordinate.Coordinate{Project: "project", Type: "builtin:test", ConfigId: "removed"}
+	externalID, err := idutils.GenerateExternalIDForSettingsObject(removed)
+	require.NoError(t, err)
+
+	var listedSettings, deleteRequests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		switch {
+		case req.Method == http.MethodDelete:
+			deleteRequests.Add(1)
+			rw.WriteHeader(http.StatusNoContent)
+		case req.Method == http.MethodGet && req.URL.Path == "/api/v2/settings/schemas":
+			_, _ = rw.Write([]byte(`{"items": [{"schemaId": "builtin:test"}], "totalCount": 1}`))
+		case req.Method == http.MethodGet && req.URL.Path == "/api/v2/settings/objects":
+			listedSettings.Add(1)
+			_, _ = rw.Write([]byte(`{"items": [{"objectId": "removed-id", "externalId": "` + externalID + `", "schemaId": "builtin:test", "scope": "environment", "resourceContext": {"operations": ["read", "write", "delete"]}}], "totalCount": 1, "pageSize": 500}`))
+		default:
+			rw.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	t.Setenv("ENV_TOKEN", "mock env token")
+	manifestYaml := `manifestVersion: "1.0"
+projects:
+- name: project
+environmentGroups:
+- name: default
+  environments:
+  - name: env
+    url:
+      value: ` + server.URL + `
+    auth:
+      token:
+        type: environment
+        name: ENV_TOKEN
+`
+	configYaml := `configs:
+- id: kept
+  config:
+    template: kept.json
+  type:
+    settings:
+      schema: builtin:test
+      scope: environment
+`
+	testFs := afero.NewMemMapFs()
+	configPath, _ := filepath.Abs("project/builtin-test/config.yaml")
+	require.NoError(t, afero.WriteFile(testFs, configPath, []byte(configYaml), 0644))
+	templatePath, _ := filepath.Abs("project/builtin-test/kept.json")
+	require.NoError(t, afero.WriteFile(testFs, templatePath, []byte("{}"), 0644))
+	manifestPath, _ := filepath.Abs("manifest.yaml")
+	require.NoError(t, afero.WriteFile(testFs, manifestPath, []byte(manifestYaml), 0644))
+
+	err = deployConfigs(t.Context(), testFs, deployOpts{manifestName: manifestPath, dryRun: true, prune: true})
+	require.NoError(t, err)
+
+	assert.Positive(t, listedSettings.Load(), "settings objects of the environment must be listed in a dry-run")
+	assert.Zero(t, deleteRequests.Load(), "nothing must be deleted in a dry-run")
+}
@@ -0,0 +1,76 @@
+/*
+ * @license
+ * Copyright 2025 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package deploy
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/cmd/monaco/dynatrace"
+	jsonutils "github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/json"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/log"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy"
+	project "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/project/v2"
+)
+
+// planConfigs creates a deployment plan for the given projects and logs all planned changes
+func planConfigs(ctx context.Context, projects []project.Project, clientSets dynatrace.EnvironmentClients) error {
+	changes, err := deploy.Plan(ctx, projects, clientSets)
+
+	for _, line := range formatPlan(changes) {
+		log.Info("%s", line)
+	}
+
+	if err != nil {
+		return fmt.Errorf("plan failed - check logs for details: %w", err)
+	}
+
+	log.Info("Plan finished without errors")
+	return nil
+}
+
+// formatPlan returns a human-readable representation of the planned changes, one line per change followed by the
+// field-level differences of updates and a final summary line.
+func formatPlan(changes []deploy.PlannedChange) []string {
+	var lines []string
+	counts := map[deploy.PlanAction]int{}
+
+	for _, c := range changes {
+		counts[c.Action]++
+
+		line := fmt.Sprintf("[%s] %-9s %s", c.Environment, c.Action, c.Coordinate)
+		if c.RemoteID != "" {
+			line += fmt.Sprintf(" (%s)", c.RemoteID)
+		}
+		lines = append(lines, line)
+
+		for _, d := range c.Differences {
+			lines = append(lines, fmt.Sprintf("    %s: %s -> %s", d.Path, formatValue(d.Actual), formatValue(d.Desired)))
+		}
+	}
+
+	lines = append(lines, fmt.Sprintf("Plan: %d to create, %d to update, %d unchanged, %d skipped",
+		counts[deploy.PlanActionCreate], counts[deploy.PlanActionUpdate], counts[deploy.PlanActionUnchanged], counts[deploy.PlanActionSkip]))
+	return lines
+}
+
+func formatValue(v any) string {
+	if v == nil {
+		return "<missing>"
+	}
+	return jsonutils.ToString(v)
+}
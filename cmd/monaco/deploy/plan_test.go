@@ -0,0 +1,52 @@
+//go:build unit
+
+/*
+ * @license
+ * Copyright 2025 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package deploy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/json"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/coordinate"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy"
+)
+
+func Test_formatPlan(t *testing.T) {
+	changes := []deploy.PlannedChange{
+		{Environment: "dev", Coordinate: coordinate.Coordinate{Project: "p", Type: "t", ConfigId: "a"}, Action: deploy.PlanActionCreate},
+		{
+			Environment: "dev",
+			Coordinate:  coordinate.Coordinate{Project: "p", Type: "t", ConfigId: "b"},
+			Action:      deploy.PlanActionUpdate,
+			RemoteID:    "1234",
+			Differences: []json.Difference{{Path: "$.name", Desired: "new", Actual: "old"}, {Path: "$.enabled", Desired: true}},
+		},
+		{Environment: "dev", Coordinate: coordinate.Coordinate{Project: "p", Type: "t", ConfigId: "c"}, Action: deploy.PlanActionUnchanged, RemoteID: "5678"},
+	}
+
+	assert.Equal(t, []string{
+		"[dev] create    p:t:a",
+		"[dev] update    p:t:b (1234)",
+		`    $.name: "old" -> "new"`,
+		"    $.enabled: <missing> -> true",
+		"[dev] unchanged p:t:c (5678)",
+		"Plan: 1 to create, 1 to update, 1 unchanged, 0 skipped",
+	}, formatPlan(changes))
+}
@@ -0,0 +1,103 @@
+/*
+ * @license
+ * Copyright 2025 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package deploy
+
+import (
+	"fmt"
+	"path"
+	"slices"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/log"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/log/field"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/coordinate"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/graph"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/manifest"
+	project "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/project/v2"
+)
+
+// configSelectors select the configs to deploy. A config is selected if it matches at least one value of each kind of
+// selector that is set.
+type configSelectors struct {
+	// configs are glob patterns matching the 'project:type:configId' coordinate of a config
+	configs []string
+	// types are glob patterns matching the type of a config
+	types []string
+	// labels are labels of which a config must have at least one
+	labels []string
+}
+
+func (s configSelectors) isEmpty() bool {
+	return len(s.configs) == 0 && len(s.types) == 0 && len(s.labels) == 0
+}
+
+func (s configSelectors) validate() error {
+	for _, pattern := range slices.Concat(s.configs, s.types) {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("invalid pattern %q: %w", pattern, err)
+		}
+	}
+	return nil
+}
+
+func (s configSelectors) matches(c config.Config) bool {
+	if len(s.configs) > 0 && !slices.ContainsFunc(s.configs, func(p string) bool { return matchesPattern(p, c.Coordinate.String()) }) {
+		return false
+	}
+	if len(s.types) > 0 && !slices.ContainsFunc(s.types, func(p string) bool { return matchesPattern(p, c.Coordinate.Type) }) {
+		return false
+	}
+	if len(s.labels) > 0 && !slices.ContainsFunc(s.labels, func(l string) bool { return slices.Contains(c.Labels, l) }) {
+		return false
+	}
+	return true
+}
+
+func matchesPattern(pattern, s string) bool {
+	matched, _ := path.Match(pattern, s) // patterns are validated upfront
+	return matched
+}
+
+// selectConfigs returns the given projects reduced to the configs matched by the given selectors, together with all
+// configs they depend on
+func selectConfigs(man *manifest.Manifest, projects []project.Project, selectors configSelectors) ([]project.Project, error) {
+	if err := selectors.validate(); err != nil {
+		return nil, err
+	}
+
+	g := graph.New(projects, man.Environments.Names())
+	selected := make(map[string]map[coordinate.Coordinate]struct{}, len(man.Environments))
+	for _, env := range man.Environments {
+		var matched []coordinate.Coordinate
+		for _, p := range projects {
+			p.ForEveryConfigInEnvironmentDo(env.Name, func(c config.Config) {
+				if selectors.matches(c) {
+					matched = append(matched, c.Coordinate)
+				}
+			})
+		}
+
+		closure, err := g.GetDependencyClosure(env.Name, matched, false)
+		if err != nil {
+			return nil, err
+		}
+		selected[env.Name] = closure
+		log.WithFields(field.Environment(env.Name, env.Group)).Info("Selected %d configuration(s) including dependencies to deploy to environment %q", len(closure), env.Name)
+	}
+
+	return filterConfigs(projects, selected), nil
+}
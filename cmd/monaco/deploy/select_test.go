@@ -0,0 +1,123 @@
+//go:build unit
+
+/*
+ * @license
+ * Copyright 2025 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package deploy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/coordinate"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter/reference"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/manifest"
+	project "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/project/v2"
+)
+
+func Test_configSelectors_matches(t *testing.T) {
+	c := config.Config{
+		Coordinate: coordinate.Coordinate{Project: "proj", Type: "builtin:alerting.profile", ConfigId: "profile"},
+		Labels:     []string{"team-a", "critical"},
+	}
+
+	tests := []struct {
+		name      string
+		selectors configSelectors
+		want      bool
+	}{
+		{"no selectors", configSelectors{}, true},
+		{"exact coordinate", configSelectors{configs: []string{"proj:builtin:alerting.profile:profile"}}, true},
+		{"coordinate pattern", configSelectors{configs: []string{"proj:*:profile"}}, true},
+		{"any coordinate pattern", configSelectors{configs: []string{"other:*", "proj:*"}}, true},
+		{"coordinate pattern not matching", configSelectors{configs: []string{"other:*"}}, false},
+		{"type", configSelectors{types: []string{"builtin:alerting.profile"}}, true},
+		{"type pattern", configSelectors{types: []string{"builtin:alerting.*"}}, true},
+		{"type not matching", configSelectors{types: []string{"dashboard"}}, false},
+		{"label", configSelectors{labels: []string{"team-b", "critical"}}, true},
+		{"label not matching", configSelectors{labels: []string{"team-b"}}, false},
+		{"all selectors must match", configSelectors{types: []string{"builtin:*"}, labels: []string{"team-b"}}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.selectors.matches(c))
+		})
+	}
+}
+
+func Test_selectConfigs(t *testing.T) {
+	zone := config.Config{
+		Coordinate: coordinate.Coordinate{Project: "proj", Type: "management-zone", ConfigId: "zone"},
+		Parameters: config.Parameters{},
+	}
+	profile := config.Config{
+		Coordinate: coordinate.Coordinate{Project: "proj", Type: "builtin:alerting.profile", ConfigId: "profile"},
+		Parameters: config.Parameters{"zoneId": reference.New("proj", "management-zone", "zone", "id")},
+		Labels:     []string{"alerting"},
+	}
+	dashboard := config.Config{
+		Coordinate: coordinate.Coordinate{Project: "proj", Type: "dashboard", ConfigId: "dashboard"},
+		Parameters: config.Parameters{},
+	}
+
+	projects := []project.Project{
+		{
+			Id: "proj",
+			Configs: project.ConfigsPerTypePerEnvironments{
+				"env": {
+					"management-zone":          {zone},
+					"builtin:alerting.profile": {profile},
+					"dashboard":                {dashboard},
+				},
+			},
+		},
+	}
+	man := &manifest.Manifest{Environments: manifest.Environments{"env": {Name: "env"}}}
+
+	coordinatesOf := func(projects []project.Project) []coordinate.Coordinate {
+		var result []coordinate.Coordinate
+		for _, p := range projects {
+			p.ForEveryConfigDo(func(c config.Config) { result = append(result, c.Coordinate) })
+		}
+		return result
+	}
+
+	t.Run("dependencies are selected", func(t *testing.T) {
+		got, err := selectConfigs(man, projects, configSelectors{labels: []string{"alerting"}})
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []coordinate.Coordinate{zone.Coordinate, profile.Coordinate}, coordinatesOf(got))
+	})
+
+	t.Run("dependents are not selected", func(t *testing.T) {
+		got, err := selectConfigs(man, projects, configSelectors{configs: []string{"proj:management-zone:*"}})
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []coordinate.Coordinate{zone.Coordinate}, coordinatesOf(got))
+	})
+
+	t.Run("nothing matches", func(t *testing.T) {
+		got, err := selectConfigs(man, projects, configSelectors{types: []string{"unknown"}})
+		require.NoError(t, err)
+		assert.Empty(t, coordinatesOf(got))
+	})
+
+	t.Run("invalid pattern", func(t *testing.T) {
+		_, err := selectConfigs(man, projects, configSelectors{configs: []string{"proj:["}})
+		assert.ErrorContains(t, err, "invalid pattern")
+	})
+}
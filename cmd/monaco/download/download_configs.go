@@ -23,7 +23,6 @@ import (
 	"github.com/spf13/afero"
 
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/cmd/monaco/dynatrace"
-	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/featureflags"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/log"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/log/field"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/secret"
@@ -31,20 +30,11 @@ import (
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/api"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/client"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config"
-	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/download/automation"
-	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/download/bucket"
-	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/download/classic"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/download/dependency_resolution"
-	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/download/document"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/download/id_extraction"
-	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/download/openpipeline"
-	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/download/segment"
-	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/download/settings"
-	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/download/slo"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/manifest"
 	manifestloader "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/manifest/loader"
 	project "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/project/v2"
-	projectv2 "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/project/v2"
 )
 
 type downloadCmdOptions struct {
@@ -212,7 +202,7 @@ func doDownloadConfigs(ctx context.Context, fs afero.Fs, clientSet *client.Clien
 	}
 
 	log.Info("Downloading from environment '%v' into project '%v'", opts.environmentURL, opts.projectName)
-	downloadedConfigs, err := downloadConfigs(ctx, clientSet, apisToDownload, opts, defaultDownloadFn)
+	downloadedConfigs, err := downloadConfigs(ctx, clientSet, apisToDownload, opts, config.Types)
 	if err != nil {
 		return err
 	}
@@ -268,138 +258,121 @@ func escapeGoTemplating(c *config.Config) error {
 	return nil
 }
 
-type downloadFn struct {
-	classicDownload      func(context.Context, client.ConfigClient, string, api.APIs, classic.ContentFilters) (projectv2.ConfigsPerType, error)
-	settingsDownload     func(context.Context, client.SettingsClient, string, settings.Filters, ...config.SettingsType) (projectv2.ConfigsPerType, error)
-	automationDownload   func(context.Context, client.AutomationClient, string, ...config.AutomationType) (projectv2.ConfigsPerType, error)
-	bucketDownload       func(context.Context, client.BucketClient, string) (projectv2.ConfigsPerType, error)
-	documentDownload     func(context.Context, client.DocumentClient, string) (projectv2.ConfigsPerType, error)
-	openPipelineDownload func(context.Context, client.OpenPipelineClient, string) (projectv2.ConfigsPerType, error)
-	segmentDownload      func(context.Context, segment.DownloadSegmentClient, string) (projectv2.ConfigsPerType, error)
-	sloDownload          func(context.Context, slo.DownloadSloClient, string) (projectv2.ConfigsPerType, error)
+// typeDownload defines when the configs of a config type are downloaded
+type typeDownload struct {
+	// selected reports whether the type is selected by the download options
+	selected func(opts downloadConfigsOptions) bool
+	// requiresToken and requiresOAuth state which credentials are needed to download the type
+	requiresToken, requiresOAuth bool
+	// requested reports whether the download is limited to the type. Missing credentials are only an error if so,
+	// otherwise the type is skipped.
+	requested func(opts downloadConfigsOptions) bool
+	// missingCredentialsErr is returned if the type is requested but the credentials it requires are missing
+	missingCredentialsErr error
+	// message is logged before the type is downloaded
+	message string
 }
 
-var defaultDownloadFn = downloadFn{
-	classicDownload:      classic.Download,
-	settingsDownload:     settings.Download,
-	automationDownload:   automation.Download,
-	bucketDownload:       bucket.Download,
-	documentDownload:     document.Download,
-	openPipelineDownload: openpipeline.Download,
-	segmentDownload:      segment.Download,
-	sloDownload:          slo.Download,
+func (d typeDownload) hasCredentials(auth manifest.Auth) bool {
+	return (!d.requiresToken || auth.Token != nil) && (!d.requiresOAuth || auth.OAuth != nil)
 }
 
-func downloadConfigs(ctx context.Context, clientSet *client.ClientSet, apisToDownload api.APIs, opts downloadConfigsOptions, fn downloadFn) (project.ConfigsPerType, error) {
-	configs := make(project.ConfigsPerType)
-	if shouldDownloadConfigs(opts) {
-		if opts.auth.Token == nil {
-			return nil, errors.New("classic client config requires token")
-		}
-		classicCfgs, err := fn.classicDownload(ctx, clientSet.ConfigClient, opts.projectName, prepareAPIs(apisToDownload, opts), classic.ApiContentFilters)
-		if err != nil {
-			return nil, err
-		}
-		copyConfigs(configs, classicCfgs)
-	}
-
-	if shouldDownloadSettings(opts) {
-		log.Info("Downloading settings objects")
-		settingCfgs, err := fn.settingsDownload(ctx, clientSet.SettingsClient, opts.projectName, settings.DefaultSettingsFilters, makeSettingTypes(opts.specificSchemas)...)
-		if err != nil {
-			return nil, err
-		}
-		copyConfigs(configs, settingCfgs)
-	}
+func always(downloadConfigsOptions) bool { return true }
+
+func never(downloadConfigsOptions) bool { return false }
+
+// typeDownloads holds when the built-in config types are downloaded. All other registered types are downloaded unless
+// the download is limited to specific built-in types.
+var typeDownloads = map[config.TypeID]typeDownload{
+	config.ClassicApiTypeID: {
+		selected:              shouldDownloadConfigs,
+		requiresToken:         true,
+		requested:             always,
+		missingCredentialsErr: errors.New("classic client config requires token"),
+	},
+	config.SettingsTypeID: {
+		selected: shouldDownloadSettings,
+		message:  "Downloading settings objects",
+	},
+	config.AutomationTypeID: {
+		selected:              shouldDownloadAutomationResources,
+		requiresOAuth:         true,
+		requested:             func(opts downloadConfigsOptions) bool { return opts.onlyAutomation },
+		missingCredentialsErr: errors.New("can't download automation resources: no OAuth credentials configured"),
+		message:               "Downloading automation resources",
+	},
+	config.BucketTypeID: {
+		selected:      shouldDownloadBuckets,
+		requiresOAuth: true,
+		requested:     never,
+		message:       "Downloading Grail buckets",
+	},
+	config.DocumentTypeID: {
+		selected:              shouldDownloadDocuments,
+		requiresOAuth:         true,
+		requested:             func(opts downloadConfigsOptions) bool { return opts.onlyDocuments },
+		missingCredentialsErr: errors.New("can't download documents: no OAuth credentials configured"),
+		message:               "Downloading documents",
+	},
+	config.OpenPipelineTypeID: {
+		selected:              shouldDownloadOpenPipeline,
+		requiresOAuth:         true,
+		requested:             func(opts downloadConfigsOptions) bool { return opts.onlyOpenPipeline },
+		missingCredentialsErr: errors.New("can't download openpipeline resources: no OAuth credentials configured"),
+	},
+	config.SegmentID: {
+		selected:              shouldDownloadSegments,
+		requiresOAuth:         true,
+		requested:             func(opts downloadConfigsOptions) bool { return opts.onlySegment },
+		missingCredentialsErr: errors.New("can't download segment resources: no OAuth credentials configured"),
+	},
+	config.ServiceLevelObjectiveID: {
+		selected:              shouldDownloadSLOsV2,
+		requiresOAuth:         true,
+		requested:             func(opts downloadConfigsOptions) bool { return opts.onlySLOV2 },
+		missingCredentialsErr: fmt.Errorf("can't download %s resources: no OAuth credentials configured", config.ServiceLevelObjectiveID),
+	},
+}
 
-	if shouldDownloadAutomationResources(opts) {
-		if opts.auth.OAuth != nil {
-			log.Info("Downloading automation resources")
-			automationCfgs, err := fn.automationDownload(ctx, clientSet.AutClient, opts.projectName)
-			if err != nil {
-				return nil, err
-			}
-			copyConfigs(configs, automationCfgs)
-		} else if opts.onlyAutomation {
-			return nil, errors.New("can't download automation resources: no OAuth credentials configured")
+// downloadConfigs downloads the configs of all types of the registry that are selected by the download options
+func downloadConfigs(ctx context.Context, clientSet *client.ClientSet, apisToDownload api.APIs, opts downloadConfigsOptions, types *config.TypeRegistry) (project.ConfigsPerType, error) {
+	configs := make(project.ConfigsPerType)
+	for _, h := range types.All() {
+		if h.Download == nil {
+			continue
 		}
-	}
 
-	if shouldDownloadBuckets(opts) && opts.auth.OAuth != nil {
-		log.Info("Downloading Grail buckets")
-		bucketCfgs, err := fn.bucketDownload(ctx, clientSet.BucketClient, opts.projectName)
-		if err != nil {
-			return nil, err
+		d, found := typeDownloads[h.ID]
+		if !found {
+			d = typeDownload{selected: shouldDownloadRegisteredTypes, message: fmt.Sprintf("Downloading configurations of type %q", h.ID)}
 		}
-		copyConfigs(configs, bucketCfgs)
-	}
-
-	if shouldDownloadDocuments(opts) {
-		if opts.auth.OAuth != nil {
-			log.Info("Downloading documents")
-			documentCfgs, err := fn.documentDownload(ctx, clientSet.DocumentClient, opts.projectName)
-			if err != nil {
-				return nil, err
-			}
-			copyConfigs(configs, documentCfgs)
-		} else if opts.onlyDocuments {
-			return nil, errors.New("can't download documents: no OAuth credentials configured")
+		if !d.selected(opts) {
+			continue
 		}
-	}
-
-	if featureflags.OpenPipeline.Enabled() {
-		if shouldDownloadOpenPipeline(opts) {
-			if opts.auth.OAuth != nil {
-				openPipelineCfgs, err := fn.openPipelineDownload(ctx, clientSet.OpenPipelineClient, opts.projectName)
-				if err != nil {
-					return nil, err
-				}
-				copyConfigs(configs, openPipelineCfgs)
-			} else if opts.onlyOpenPipeline {
-				return nil, errors.New("can't download openpipeline resources: no OAuth credentials configured")
+		if !d.hasCredentials(opts.auth) {
+			if d.requested(opts) {
+				return nil, d.missingCredentialsErr
 			}
+			continue
 		}
-	}
 
-	if featureflags.Segments.Enabled() {
-		if shouldDownloadSegments(opts) {
-			if opts.auth.OAuth != nil {
-				segmentCgfs, err := fn.segmentDownload(ctx, clientSet.SegmentClient, opts.projectName)
-				if err != nil {
-					return nil, err
-				}
-				copyConfigs(configs, segmentCgfs)
-			} else if opts.onlySegment {
-				return nil, errors.New("can't download segment resources: no OAuth credentials configured")
-			}
+		if d.message != "" {
+			log.Info(d.message)
 		}
-	}
-
-	if featureflags.ServiceLevelObjective.Enabled() {
-		if shouldDownloadSLOsV2(opts) {
-			if opts.auth.OAuth != nil {
-				sloCgfs, err := fn.sloDownload(ctx, clientSet.ServiceLevelObjectiveClient, opts.projectName)
-				if err != nil {
-					return nil, err
-				}
-				copyConfigs(configs, sloCgfs)
-			} else if opts.onlySLOV2 {
-				return nil, fmt.Errorf("can't download %s resources: no OAuth credentials configured", config.ServiceLevelObjectiveID)
-			}
+		cfgs, err := h.Download(ctx, clientSet, config.DownloadOptions{
+			ProjectName: opts.projectName,
+			APIs:        prepareAPIs(apisToDownload, opts),
+			SchemaIDs:   opts.specificSchemas,
+		})
+		if err != nil {
+			return nil, err
 		}
+		copyConfigs(configs, cfgs)
 	}
 
 	return configs, nil
 }
 
-func makeSettingTypes(specificSchemas []string) []config.SettingsType {
-	var settingTypes []config.SettingsType
-	for _, schema := range specificSchemas {
-		settingTypes = append(settingTypes, config.SettingsType{SchemaId: schema})
-	}
-	return settingTypes
-}
-
 func copyConfigs(dest, src project.ConfigsPerType) {
 	for k, v := range src {
 		dest[k] = append(dest[k], v...)
@@ -484,3 +457,15 @@ func shouldDownloadSLOsV2(opts downloadConfigsOptions) bool {
 		!opts.onlyOpenPipeline &&
 		!opts.onlySegment
 }
+
+// shouldDownloadRegisteredTypes returns true if download is not limited to a specific built-in type.
+// The built-in types are downloaded based on their own flags, see typeDownloads.
+func shouldDownloadRegisteredTypes(opts downloadConfigsOptions) bool {
+	return !opts.onlyAPIs && len(opts.specificAPIs) == 0 &&
+		!opts.onlySettings && len(opts.specificSchemas) == 0 &&
+		!opts.onlyAutomation &&
+		!opts.onlyDocuments &&
+		!opts.onlyOpenPipeline &&
+		!opts.onlySegment &&
+		!opts.onlySLOV2
+}
@@ -35,10 +35,6 @@ import (
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/client/dtclient"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/coordinate"
-	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/download/classic"
-	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/download/segment"
-	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/download/settings"
-	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/download/slo"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/manifest"
 	projectv2 "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/project/v2"
 )
@@ -177,7 +173,7 @@ func TestDownloadConfigsBehaviour(t *testing.T) {
 			settingsClient := client.NewMockSettingsClient(gomock.NewController(t))
 			tt.expectedSettingsBehaviour(settingsClient)
 
-			_, err := downloadConfigs(t.Context(), &client.ClientSet{ConfigClient: configClient, SettingsClient: settingsClient}, api.NewAPIs(), tt.givenOpts, defaultDownloadFn)
+			_, err := downloadConfigs(t.Context(), &client.ClientSet{ConfigClient: configClient, SettingsClient: settingsClient}, api.NewAPIs(), tt.givenOpts, config.Types)
 			assert.NoError(t, err)
 		})
 	}
@@ -200,6 +196,7 @@ func TestDownload_Options(t *testing.T) {
 					auth: manifest.Auth{Token: &manifest.AuthSecret{}, OAuth: &manifest.OAuth{}}, // OAuth and Token required to download whole config
 				},
 			},
+			featureFlags: map[featureflags.FeatureFlag]bool{featureflags.ServiceLevelObjective: true},
 			want: wantDownload{
 				config:       true,
 				settings:     true,
@@ -333,60 +330,28 @@ func TestDownload_Options(t *testing.T) {
 				t.Setenv(string(ff), strconv.FormatBool(v))
 			}
 
-			fn := downloadFn{
-				classicDownload: func(context.Context, client.ConfigClient, string, api.APIs, classic.ContentFilters) (projectv2.ConfigsPerType, error) {
-					if !tt.want.config {
-						t.Fatalf("classic config download was not meant to be called but was")
-					}
-					return nil, nil
-				},
-				settingsDownload: func(ctx context.Context, settingsClient client.SettingsClient, s string, filters settings.Filters, settingsType ...config.SettingsType) (projectv2.ConfigsPerType, error) {
-					if !tt.want.settings {
-						t.Fatalf("settings download was not meant to be called but was")
-					}
-					return nil, nil
-				},
-				automationDownload: func(ctx context.Context, a client.AutomationClient, s string, automationType ...config.AutomationType) (projectv2.ConfigsPerType, error) {
-					if !tt.want.automation {
-						t.Fatalf("automation download was not meant to be called but was")
-					}
-					return nil, nil
-				},
-				bucketDownload: func(ctx context.Context, b client.BucketClient, s string) (projectv2.ConfigsPerType, error) {
-					if !tt.want.bucket {
-						t.Fatalf("automation download was not meant to be called but was")
-					}
-					return nil, nil
-				},
-				documentDownload: func(ctx context.Context, b client.DocumentClient, s string) (projectv2.ConfigsPerType, error) {
-					if !tt.want.document {
-						t.Fatalf("document download was not meant to be called but was")
-					}
-					return nil, nil
-				},
-				openPipelineDownload: func(ctx context.Context, b client.OpenPipelineClient, s string) (projectv2.ConfigsPerType, error) {
-					if !tt.want.openpipeline {
-						t.Fatalf("openpipeline download was not meant to be called but was")
-					}
-					return nil, nil
-				},
-				segmentDownload: func(ctx context.Context, b segment.DownloadSegmentClient, s string) (projectv2.ConfigsPerType, error) {
-					if !tt.want.segment {
-						t.Fatalf("segment download was not meant to be called but was")
-					}
-					return nil, nil
-				},
-				sloDownload: func(ctx context.Context, b slo.DownloadSloClient, s string) (projectv2.ConfigsPerType, error) {
-					if !tt.want.slo {
-						t.Fatalf("slo-v2 download was not meant to be called but was")
-					}
+			var got wantDownload
+			download := func(called *bool) func(context.Context, *client.ClientSet, config.DownloadOptions) (map[string][]config.Config, error) {
+				return func(context.Context, *client.ClientSet, config.DownloadOptions) (map[string][]config.Config, error) {
+					*called = true
 					return nil, nil
-				},
+				}
 			}
+			types := config.NewTypeRegistry(
+				config.TypeHandler{ID: config.ClassicApiTypeID, Download: download(&got.config)},
+				config.TypeHandler{ID: config.SettingsTypeID, Download: download(&got.settings)},
+				config.TypeHandler{ID: config.AutomationTypeID, Download: download(&got.automation)},
+				config.TypeHandler{ID: config.BucketTypeID, Download: download(&got.bucket)},
+				config.TypeHandler{ID: config.DocumentTypeID, Download: download(&got.document)},
+				config.TypeHandler{ID: config.OpenPipelineTypeID, Enabled: featureflags.OpenPipeline.Enabled, Download: download(&got.openpipeline)},
+				config.TypeHandler{ID: config.SegmentID, Enabled: featureflags.Segments.Enabled, Download: download(&got.segment)},
+				config.TypeHandler{ID: config.ServiceLevelObjectiveID, Enabled: featureflags.ServiceLevelObjective.Enabled, Download: download(&got.slo)},
+			)
 
 			c := client.NewMockConfigClient(gomock.NewController(t))
-			_, err := downloadConfigs(t.Context(), &client.ClientSet{ConfigClient: c}, api.NewAPIs(), tt.given, fn)
+			_, err := downloadConfigs(t.Context(), &client.ClientSet{ConfigClient: c}, api.NewAPIs(), tt.given, types)
 			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
 		})
 	}
 }
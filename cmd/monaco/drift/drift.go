@@ -0,0 +1,201 @@
+/*
+ * @license
+ * Copyright 2025 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package drift
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/cmd/monaco/cmdutils"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/cmd/monaco/completion"
+	deploycmd "github.com/dynatrace/dynatrace-configuration-as-code/v2/cmd/monaco/deploy"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/cmd/monaco/dynatrace"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/files"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/log"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy"
+)
+
+// ExitCodeDriftDetected is the exit code of the drift command if any config drifted or is missing. If the detection
+// itself fails, the command exits with code 1 like all other commands.
+const ExitCodeDriftDetected = 2
+
+// ErrDriftDetected is returned if any config drifted or is missing
+var ErrDriftDetected = errors.New("drift detected")
+
+type driftOpts struct {
+	manifestName      string
+	environmentGroups []string
+	environments      []string
+	projects          []string
+	outputFile        string
+}
+
+// driftReport is the machine-readable result of the drift command
+type driftReport struct {
+	InSync  int            `json:"inSync"`
+	Drifted int            `json:"drifted"`
+	Missing int            `json:"missing"`
+	Configs []deploy.Drift `json:"configs"`
+}
+
+func GetDriftCommand(fs afero.Fs) (driftCmd *cobra.Command) {
+	opts := driftOpts{}
+
+	driftCmd = &cobra.Command{
+		Use:   "drift <manifest.yaml>",
+		Short: "Detect configurations that were changed or deleted on Dynatrace environments",
+		Long: "Renders all configurations for each environment and compares them with the current state of their objects on the environments. " +
+			"The result lists each configuration as 'in-sync', 'drifted' (including the differing fields), or 'missing' in JSON format. " +
+			"The command exits with code 2 if any configuration drifted or is missing, and with code 1 if the detection failed.",
+		Example:           "monaco drift manifest.yaml -e dev-environment --output-file drift.json",
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completion.DeployCompletion,
+		PreRun:            cmdutils.SilenceUsageCommand(),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.manifestName = args[0]
+
+			if !files.IsYamlFileExtension(opts.manifestName) {
+				return fmt.Errorf("wrong format for manifest file! expected a .yaml file, but got %s", opts.manifestName)
+			}
+
+			return detectDrift(cmd.Context(), fs, cmd.OutOrStdout(), opts)
+		},
+	}
+
+	driftCmd.Flags().StringSliceVarP(&opts.environments, "environment", "e", []string{},
+		"Specify one (or multiple) environment(s) to check for drift. "+
+			"To set multiple environments either repeat this flag, or separate them using a comma (,). "+
+			"This flag is mutually exclusive with '--group'.")
+	driftCmd.Flags().StringSliceVarP(&opts.environmentGroups, "group", "g", []string{},
+		"Specify one (or multiple) environmentGroup(s) to check for drift. "+
+			"To set multiple groups either repeat this flag, or separate them using a comma (,). "+
+			"This flag is mutually exclusive with '--environment'")
+	driftCmd.Flags().StringSliceVarP(&opts.projects, "project", "p", make([]string, 0), "Project configuration to check for drift (also checks any dependent configurations)")
+	driftCmd.Flags().StringVarP(&opts.outputFile, "output-file", "o", "", "File to write the JSON result to. If not set, the result is written to stdout.")
+
+	if err := driftCmd.RegisterFlagCompletionFunc("environment", completion.EnvironmentByManifestFlag); err != nil {
+		log.Fatal("failed to setup CLI %v", err)
+	}
+	if err := driftCmd.RegisterFlagCompletionFunc("project", completion.ProjectsFromManifest); err != nil {
+		log.Fatal("failed to setup CLI %v", err)
+	}
+	driftCmd.MarkFlagsMutuallyExclusive("environment", "group")
+
+	return driftCmd
+}
+
+func detectDrift(ctx context.Context, fs afero.Fs, out io.Writer, opts driftOpts) error {
+	absManifestPath, err := deploycmd.AbsPath(opts.manifestName)
+	if err != nil {
+		return fmt.Errorf("error while finding absolute path for `%s`: %w", opts.manifestName, err)
+	}
+
+	loadedManifest, err := deploycmd.LoadManifest(ctx, fs, absManifestPath, opts.environmentGroups, opts.environments)
+	if err != nil {
+		return err
+	}
+
+	if !dynatrace.VerifyEnvironmentGeneration(ctx, loadedManifest.Environments) {
+		return fmt.Errorf("unable to verify Dynatrace environment generation")
+	}
+
+	loadedProjects, err := deploycmd.LoadProjects(ctx, fs, absManifestPath, loadedManifest, opts.projects)
+	if err != nil {
+		return err
+	}
+
+	if err := deploycmd.ValidateProjectsWithEnvironments(ctx, loadedProjects, loadedManifest.Environments); err != nil {
+		return err
+	}
+
+	if err := deploycmd.ValidateAuthenticationWithProjectConfigs(loadedProjects, loadedManifest.Environments); err != nil {
+		return fmt.Errorf("manifest auth field misconfigured: %w", err)
+	}
+
+	clientSets, err := dynatrace.CreateEnvironmentClients(ctx, loadedManifest.Environments, false)
+	if err != nil {
+		return fmt.Errorf("failed to create API clients: %w", err)
+	}
+
+	drifts, detectErr := deploy.DetectDrift(ctx, loadedProjects, clientSets)
+	result := newDriftReport(drifts)
+
+	if err := writeDriftReport(fs, out, opts.outputFile, result); err != nil {
+		return fmt.Errorf("failed to write drift report: %w", err)
+	}
+
+	log.Info("Drift: %d drifted, %d missing, %d in sync", result.Drifted, result.Missing, result.InSync)
+
+	if err := resultError(result, detectErr); err != nil {
+		return err
+	}
+
+	log.Info("No drift detected")
+	return nil
+}
+
+// resultError returns the error the drift command fails with. Detected drift is returned as ErrDriftDetected with the
+// ExitCodeDriftDetected, so that it can be told apart from a failed detection.
+func resultError(r driftReport, detectErr error) error {
+	if detectErr != nil {
+		return fmt.Errorf("drift detection failed - check logs for details: %w", detectErr)
+	}
+	if r.Drifted > 0 || r.Missing > 0 {
+		return cmdutils.ExitCodeError{
+			Code: ExitCodeDriftDetected,
+			Err:  fmt.Errorf("%w: %d configuration(s) drifted, %d configuration(s) missing", ErrDriftDetected, r.Drifted, r.Missing),
+		}
+	}
+	return nil
+}
+
+func newDriftReport(drifts []deploy.Drift) driftReport {
+	r := driftReport{Configs: make([]deploy.Drift, 0, len(drifts))}
+	for _, d := range drifts {
+		switch d.Status {
+		case deploy.DriftStatusInSync:
+			r.InSync++
+		case deploy.DriftStatusDrifted:
+			r.Drifted++
+		case deploy.DriftStatusMissing:
+			r.Missing++
+		}
+		r.Configs = append(r.Configs, d)
+	}
+	return r
+}
+
+// writeDriftReport writes the given report as JSON to the output file, or to out if no output file is set
+func writeDriftReport(fs afero.Fs, out io.Writer, outputFile string, r driftReport) error {
+	b, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return err
+	}
+	b = append(b, '\n')
+
+	if outputFile == "" {
+		_, err = out.Write(b)
+		return err
+	}
+	return afero.WriteFile(fs, outputFile, b, 0644)
+}
@@ -0,0 +1,130 @@
+//go:build unit
+
+/*
+ * @license
+ * Copyright 2025 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package drift
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/cmd/monaco/cmdutils"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/json"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/coordinate"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy"
+)
+
+func Test_writeDriftReport(t *testing.T) {
+	report := newDriftReport([]deploy.Drift{
+		{Environment: "dev", Coordinate: coordinate.Coordinate{Project: "p", Type: "t", ConfigId: "a"}, Status: deploy.DriftStatusInSync, RemoteID: "1"},
+		{Environment: "dev", Coordinate: coordinate.Coordinate{Project: "p", Type: "t", ConfigId: "b"}, Status: deploy.DriftStatusDrifted, RemoteID: "2", Differences: []json.Difference{{Path: "$.name", Desired: "new", Actual: "old"}}},
+		{Environment: "dev", Coordinate: coordinate.Coordinate{Project: "p", Type: "t", ConfigId: "c"}, Status: deploy.DriftStatusMissing},
+	})
+
+	expected := `{
+  "inSync": 1,
+  "drifted": 1,
+  "missing": 1,
+  "configs": [
+    {
+      "environment": "dev",
+      "coordinate": {
+        "project": "p",
+        "type": "t",
+        "configId": "a"
+      },
+      "status": "in-sync",
+      "remoteId": "1"
+    },
+    {
+      "environment": "dev",
+      "coordinate": {
+        "project": "p",
+        "type": "t",
+        "configId": "b"
+      },
+      "status": "drifted",
+      "remoteId": "2",
+      "differences": [
+        {
+          "path": "$.name",
+          "desired": "new",
+          "actual": "old"
+        }
+      ]
+    },
+    {
+      "environment": "dev",
+      "coordinate": {
+        "project": "p",
+        "type": "t",
+        "configId": "c"
+      },
+      "status": "missing"
+    }
+  ]
+}
+`
+
+	t.Run("writes to output", func(t *testing.T) {
+		var out bytes.Buffer
+		require.NoError(t, writeDriftReport(afero.NewMemMapFs(), &out, "", report))
+		assert.Equal(t, expected, out.String())
+	})
+
+	t.Run("writes to output file", func(t *testing.T) {
+		fs := afero.NewMemMapFs()
+		var out bytes.Buffer
+		require.NoError(t, writeDriftReport(fs, &out, "drift.json", report))
+		assert.Empty(t, out.String())
+
+		content, err := afero.ReadFile(fs, "drift.json")
+		require.NoError(t, err)
+		assert.Equal(t, expected, string(content))
+	})
+
+	t.Run("empty result writes empty list", func(t *testing.T) {
+		var out bytes.Buffer
+		require.NoError(t, writeDriftReport(afero.NewMemMapFs(), &out, "", newDriftReport(nil)))
+		assert.JSONEq(t, `{"inSync": 0, "drifted": 0, "missing": 0, "configs": []}`, out.String())
+	})
+}
+
+func Test_resultError(t *testing.T) {
+	t.Run("no error if nothing drifted", func(t *testing.T) {
+		err := resultError(driftReport{InSync: 2}, nil)
+		assert.NoError(t, err)
+		assert.Equal(t, 0, cmdutils.ExitCode(err))
+	})
+
+	t.Run("drift exits with drift exit code", func(t *testing.T) {
+		err := resultError(driftReport{InSync: 1, Drifted: 1, Missing: 1}, nil)
+		assert.ErrorIs(t, err, ErrDriftDetected)
+		assert.Equal(t, ExitCodeDriftDetected, cmdutils.ExitCode(err))
+	})
+
+	t.Run("failed detection exits with general exit code", func(t *testing.T) {
+		err := resultError(driftReport{Drifted: 1}, errors.New("failed"))
+		assert.NotErrorIs(t, err, ErrDriftDetected)
+		assert.Equal(t, 1, cmdutils.ExitCode(err))
+	})
+}
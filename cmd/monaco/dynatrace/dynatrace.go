@@ -195,7 +195,10 @@ func CreateEnvironmentClients(ctx context.Context, environments manifest.Environ
 			continue
 		}
 
-		clientSet, err := client.CreateClientSet(ctx, env.URL.Value, env.Auth)
+		if env.Limits.RequestsPerSecond > 0 {
+			log.WithFields(field.Environment(env.Name, env.Group)).Info("Limiting requests to environment %q to %v per second", env.Name, env.Limits.RequestsPerSecond)
+		}
+		clientSet, err := client.CreateClientSetWithOptions(ctx, env.URL.Value, env.Auth, client.ClientOptions{RequestsPerSecond: env.Limits.RequestsPerSecond})
 		if err != nil {
 			return EnvironmentClients{}, err
 		}
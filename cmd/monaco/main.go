@@ -22,6 +22,7 @@ import (
 
 	"github.com/spf13/afero"
 
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/cmd/monaco/cmdutils"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/cmd/monaco/runner"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/featureflags"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/log"
@@ -48,10 +49,7 @@ func main() {
 	err := runner.RunCmd(ctx, cmd)
 	notifyUser(versionNotification)
 
-	if err != nil {
-		os.Exit(1)
-	}
-	os.Exit(0)
+	os.Exit(cmdutils.ExitCode(err))
 }
 
 func setVersionNotificationStr(ctx context.Context, msg *string) {
@@ -22,9 +22,11 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/cmd/monaco/account"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/cmd/monaco/bundle"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/cmd/monaco/delete"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/cmd/monaco/deploy"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/cmd/monaco/download"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/cmd/monaco/drift"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/cmd/monaco/generate"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/cmd/monaco/purge"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/cmd/monaco/supportarchive"
@@ -110,6 +112,8 @@ Examples:
 	// commands
 	rootCmd.AddCommand(download.GetDownloadCommand(fs, &download.DefaultCommand{}))
 	rootCmd.AddCommand(deploy.GetDeployCommand(fs))
+	rootCmd.AddCommand(drift.GetDriftCommand(fs))
+	rootCmd.AddCommand(bundle.GetBundleCommand(fs))
 	rootCmd.AddCommand(delete.GetDeleteCommand(fs))
 	rootCmd.AddCommand(versionCommand.GetVersionCommand())
 	rootCmd.AddCommand(generate.Command(fs))
@@ -19,6 +19,7 @@ package idutils
 import (
 	"encoding/base64"
 	"fmt"
+	"strings"
 
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/coordinate"
 )
@@ -50,6 +51,30 @@ func GenerateExternalIDForSettingsObject(c coordinate.Coordinate) (string, error
 	return fmt.Sprintf("%s%s", prefix, encodedID), nil
 }
 
+// ParseExternalIDForSettingsObject returns the coordinate an external ID was generated for by GenerateExternalIDForSettingsObject.
+// An error is returned if the external ID was not generated by monaco, or if it was shortened and can't be decoded anymore.
+func ParseExternalIDForSettingsObject(externalID string) (coordinate.Coordinate, error) {
+	encodedID, found := strings.CutPrefix(externalID, "monaco:")
+	if !found {
+		return coordinate.Coordinate{}, fmt.Errorf("external id %q was not generated by monaco", externalID)
+	}
+
+	decodedID, err := base64.StdEncoding.DecodeString(encodedID)
+	if err != nil {
+		return coordinate.Coordinate{}, fmt.Errorf("failed to decode external id %q: %w", externalID, err)
+	}
+
+	parts := strings.SplitN(string(decodedID), "$", 3)
+	switch len(parts) {
+	case 2:
+		return coordinate.Coordinate{Type: parts[0], ConfigId: parts[1]}, nil
+	case 3:
+		return coordinate.Coordinate{Project: parts[0], Type: parts[1], ConfigId: parts[2]}, nil
+	default:
+		return coordinate.Coordinate{}, fmt.Errorf("external id %q does not contain a coordinate", externalID)
+	}
+}
+
 type ExternalIDGenerator func(coordinate.Coordinate) (string, error)
 
 // GenerateExternalID generates an external ID for a configuration. It is under 50 characters long and uses at most only "a-z", "A-Z", "0-9" and "-".
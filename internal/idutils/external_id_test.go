@@ -152,3 +152,35 @@ func TestGenerateExternalID(t *testing.T) {
 		assert.True(t, strings.HasPrefix(id, "monaco-"))
 	})
 }
+
+func TestParseExternalIDForSettingsObject(t *testing.T) {
+	t.Run("with project", func(t *testing.T) {
+		c := coordinate.Coordinate{Project: "project-name", Type: "schema-id", ConfigId: "config-id"}
+		id, err := idutils.GenerateExternalIDForSettingsObject(c)
+		assert.NoError(t, err)
+
+		parsed, err := idutils.ParseExternalIDForSettingsObject(id)
+		assert.NoError(t, err)
+		assert.Equal(t, c, parsed)
+	})
+
+	t.Run("without project", func(t *testing.T) {
+		c := coordinate.Coordinate{Type: "schema-id", ConfigId: "config-id"}
+		id, err := idutils.GenerateExternalIDForSettingsObject(c)
+		assert.NoError(t, err)
+
+		parsed, err := idutils.ParseExternalIDForSettingsObject(id)
+		assert.NoError(t, err)
+		assert.Equal(t, c, parsed)
+	})
+
+	t.Run("not generated by monaco", func(t *testing.T) {
+		_, err := idutils.ParseExternalIDForSettingsObject("some-external-id")
+		assert.Error(t, err)
+	})
+
+	t.Run("not decodable", func(t *testing.T) {
+		_, err := idutils.ParseExternalIDForSettingsObject("monaco:not$base64")
+		assert.Error(t, err)
+	})
+}
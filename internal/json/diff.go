@@ -0,0 +1,89 @@
+/*
+ * @license
+ * Copyright 2025 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package json
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+)
+
+// Difference describes a single value that differs between a desired and an actual JSON document.
+// Path is a JSONPath-like expression (e.g. "$.rules[0].enabled") pointing to the differing value.
+// A nil Actual means that the value is missing in the actual document.
+type Difference struct {
+	Path    string `json:"path"`
+	Desired any    `json:"desired"`
+	Actual  any    `json:"actual"`
+}
+
+// Diff semantically compares the desired JSON document with the actual one and returns all differences.
+// Only values present in the desired document are compared - additional fields in the actual document
+// (e.g. server-managed metadata like IDs or timestamps) are ignored.
+// Arrays of differing length are reported as a single difference on the array itself.
+func Diff(desired, actual []byte) ([]Difference, error) {
+	var d, a any
+	if err := json.Unmarshal(desired, &d); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal desired JSON: %w", err)
+	}
+	if err := json.Unmarshal(actual, &a); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal actual JSON: %w", err)
+	}
+
+	return diffAny("$", d, a), nil
+}
+
+func diffAny(path string, desired, actual any) []Difference {
+	switch d := desired.(type) {
+	case map[string]any:
+		a, ok := actual.(map[string]any)
+		if !ok {
+			return []Difference{{Path: path, Desired: desired, Actual: actual}}
+		}
+
+		keys := make([]string, 0, len(d))
+		for k := range d {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+
+		var diffs []Difference
+		for _, k := range keys {
+			diffs = append(diffs, diffAny(path+"."+k, d[k], a[k])...)
+		}
+		return diffs
+
+	case []any:
+		a, ok := actual.([]any)
+		if !ok || len(a) != len(d) {
+			return []Difference{{Path: path, Desired: desired, Actual: actual}}
+		}
+
+		var diffs []Difference
+		for i := range d {
+			diffs = append(diffs, diffAny(fmt.Sprintf("%s[%d]", path, i), d[i], a[i])...)
+		}
+		return diffs
+
+	default:
+		if !reflect.DeepEqual(desired, actual) {
+			return []Difference{{Path: path, Desired: desired, Actual: actual}}
+		}
+		return nil
+	}
+}
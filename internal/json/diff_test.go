@@ -0,0 +1,96 @@
+//go:build unit
+
+/*
+ * @license
+ * Copyright 2025 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package json
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiff_Success(t *testing.T) {
+	tests := []struct {
+		name     string
+		desired  string
+		actual   string
+		expected []Difference
+	}{
+		{
+			name:    "equal documents produce no differences",
+			desired: `{"name": "a", "enabled": true}`,
+			actual:  `{"enabled": true, "name": "a"}`,
+		},
+		{
+			name:    "additional remote fields are ignored",
+			desired: `{"name": "a"}`,
+			actual:  `{"name": "a", "id": "1234", "metadata": {"version": 3}}`,
+		},
+		{
+			name:     "changed value is reported",
+			desired:  `{"name": "a"}`,
+			actual:   `{"name": "b"}`,
+			expected: []Difference{{Path: "$.name", Desired: "a", Actual: "b"}},
+		},
+		{
+			name:     "missing value is reported",
+			desired:  `{"name": "a"}`,
+			actual:   `{}`,
+			expected: []Difference{{Path: "$.name", Desired: "a", Actual: nil}},
+		},
+		{
+			name:     "nested values are reported with full path",
+			desired:  `{"rules": [{"enabled": true}, {"enabled": false}]}`,
+			actual:   `{"rules": [{"enabled": true}, {"enabled": true}]}`,
+			expected: []Difference{{Path: "$.rules[1].enabled", Desired: false, Actual: true}},
+		},
+		{
+			name:     "arrays of different length are reported as a whole",
+			desired:  `{"tags": ["a", "b"]}`,
+			actual:   `{"tags": ["a"]}`,
+			expected: []Difference{{Path: "$.tags", Desired: []any{"a", "b"}, Actual: []any{"a"}}},
+		},
+		{
+			name:     "type changes are reported",
+			desired:  `{"value": {"a": 1}}`,
+			actual:   `{"value": "a"}`,
+			expected: []Difference{{Path: "$.value", Desired: map[string]any{"a": float64(1)}, Actual: "a"}},
+		},
+		{
+			name:     "differences are sorted by key",
+			desired:  `{"b": 1, "a": 1}`,
+			actual:   `{"b": 2, "a": 2}`,
+			expected: []Difference{{Path: "$.a", Desired: float64(1), Actual: float64(2)}, {Path: "$.b", Desired: float64(1), Actual: float64(2)}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := Diff([]byte(tt.desired), []byte(tt.actual))
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func TestDiff_Errors(t *testing.T) {
+	_, err := Diff([]byte(`{`), []byte(`{}`))
+	assert.Error(t, err)
+
+	_, err = Diff([]byte(`{}`), []byte(`{`))
+	assert.Error(t, err)
+}
@@ -180,3 +180,13 @@ func MarshalIndent(jsonContent []byte) []byte {
 	}
 	return indentedData
 }
+
+// ToString returns the JSON representation of the given value, to be used in messages.
+// If the value can not be marshalled, it is formatted using fmt.Sprintf instead.
+func ToString(v any) string {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprintf("%v", v)
+	}
+	return string(b)
+}
@@ -20,6 +20,7 @@ package json
 
 import (
 	"github.com/stretchr/testify/require"
+	"math"
 	"reflect"
 	"testing"
 )
@@ -166,3 +167,38 @@ func TestMarshalIndent(t *testing.T) {
 		})
 	}
 }
+
+func TestToString(t *testing.T) {
+	tests := []struct {
+		name  string
+		input any
+		want  string
+	}{
+		{
+			name:  "String is quoted",
+			input: "value",
+			want:  `"value"`,
+		},
+		{
+			name:  "Nil is null",
+			input: nil,
+			want:  "null",
+		},
+		{
+			name:  "Map is marshalled",
+			input: map[string]any{"key": []any{1, true}},
+			want:  `{"key":[1,true]}`,
+		},
+		{
+			name:  "Value that can't be marshalled is formatted",
+			input: math.NaN(),
+			want:  "NaN",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, ToString(tt.input))
+		})
+	}
+}
@@ -0,0 +1,130 @@
+/*
+ * @license
+ * Copyright 2025 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package json
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Path is a parsed JSONPath-like expression pointing to a single value of a JSON document, e.g. "$.rules[0].enabled".
+// It uses the same syntax as the paths of a Difference. Only member names, written as ".name" or "['name']", and
+// array indexes, written as "[0]", are supported.
+type Path []pathElement
+
+type pathElement struct {
+	key     string
+	index   int
+	isIndex bool
+}
+
+// ParsePath parses the given JSONPath-like expression. It must start with the root element "$".
+func ParsePath(s string) (Path, error) {
+	rest, found := strings.CutPrefix(s, "$")
+	if !found {
+		return nil, fmt.Errorf("invalid path %q: must start with '$'", s)
+	}
+
+	var p Path
+	for rest != "" {
+		var e pathElement
+		var err error
+		switch rest[0] {
+		case '.':
+			e, rest, err = parseMember(rest[1:])
+		case '[':
+			e, rest, err = parseBracket(rest[1:])
+		default:
+			err = fmt.Errorf("unexpected character %q", rest[0])
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid path %q: %w", s, err)
+		}
+		p = append(p, e)
+	}
+	return p, nil
+}
+
+func parseMember(s string) (pathElement, string, error) {
+	end := strings.IndexAny(s, ".[")
+	if end < 0 {
+		end = len(s)
+	}
+	if end == 0 {
+		return pathElement{}, "", errors.New("empty member name")
+	}
+	return pathElement{key: s[:end]}, s[end:], nil
+}
+
+func parseBracket(s string) (pathElement, string, error) {
+	if s != "" && (s[0] == '\'' || s[0] == '"') {
+		end := strings.IndexByte(s[1:], s[0]) + 1
+		if end <= 0 || len(s) <= end+1 || s[end+1] != ']' {
+			return pathElement{}, "", errors.New("unterminated member name")
+		}
+		return pathElement{key: s[1:end]}, s[end+2:], nil
+	}
+
+	end := strings.IndexByte(s, ']')
+	if end < 0 {
+		return pathElement{}, "", errors.New("missing ']'")
+	}
+	i, err := strconv.Atoi(s[:end])
+	if err != nil || i < 0 {
+		return pathElement{}, "", fmt.Errorf("invalid array index %q", s[:end])
+	}
+	return pathElement{index: i, isIndex: true}, s[end+1:], nil
+}
+
+// Get returns the value the path points to in the given unmarshalled JSON document, and whether the value exists
+func (p Path) Get(doc any) (any, bool) {
+	v := doc
+	for _, e := range p {
+		if e.isIndex {
+			a, ok := v.([]any)
+			if !ok || e.index >= len(a) {
+				return nil, false
+			}
+			v = a[e.index]
+			continue
+		}
+
+		m, ok := v.(map[string]any)
+		if !ok {
+			return nil, false
+		}
+		if v, ok = m[e.key]; !ok {
+			return nil, false
+		}
+	}
+	return v, true
+}
+
+// Contains returns whether the other path points to the same value as this path, or to a value nested in it
+func (p Path) Contains(other Path) bool {
+	if len(other) < len(p) {
+		return false
+	}
+	for i, e := range p {
+		if other[i] != e {
+			return false
+		}
+	}
+	return true
+}
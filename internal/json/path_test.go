@@ -0,0 +1,86 @@
+//go:build unit
+
+/*
+ * @license
+ * Copyright 2025 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package json
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPath_Get(t *testing.T) {
+	var doc any
+	require.NoError(t, json.Unmarshal([]byte(`{"name": "a", "rules": [{"enabled": true}], "a.b": {"c": 1}, "empty": null}`), &doc))
+
+	tests := []struct {
+		path          string
+		expectedValue any
+		expectedFound bool
+	}{
+		{path: "$", expectedValue: doc, expectedFound: true},
+		{path: "$.name", expectedValue: "a", expectedFound: true},
+		{path: "$.rules[0].enabled", expectedValue: true, expectedFound: true},
+		{path: "$['rules'][0]['enabled']", expectedValue: true, expectedFound: true},
+		{path: `$["a.b"].c`, expectedValue: float64(1), expectedFound: true},
+		{path: "$.empty", expectedValue: nil, expectedFound: true},
+		{path: "$.rules[1]", expectedFound: false},
+		{path: "$.name.length", expectedFound: false},
+		{path: "$.missing", expectedFound: false},
+		{path: "$.rules.enabled", expectedFound: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			p, err := ParsePath(tt.path)
+			require.NoError(t, err)
+
+			v, found := p.Get(doc)
+			assert.Equal(t, tt.expectedFound, found)
+			assert.Equal(t, tt.expectedValue, v)
+		})
+	}
+}
+
+func TestParsePath_Errors(t *testing.T) {
+	for _, path := range []string{"", "name", "$.", "$..a", "$[a]", "$[-1]", "$[0", "$['a]", "$['a'", "$a"} {
+		t.Run(path, func(t *testing.T) {
+			_, err := ParsePath(path)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestPath_Contains(t *testing.T) {
+	parent, err := ParsePath("$.tiles[0]")
+	require.NoError(t, err)
+
+	for path, expected := range map[string]bool{
+		"$.tiles[0]":        true,
+		"$.tiles[0].bounds": true,
+		"$['tiles'][0].x":   true,
+		"$.tiles":           false,
+		"$.tiles[1]":        false,
+		"$.name":            false,
+	} {
+		p, err := ParsePath(path)
+		require.NoError(t, err)
+		assert.Equal(t, expected, parent.Contains(p), path)
+	}
+}
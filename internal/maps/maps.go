@@ -29,3 +29,30 @@ func ToStringMap(m map[interface{}]interface{}) map[string]interface{} {
 
 	return result
 }
+
+// ToStringKeys recursively turns all maps of the given value, as parsed from YAML, into maps with string keys.
+// Non-string keys are transformed using fmt.Sprintf. Maps and slices are copied, all other values are returned as is.
+func ToStringKeys(v interface{}) interface{} {
+	switch t := v.(type) {
+	case map[interface{}]interface{}:
+		result := make(map[string]interface{}, len(t))
+		for key, value := range t {
+			result[fmt.Sprintf("%v", key)] = ToStringKeys(value)
+		}
+		return result
+	case map[string]interface{}:
+		result := make(map[string]interface{}, len(t))
+		for key, value := range t {
+			result[key] = ToStringKeys(value)
+		}
+		return result
+	case []interface{}:
+		result := make([]interface{}, len(t))
+		for i, value := range t {
+			result[i] = ToStringKeys(value)
+		}
+		return result
+	default:
+		return v
+	}
+}
@@ -78,6 +78,43 @@ func TestToStringMap(t *testing.T) {
 	}
 }
 
+func TestToStringKeys(t *testing.T) {
+	tests := []struct {
+		name  string
+		input interface{}
+		want  interface{}
+	}{
+		{
+			"keeps scalar values",
+			"value",
+			"value",
+		},
+		{
+			"converts nested maps and lists",
+			map[interface{}]interface{}{
+				"map":  map[interface{}]interface{}{1: "one", true: []interface{}{map[interface{}]interface{}{"key": "value"}}},
+				"list": []interface{}{map[interface{}]interface{}{2: "two"}},
+			},
+			map[string]interface{}{
+				"map":  map[string]interface{}{"1": "one", "true": []interface{}{map[string]interface{}{"key": "value"}}},
+				"list": []interface{}{map[string]interface{}{"2": "two"}},
+			},
+		},
+		{
+			"converts maps nested in maps with string keys",
+			map[string]interface{}{"map": map[interface{}]interface{}{"key": "value"}},
+			map[string]interface{}{"map": map[string]interface{}{"key": "value"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ToStringKeys(tt.input); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ToStringKeys() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 // OrderInts can be used in assert.DeepEqual to order an int-slice before comparing
 var OrderInts = cmpopts.SortSlices(func(a, b int) bool {
 	return a < b
@@ -27,3 +27,52 @@ func (s MaskedString) String() string {
 func (s MaskedString) Value() string {
 	return string(s)
 }
+
+// MarshalJSON masks the value, so that it is not revealed in files or reports
+func (s MaskedString) MarshalJSON() ([]byte, error) {
+	return []byte(`"****"`), nil
+}
+
+// Reveal returns the given value with all MaskedString values, also within maps and slices, replaced by their actual
+// values. It must only be used right before the values are sent to Dynatrace, e.g. to render templates.
+func Reveal(v any) any {
+	switch t := v.(type) {
+	case MaskedString:
+		return t.Value()
+	case map[string]any:
+		result := make(map[string]any, len(t))
+		for k, val := range t {
+			result[k] = Reveal(val)
+		}
+		return result
+	case []any:
+		result := make([]any, len(t))
+		for i, val := range t {
+			result[i] = Reveal(val)
+		}
+		return result
+	default:
+		return v
+	}
+}
+
+// ContainsMasked returns whether the given value is, or contains, a MaskedString value
+func ContainsMasked(v any) bool {
+	switch t := v.(type) {
+	case MaskedString:
+		return true
+	case map[string]any:
+		for _, val := range t {
+			if ContainsMasked(val) {
+				return true
+			}
+		}
+	case []any:
+		for _, val := range t {
+			if ContainsMasked(val) {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -17,6 +17,7 @@
 package secret
 
 import (
+	"encoding/json"
 	"github.com/stretchr/testify/assert"
 	"testing"
 )
@@ -72,3 +73,25 @@ func TestSensitiveString_Value(t *testing.T) {
 		})
 	}
 }
+
+func TestSensitiveString_MarshalJSON(t *testing.T) {
+	b, err := json.Marshal(map[string]any{"token": MaskedString("password123")})
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"token": "****"}`, string(b))
+}
+
+func TestReveal(t *testing.T) {
+	given := map[string]any{
+		"token":  MaskedString("password123"),
+		"nested": map[string]any{"list": []any{MaskedString("a"), "b"}},
+		"plain":  42,
+	}
+
+	assert.True(t, ContainsMasked(given))
+	assert.Equal(t, map[string]any{
+		"token":  "password123",
+		"nested": map[string]any{"list": []any{"a", "b"}},
+		"plain":  42,
+	}, Reveal(given))
+	assert.False(t, ContainsMasked(Reveal(given)))
+}
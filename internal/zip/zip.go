@@ -18,6 +18,7 @@ package zip
 
 import (
 	"archive/zip"
+	"errors"
 	"fmt"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/multierror"
 	"github.com/spf13/afero"
@@ -25,6 +26,12 @@ import (
 	"path/filepath"
 )
 
+// Entry is an archive entry whose content is given directly instead of being read from a file
+type Entry struct {
+	Name    string
+	Content []byte
+}
+
 func Create(fs afero.Fs, zipFileName string, files []string, preservePath bool) error {
 	zipFile, err := fs.Create(zipFileName)
 	if err != nil {
@@ -32,17 +39,40 @@ func Create(fs afero.Fs, zipFileName string, files []string, preservePath bool)
 	}
 	defer zipFile.Close()
 
-	zipWriter := zip.NewWriter(zipFile)
-	defer zipWriter.Close()
+	if err := Write(zipFile, fs, files, preservePath); err != nil {
+		return fmt.Errorf("failed to create archive %s: %w", zipFileName, err)
+	}
+	return nil
+}
+
+// Write writes an archive containing the given files and directories of fs, followed by the given entries, to w.
+// If preservePath is set, files are added with their path, otherwise only with their name.
+func Write(w io.Writer, fs afero.Fs, files []string, preservePath bool, entries ...Entry) error {
+	zipWriter := zip.NewWriter(w)
 
 	var errs error
 	for _, f := range files {
-		err = addFileToZip(fs, zipWriter, f, preservePath)
+		err := addFileToZip(fs, zipWriter, f, preservePath)
 		if err != nil {
-			errs = multierror.New(errs, fmt.Errorf("unable to add %s file to archive %s: %w", f, zipFileName, err))
+			errs = multierror.New(errs, fmt.Errorf("unable to add %s file to archive: %w", f, err))
+		}
+	}
+	for _, e := range entries {
+		if err := addEntryToZip(zipWriter, e); err != nil {
+			errs = multierror.New(errs, fmt.Errorf("unable to add %s entry to archive: %w", e.Name, err))
 		}
 	}
-	return errs
+
+	return errors.Join(errs, zipWriter.Close())
+}
+
+func addEntryToZip(zipWriter *zip.Writer, e Entry) error {
+	w, err := zipWriter.Create(filepath.ToSlash(e.Name))
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(e.Content)
+	return err
 }
 func addFileToZip(fs afero.Fs, zipWriter *zip.Writer, file string, preservePath bool) error {
 	fileToZip, err := fs.Open(file)
@@ -62,11 +92,19 @@ func addFileToZip(fs afero.Fs, zipWriter *zip.Writer, file string, preservePath
 	}
 
 	if preservePath {
-		header.Name = file
+		header.Name = filepath.ToSlash(file)
 	} else {
 		header.Name = filepath.Base(file)
 	}
 
+	if fileInfo.IsDir() {
+		// directories are marked by a trailing slash and have no content
+		header.Name += "/"
+		header.Method = zip.Store
+		_, err = zipWriter.CreateHeader(header)
+		return err
+	}
+
 	zippedFile, err := zipWriter.CreateHeader(header)
 	if err != nil {
 		return err
@@ -73,9 +73,12 @@ type API struct {
 	PropertyNameOfIdentifier string
 	// NonDeletable indicates that configs of that type cannot be deleted
 	NonDeletable bool
-	// DeployWaitDuration defines the amount of time that shall elapse between deploying configs of this type.
-	// Note, that this only applies to configs within the same independent graph component
+	// DeployWaitDuration defines the amount of time that shall elapse before deploying each config of this type.
+	// Combined with a MaxConcurrency of 1, it defines the time between deploying configs of this type.
 	DeployWaitDuration time.Duration
+	// MaxConcurrency is the maximum number of configs of this type that are deployed concurrently to an environment.
+	// APIs that break when written concurrently set it to 1. If it is 0, deployments are not limited.
+	MaxConcurrency int
 }
 
 // HasParent returns true iff the API has a relation to another (parent) API.
@@ -115,6 +115,7 @@ func NewAPIs() APIs {
 			ID:                           ApplicationWeb,
 			URLPath:                      "/api/config/v1/applications/web",
 			PropertyNameOfGetAllResponse: StandardApiPropertyNameOfGetAllResponse,
+			MaxConcurrency:               1,
 		}
 
 		// ApplicationMobile has KeyUserActionsMobile and UserActionAndSessionPropertiesMobile as child APIs and so is defined here explicitly
@@ -482,6 +483,7 @@ func NewAPIs() APIs {
 						existing["domain"] == current["domain"]
 				},
 				DeployWaitDuration: time.Duration(environment.GetEnvValueIntLog(environment.KeyUserActionWebWaitSecondsEnvKey)) * time.Second,
+				MaxConcurrency:     1,
 			},
 			{
 				ID:      UserActionAndSessionPropertiesMobile,
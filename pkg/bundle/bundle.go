@@ -0,0 +1,209 @@
+/*
+ * @license
+ * Copyright 2025 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package bundle implements bundles, which are zip archives containing a manifest together with all files of its
+// projects. Each bundle contains the hash of its content, which is verified when the bundle is opened, so that exactly
+// the bundled content is deployed.
+package bundle
+
+import (
+	"archive/zip"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/fs"
+	"maps"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/spf13/afero"
+	"github.com/spf13/afero/zipfs"
+
+	zipfile "github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/zip"
+)
+
+// MetadataFile is the name of the archive entry holding the Metadata of a bundle
+const MetadataFile = "monaco-bundle.json"
+
+// Metadata describes the content of a bundle
+type Metadata struct {
+	// Manifest is the path of the manifest within the bundle
+	Manifest string `json:"manifest"`
+	// Hash is the SHA-256 hash of all files of the bundle in the form 'sha256:<hex>'
+	Hash string `json:"hash"`
+}
+
+// Bundle is an opened bundle
+type Bundle struct {
+	Metadata
+	// Fs is the read-only file system of the bundled files
+	Fs afero.Fs
+	// ManifestPath is the absolute path of the manifest in Fs
+	ManifestPath string
+}
+
+// Create writes a bundle to outputFile on fs. The manifest and all files are read from srcFs, whose root becomes the
+// root of the bundle. Paths must be relative to the root of srcFs and must not leave it. Directories are added
+// together with all files they contain. The hash of the bundle is returned.
+func Create(fs afero.Fs, outputFile string, srcFs afero.Fs, manifest string, files []string) (string, error) {
+	paths, err := collectFiles(srcFs, append([]string{manifest}, files...))
+	if err != nil {
+		return "", err
+	}
+
+	contents := make(map[string][]byte, len(paths))
+	for _, p := range paths {
+		if isDir, err := afero.IsDir(srcFs, p); err != nil {
+			return "", err
+		} else if isDir {
+			continue
+		}
+		if contents[p], err = afero.ReadFile(srcFs, p); err != nil {
+			return "", err
+		}
+	}
+
+	h := hash(contents)
+	metadata, err := json.MarshalIndent(Metadata{Manifest: filepath.ToSlash(filepath.Clean(manifest)), Hash: h}, "", "  ")
+	if err != nil {
+		return "", err
+	}
+
+	out, err := fs.Create(outputFile)
+	if err != nil {
+		return "", err
+	}
+	defer out.Close()
+
+	if err := zipfile.Write(out, srcFs, paths, true, zipfile.Entry{Name: MetadataFile, Content: metadata}); err != nil {
+		return "", fmt.Errorf("failed to write bundle %q: %w", outputFile, err)
+	}
+	return h, out.Close()
+}
+
+// collectFiles returns the given files together with all their parent directories, and all files contained in given
+// directories, sorted by path
+func collectFiles(srcFs afero.Fs, files []string) ([]string, error) {
+	result := map[string]struct{}{}
+	for _, f := range files {
+		if err := validatePath(f); err != nil {
+			return nil, err
+		}
+
+		err := afero.Walk(srcFs, f, func(p string, _ fs.FileInfo, err error) error {
+			if err != nil {
+				return err
+			}
+			if err := validatePath(p); err != nil {
+				return err
+			}
+			for dir := filepath.ToSlash(filepath.Clean(p)); dir != "."; dir = path.Dir(dir) {
+				result[dir] = struct{}{}
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return slices.Sorted(maps.Keys(result)), nil
+}
+
+func validatePath(p string) error {
+	p = filepath.ToSlash(filepath.Clean(p))
+	if path.IsAbs(p) || p == ".." || strings.HasPrefix(p, "../") {
+		return fmt.Errorf("%q is not located in the directory of the manifest and can't be bundled", p)
+	}
+	if p == MetadataFile {
+		return fmt.Errorf("%q is reserved for the metadata of bundles", p)
+	}
+	return nil
+}
+
+// hash returns the hash of the given file contents by path
+func hash(contents map[string][]byte) string {
+	h := sha256.New()
+	for _, p := range slices.Sorted(maps.Keys(contents)) {
+		_, _ = fmt.Fprintf(h, "%s\x00%d\x00", p, len(contents[p]))
+		_, _ = h.Write(contents[p])
+	}
+	return "sha256:" + hex.EncodeToString(h.Sum(nil))
+}
+
+// Open opens the bundle file on fs and verifies that its content matches its hash
+func Open(fs afero.Fs, file string) (Bundle, error) {
+	data, err := afero.ReadFile(fs, file)
+	if err != nil {
+		return Bundle{}, fmt.Errorf("failed to read bundle %q: %w", file, err)
+	}
+
+	r, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return Bundle{}, fmt.Errorf("failed to read bundle %q: %w", file, err)
+	}
+
+	var metadata *Metadata
+	contents := map[string][]byte{}
+	for _, f := range r.File {
+		if f.FileInfo().IsDir() {
+			continue
+		}
+
+		content, err := readZipFile(f)
+		if err != nil {
+			return Bundle{}, fmt.Errorf("failed to read %q of bundle %q: %w", f.Name, file, err)
+		}
+
+		if f.Name == MetadataFile {
+			metadata = &Metadata{}
+			if err := json.Unmarshal(content, metadata); err != nil {
+				return Bundle{}, fmt.Errorf("invalid metadata of bundle %q: %w", file, err)
+			}
+			continue
+		}
+		contents[f.Name] = content
+	}
+
+	if metadata == nil {
+		return Bundle{}, fmt.Errorf("%q is not a bundle: %q is missing", file, MetadataFile)
+	}
+	if actual := hash(contents); actual != metadata.Hash {
+		return Bundle{}, fmt.Errorf("content of bundle %q does not match its hash: expected %s, but got %s", file, metadata.Hash, actual)
+	}
+	if _, found := contents[metadata.Manifest]; !found {
+		return Bundle{}, fmt.Errorf("manifest %q is missing in bundle %q", metadata.Manifest, file)
+	}
+
+	return Bundle{
+		Metadata:     *metadata,
+		Fs:           afero.NewReadOnlyFs(zipfs.New(r)),
+		ManifestPath: filepath.FromSlash("/" + metadata.Manifest),
+	}, nil
+}
+
+func readZipFile(f *zip.File) ([]byte, error) {
+	rc, err := f.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+	return io.ReadAll(rc)
+}
@@ -0,0 +1,141 @@
+//go:build unit
+
+/*
+ * @license
+ * Copyright 2025 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package bundle_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/bundle"
+)
+
+func newSourceFs(t *testing.T) afero.Fs {
+	fs := afero.NewMemMapFs()
+	require.NoError(t, afero.WriteFile(fs, "manifest.yaml", []byte("manifestVersion: 1.0"), 0644))
+	require.NoError(t, afero.WriteFile(fs, "project/type/config.yaml", []byte("configs: []"), 0644))
+	require.NoError(t, afero.WriteFile(fs, "project/type/template.json", []byte("{}"), 0644))
+	require.NoError(t, afero.WriteFile(fs, "shared/template.json", []byte(`{"shared": true}`), 0644))
+	require.NoError(t, afero.WriteFile(fs, "unrelated.txt", []byte("unrelated"), 0644))
+	return fs
+}
+
+func TestCreateAndOpen(t *testing.T) {
+	fs := afero.NewMemMapFs()
+
+	hash, err := bundle.Create(fs, "out/bundle.zip", newSourceFs(t), "manifest.yaml", []string{"project", "shared/template.json"})
+	require.NoError(t, err)
+	assert.Regexp(t, "^sha256:[0-9a-f]{64}$", hash)
+
+	b, err := bundle.Open(fs, "out/bundle.zip")
+	require.NoError(t, err)
+	assert.Equal(t, hash, b.Hash)
+	assert.Equal(t, "manifest.yaml", b.Manifest)
+
+	content, err := afero.ReadFile(b.Fs, b.ManifestPath)
+	require.NoError(t, err)
+	assert.Equal(t, "manifestVersion: 1.0", string(content))
+
+	entries, err := afero.ReadDir(b.Fs, "/project/type")
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	assert.Equal(t, "config.yaml", entries[0].Name())
+	assert.Equal(t, "template.json", entries[1].Name())
+
+	content, err = afero.ReadFile(b.Fs, "/shared/template.json")
+	require.NoError(t, err)
+	assert.Equal(t, `{"shared": true}`, string(content))
+
+	exists, err := afero.Exists(b.Fs, "/unrelated.txt")
+	require.NoError(t, err)
+	assert.False(t, exists, "files not referenced must not be bundled")
+
+	assert.Error(t, afero.WriteFile(b.Fs, "/manifest.yaml", []byte("modified"), 0644), "bundles must be read-only")
+}
+
+func TestCreate_HashDependsOnContent(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	srcFs := newSourceFs(t)
+
+	hash, err := bundle.Create(fs, "bundle.zip", srcFs, "manifest.yaml", []string{"project"})
+	require.NoError(t, err)
+	sameHash, err := bundle.Create(fs, "bundle.zip", srcFs, "manifest.yaml", []string{"project"})
+	require.NoError(t, err)
+	assert.Equal(t, hash, sameHash)
+
+	require.NoError(t, afero.WriteFile(srcFs, "project/type/template.json", []byte(`{"changed": true}`), 0644))
+	otherHash, err := bundle.Create(fs, "bundle.zip", srcFs, "manifest.yaml", []string{"project"})
+	require.NoError(t, err)
+	assert.NotEqual(t, hash, otherHash)
+}
+
+func TestCreate_FailsForFilesOutsideOfManifestDirectory(t *testing.T) {
+	srcFs := afero.NewBasePathFs(newSourceFs(t), "project")
+
+	_, err := bundle.Create(afero.NewMemMapFs(), "bundle.zip", srcFs, "type/config.yaml", []string{"../shared/template.json"})
+	assert.ErrorContains(t, err, "not located in the directory of the manifest")
+}
+
+func TestOpen_FailsIfContentDoesNotMatchHash(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	_, err := bundle.Create(fs, "bundle.zip", newSourceFs(t), "manifest.yaml", []string{"project"})
+	require.NoError(t, err)
+
+	// copy the bundle, but replace the content of the template
+	original, err := afero.ReadFile(fs, "bundle.zip")
+	require.NoError(t, err)
+	r, err := zip.NewReader(bytes.NewReader(original), int64(len(original)))
+	require.NoError(t, err)
+
+	var tampered bytes.Buffer
+	w := zip.NewWriter(&tampered)
+	for _, f := range r.File {
+		if f.Name == "project/type/template.json" {
+			fw, err := w.Create(f.Name)
+			require.NoError(t, err)
+			_, err = fw.Write([]byte(`{"tampered": true}`))
+			require.NoError(t, err)
+			continue
+		}
+		require.NoError(t, w.Copy(f))
+	}
+	require.NoError(t, w.Close())
+	require.NoError(t, afero.WriteFile(fs, "tampered.zip", tampered.Bytes(), 0644))
+
+	_, err = bundle.Open(fs, "tampered.zip")
+	assert.ErrorContains(t, err, "does not match its hash")
+}
+
+func TestOpen_FailsForArchiveWithoutMetadata(t *testing.T) {
+	var buf bytes.Buffer
+	w := zip.NewWriter(&buf)
+	_, err := w.Create("manifest.yaml")
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+
+	fs := afero.NewMemMapFs()
+	require.NoError(t, afero.WriteFile(fs, "archive.zip", buf.Bytes(), 0644))
+
+	_, err = bundle.Open(fs, "archive.zip")
+	assert.ErrorContains(t, err, "is not a bundle")
+}
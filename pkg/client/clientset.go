@@ -19,10 +19,12 @@ package client
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"net/url"
 	"runtime"
 	"time"
 
+	"golang.org/x/oauth2"
 	"golang.org/x/oauth2/clientcredentials"
 
 	libAPI "github.com/dynatrace/dynatrace-configuration-as-code-core/api"
@@ -39,8 +41,10 @@ import (
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/log"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/trafficlogs"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/api"
+	clientAuth "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/client/auth"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/client/dtclient"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/client/metadata"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/client/ratelimit"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/manifest"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/version"
 )
@@ -87,6 +91,11 @@ type ConfigClient interface {
 	//	 PUT <environment-url>/api/config/v1/alertingProfiles/<id> ... with the given (or found by unique name) entity ID
 	UpsertByNonUniqueNameAndId(ctx context.Context, a api.API, entityID string, name string, payload []byte, duplicate bool) (entity dtclient.DynatraceEntity, err error)
 
+	// UpdateByID updates the existing Dynatrace config with the given id, regardless of its current name.
+	// It calls the underlying PUT endpoint for the API. E.g. for alerting profiles this would be:
+	//    PUT <environment-url>/api/config/v1/alertingProfiles/<id>
+	UpdateByID(ctx context.Context, a api.API, id string, name string, payload []byte) (entity dtclient.DynatraceEntity, err error)
+
 	// Delete removes a given config for a given API using its id.
 	// It calls the DELETE endpoint for the API. E.g. for alerting profiles this would be:
 	//    DELETE <environment-url>/api/config/v1/alertingProfiles/<id> ... to delete the config
@@ -204,6 +213,9 @@ type ClientSet struct {
 type ClientOptions struct {
 	CustomUserAgent string
 	CachingDisabled bool
+	// RequestsPerSecond limits the requests sent to the environment by all clients of the client set. If it is not
+	// positive, requests are only limited by the rate limits the environment responds with.
+	RequestsPerSecond float64
 }
 
 func (o ClientOptions) getUserAgentString() string {
@@ -255,15 +267,28 @@ func CreateClientSetWithOptions(ctx context.Context, url string, auth manifest.A
 		return nil, err
 	}
 
+	settings := restClientSettings{
+		concurrentRequestLimit: concurrentReqLimit,
+		userAgent:              opts.getUserAgentString(),
+		// all requests to the environment are paused while it throttles requests, and are limited to the requests per second
+		transport: ratelimit.NewThrottlingTransport(nil, ratelimit.NewThrottle()),
+	}
+	if opts.RequestsPerSecond > 0 {
+		settings.transport = ratelimit.NewTransport(settings.transport, ratelimit.NewLimiter(opts.RequestsPerSecond))
+	}
+	if supportarchive.IsEnabled(ctx) {
+		settings.httpListener = &rest.HTTPListener{Callback: trafficlogs.GetInstance().LogToFiles}
+	}
+
 	cFactory := clients.Factory().
-		WithConcurrentRequestLimit(concurrentReqLimit).
-		WithUserAgent(opts.getUserAgentString()).
+		WithConcurrentRequestLimit(settings.concurrentRequestLimit).
+		WithUserAgent(settings.userAgent).
 		WithRetryOptions(&DefaultRetryOptions).
-		WithRateLimiter(true)
+		WithRateLimiter(true).
+		WithHTTPListener(settings.httpListener)
 
-	if supportarchive.IsEnabled(ctx) {
-		cFactory = cFactory.WithHTTPListener(&rest.HTTPListener{Callback: trafficlogs.GetInstance().LogToFiles})
-	}
+	// OAuth clients send their requests, including token requests, using the HTTP client of the context
+	ctx = context.WithValue(ctx, oauth2.HTTPClient, &http.Client{Transport: settings.transport})
 
 	classicURL := url
 	if auth.OAuth != nil {
@@ -320,9 +345,7 @@ func CreateClientSetWithOptions(ctx context.Context, url string, auth manifest.A
 	}
 
 	if auth.Token != nil {
-		cFactory = cFactory.WithAccessToken(auth.Token.Value.Value()).
-			WithClassicURL(classicURL)
-		client, err := cFactory.CreateClassicClient()
+		client, err := settings.newClassicClient(classicURL, auth.Token.Value.Value())
 		if err != nil {
 			return nil, err
 		}
@@ -352,6 +375,33 @@ func CreateClientSetWithOptions(ctx context.Context, url string, auth manifest.A
 	}, nil
 }
 
+// restClientSettings are the settings of all REST clients of a client set. The client factory is configured with
+// them, but it can't send requests of classic clients using a custom transport, so these are created by newClassicClient.
+type restClientSettings struct {
+	concurrentRequestLimit int
+	userAgent              string
+	httpListener           *rest.HTTPListener
+	transport              http.RoundTripper
+}
+
+// newClassicClient creates a REST client for classic APIs with the same options as the client factory, which sends all
+// requests using the transport of the settings
+func (s restClientSettings) newClassicClient(classicURL string, token string) (*rest.Client, error) {
+	parsedURL, err := url.Parse(classicURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse URL %q: %w", classicURL, err)
+	}
+
+	httpClient := &http.Client{Transport: clientAuth.NewTokenAuthTransport(s.transport, token)}
+	restClient := rest.NewClient(parsedURL, httpClient,
+		rest.WithConcurrentRequestLimit(s.concurrentRequestLimit),
+		rest.WithRateLimiter(),
+		rest.WithRetryOptions(&DefaultRetryOptions),
+		rest.WithHTTPListener(s.httpListener))
+	restClient.SetHeader("User-Agent", s.userAgent)
+	return restClient, nil
+}
+
 func transformPlatformUrlToClassic(ctx context.Context, url string, auth *manifest.OAuth, client *rest.Client) (string, error) {
 	classicUrl := url
 	if auth != nil && client != nil {
@@ -17,6 +17,7 @@
 package client
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -26,6 +27,7 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"golang.org/x/oauth2"
 
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/manifest"
@@ -113,3 +115,21 @@ func TestCreateClientSet(t *testing.T) {
 		})
 	}
 }
+
+func TestCreateClientSetWithOptions_LimitsRequestsPerSecond(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		rw.WriteHeader(200)
+		_, _ = rw.Write([]byte(`{"version" : "1.300.0.20240101"}`))
+	}))
+	defer server.Close()
+
+	auth := manifest.Auth{Token: &manifest.AuthSecret{Name: "token-env-var", Value: "mock token"}}
+	clientSet, err := CreateClientSetWithOptions(t.Context(), server.URL, auth, ClientOptions{RequestsPerSecond: 0.1})
+	require.NoError(t, err)
+
+	// the only token of the limiter was consumed to query the server version while creating the client set
+	ctx, cancel := context.WithTimeout(t.Context(), 100*time.Millisecond)
+	defer cancel()
+	_, err = clientSet.SettingsClient.ListSchemas(ctx)
+	assert.Error(t, err)
+}
@@ -17,7 +17,6 @@
 package dtclient
 
 import (
-	"bytes"
 	"context"
 	"encoding/json"
 	"errors"
@@ -229,6 +228,10 @@ func (d *ConfigClient) UpsertByNonUniqueNameAndId(ctx context.Context, theApi ap
 	return d.updateDynatraceObject(ctx, objectName, entityId, theApi, body)
 }
 
+func (d *ConfigClient) UpdateByID(ctx context.Context, a api.API, id string, name string, payload []byte) (entity DynatraceEntity, err error) {
+	return d.updateDynatraceObject(ctx, name, id, a, payload)
+}
+
 func (d *ConfigClient) createDynatraceObject(ctx context.Context, objectName string, theApi api.API, payload []byte) (DynatraceEntity, error) {
 	endpoint := theApi.URLPath
 	if theApi.ID == api.KeyUserActionsMobile {
@@ -364,7 +367,7 @@ func stripCreateOnlyPropertiesFromAppMobile(payload []byte) []byte {
 // can be used in further configuration. This is a cheap way to allow monaco to work around this, by waiting, then
 // retrying in case of known errors on upload.
 func (d *ConfigClient) callWithRetryOnKnowTimingIssue(ctx context.Context, restCall SendRequestWithBody, endpoint string, requestBody []byte, theApi api.API, options corerest.RequestOptions) (*coreapi.Response, error) {
-	resp, err := coreapi.AsResponseOrError(restCall(ctx, endpoint, bytes.NewReader(requestBody), options))
+	resp, err := sendWithBody(ctx, restCall, endpoint, options, requestBody, applyRetryPolicy(ctx, RetrySetting{}))
 	if err == nil {
 		return resp, nil
 	}
@@ -28,11 +28,109 @@ import (
 	coreapi "github.com/dynatrace/dynatrace-configuration-as-code-core/api"
 	corerest "github.com/dynatrace/dynatrace-configuration-as-code-core/api/rest"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/log"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/client/ratelimit"
 )
 
 type RetrySetting struct {
 	WaitTime   time.Duration
 	MaxRetries int
+	// RequestTimeout limits the duration of each single request. Requests are not limited if it is not set.
+	RequestTimeout time.Duration
+}
+
+// RetryPolicy overrides the retry settings of requests. Values that are not set keep the settings of the request.
+type RetryPolicy struct {
+	MaxRetries     *int
+	WaitTime       time.Duration
+	RequestTimeout time.Duration
+}
+
+type retryPolicyCtxKey struct{}
+
+// NewContextWithRetryPolicy returns a context whose requests are retried according to the given policy, instead of the
+// retry settings of the respective client
+func NewContextWithRetryPolicy(ctx context.Context, p RetryPolicy) context.Context {
+	return context.WithValue(ctx, retryPolicyCtxKey{}, p)
+}
+
+// applyRetryPolicy returns the given setting, overridden by the retry policy attached to the context, if any
+func applyRetryPolicy(ctx context.Context, setting RetrySetting) RetrySetting {
+	p, ok := ctx.Value(retryPolicyCtxKey{}).(RetryPolicy)
+	if !ok {
+		return setting
+	}
+
+	if p.MaxRetries != nil {
+		setting.MaxRetries = *p.MaxRetries
+	}
+	if p.WaitTime > 0 {
+		setting.WaitTime = p.WaitTime
+	}
+	if p.RequestTimeout > 0 {
+		setting.RequestTimeout = p.RequestTimeout
+	}
+	return setting
+}
+
+// requestContext returns the context for a single request, which is limited by the request timeout of the setting
+func requestContext(ctx context.Context, setting RetrySetting) (context.Context, context.CancelFunc) {
+	if setting.RequestTimeout > 0 {
+		return context.WithTimeout(ctx, setting.RequestTimeout)
+	}
+	return context.WithCancel(ctx)
+}
+
+// sendWithBody runs a single request, limited by the request timeout of the setting
+func sendWithBody(ctx context.Context, send SendRequestWithBody, endpoint string, requestOptions corerest.RequestOptions, body []byte, setting RetrySetting) (*coreapi.Response, error) {
+	ctx, cancel := requestContext(ctx, setting)
+	defer cancel()
+	return asResponseOrThrottledError(send(ctx, endpoint, bytes.NewReader(body), requestOptions))
+}
+
+// get runs a single GET request, limited by the request timeout of the setting
+func get(ctx context.Context, c corerest.Client, endpoint string, requestOptions corerest.RequestOptions, setting RetrySetting) (*coreapi.Response, error) {
+	ctx, cancel := requestContext(ctx, setting)
+	defer cancel()
+	return asResponseOrThrottledError(c.GET(ctx, endpoint, requestOptions))
+}
+
+// throttledError is an error response that states how long to wait before retrying the request
+type throttledError struct {
+	err        error
+	retryAfter time.Duration
+}
+
+func (e throttledError) Error() string {
+	return e.err.Error()
+}
+
+func (e throttledError) Unwrap() error {
+	return e.err
+}
+
+// asResponseOrThrottledError works like coreapi.AsResponseOrError, but returns a throttledError if the response states
+// that requests are throttled
+func asResponseOrThrottledError(httpResp *http.Response, err error) (*coreapi.Response, error) {
+	if err != nil {
+		return nil, err
+	}
+
+	retryAfter, throttled := ratelimit.RetryAfter(httpResp)
+	resp, err := coreapi.AsResponseOrError(httpResp, nil)
+	if err != nil && throttled {
+		return nil, throttledError{err: err, retryAfter: retryAfter}
+	}
+	return resp, err
+}
+
+// waitTime returns how long to wait before retrying a request that failed with the given error. Throttled requests are
+// retried after the time requested by the server, others after the wait time of the setting.
+func waitTime(err error, setting RetrySetting) time.Duration {
+	var throttledErr throttledError
+	if errors.As(err, &throttledErr) {
+		return throttledErr.retryAfter
+	}
+	return setting.WaitTime
 }
 
 type RetrySettings struct {
@@ -60,14 +158,22 @@ var DefaultRetrySettings = RetrySettings{
 type SendRequestWithBody func(ctx context.Context, endpoint string, body io.Reader, options corerest.RequestOptions) (*http.Response, error)
 
 // SendWithRetry will retry to call sendWithBody for a given number of times, waiting a give duration between calls
-func SendWithRetry(ctx context.Context, sendWithBody SendRequestWithBody, endpoint string, requestOptions corerest.RequestOptions, body []byte, setting RetrySetting) (*coreapi.Response, error) {
+func SendWithRetry(ctx context.Context, send SendRequestWithBody, endpoint string, requestOptions corerest.RequestOptions, body []byte, setting RetrySetting) (*coreapi.Response, error) {
+	setting = applyRetryPolicy(ctx, setting)
+	return sendWithRetry(ctx, send, endpoint, requestOptions, body, setting, setting.WaitTime)
+}
+
+// sendWithRetry retries to call sendWithBody like SendWithRetry, but waits the given duration before the first retry
+func sendWithRetry(ctx context.Context, send SendRequestWithBody, endpoint string, requestOptions corerest.RequestOptions, body []byte, setting RetrySetting, wait time.Duration) (*coreapi.Response, error) {
 	var err error
 	var resp *coreapi.Response
 
 	for i := 0; i < setting.MaxRetries; i++ {
-		log.WithCtxFields(ctx).Warn("Failed to send HTTP request. Waiting for %s before retrying. (%d of %d).", setting.WaitTime, i, setting.MaxRetries)
-		time.Sleep(setting.WaitTime)
-		resp, err = coreapi.AsResponseOrError(sendWithBody(ctx, endpoint, bytes.NewReader(body), requestOptions))
+		log.WithCtxFields(ctx).Warn("Failed to send HTTP request. Waiting for %s before retrying. (%d of %d).", wait, i, setting.MaxRetries)
+		if err := sleep(ctx, wait); err != nil {
+			return nil, err
+		}
+		resp, err = sendWithBody(ctx, send, endpoint, requestOptions, body, setting)
 		if err == nil {
 			return resp, nil
 		}
@@ -76,14 +182,16 @@ func SendWithRetry(ctx context.Context, sendWithBody SendRequestWithBody, endpoi
 		if !errors.As(err, &apierror) {
 			return nil, err
 		}
+		wait = waitTime(err, setting)
 	}
 
 	return nil, fmt.Errorf("HTTP send request %s failed after %d retries: %w", endpoint, setting.MaxRetries, err)
 }
 
 // SendWithRetryWithInitialTry will try to call sendWithBody and if it didn't succeed call [SendWithRetry]
-func SendWithRetryWithInitialTry(ctx context.Context, sendWithBody SendRequestWithBody, endpoint string, requestOptions corerest.RequestOptions, body []byte, setting RetrySetting) (*coreapi.Response, error) {
-	resp, err := coreapi.AsResponseOrError(sendWithBody(ctx, endpoint, bytes.NewReader(body), requestOptions))
+func SendWithRetryWithInitialTry(ctx context.Context, send SendRequestWithBody, endpoint string, requestOptions corerest.RequestOptions, body []byte, setting RetrySetting) (*coreapi.Response, error) {
+	setting = applyRetryPolicy(ctx, setting)
+	resp, err := sendWithBody(ctx, send, endpoint, requestOptions, body, setting)
 	if err == nil {
 		return resp, nil
 	}
@@ -93,11 +201,12 @@ func SendWithRetryWithInitialTry(ctx context.Context, sendWithBody SendRequestWi
 		return nil, err
 	}
 
-	return SendWithRetry(ctx, sendWithBody, endpoint, requestOptions, body, setting)
+	return sendWithRetry(ctx, send, endpoint, requestOptions, body, setting, waitTime(err, setting))
 }
 
 func GetWithRetry(ctx context.Context, c corerest.Client, endpoint string, requestOptions corerest.RequestOptions, settings RetrySetting) (resp *coreapi.Response, err error) {
-	resp, err = coreapi.AsResponseOrError(c.GET(ctx, endpoint, requestOptions))
+	settings = applyRetryPolicy(ctx, settings)
+	resp, err = get(ctx, c, endpoint, requestOptions, settings)
 	if err == nil {
 		return resp, nil
 	}
@@ -110,9 +219,11 @@ func GetWithRetry(ctx context.Context, c corerest.Client, endpoint string, reque
 	url := c.BaseURL().JoinPath(endpoint).String()
 	for i := 0; i < settings.MaxRetries; i++ {
 		log.WithCtxFields(ctx).Warn("Retrying failed GET request %s (HTTP %d)", url, apierror.StatusCode)
-		time.Sleep(settings.WaitTime)
+		if err := sleep(ctx, waitTime(err, settings)); err != nil {
+			return nil, err
+		}
 
-		resp, err = coreapi.AsResponseOrError(c.GET(ctx, endpoint, requestOptions))
+		resp, err = get(ctx, c, endpoint, requestOptions, settings)
 		if err == nil {
 			return resp, nil
 		}
@@ -124,3 +235,20 @@ func GetWithRetry(ctx context.Context, c corerest.Client, endpoint string, reque
 
 	return resp, fmt.Errorf("GET request %s failed after %d retries: %w", url, settings.MaxRetries, err)
 }
+
+// sleep waits for the given duration, or until the context is done. In the latter case, the error of the context is
+// returned.
+func sleep(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return nil
+	}
+
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
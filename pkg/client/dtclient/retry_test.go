@@ -23,8 +23,10 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"strconv"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -71,6 +73,23 @@ func Test_sendWithRetryFailsAfterDefinedTries(t *testing.T) {
 	assert.Equal(t, 2, i)
 }
 
+func Test_sendWithRetryStopsWaitingIfContextIsDone(t *testing.T) {
+	i := 0
+	mockCall := SendRequestWithBody(func(ctx context.Context, url string, data io.Reader, options corerest.RequestOptions) (*http.Response, error) {
+		i++
+		return nil, coreapi.APIError{StatusCode: 400}
+	})
+
+	ctx, cancel := context.WithTimeout(t.Context(), 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err := SendWithRetryWithInitialTry(ctx, mockCall, "some/path", corerest.RequestOptions{}, []byte("body"), RetrySetting{WaitTime: time.Minute, MaxRetries: 5})
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Equal(t, 1, i)
+	assert.Less(t, time.Since(start), 10*time.Second)
+}
+
 func Test_sendWithRetryReturnContainsOriginalApiError(t *testing.T) {
 	maxRetries := 2
 	i := 0
@@ -112,3 +131,64 @@ func Test_sendWithRetryReturnsIfNotSuccess(t *testing.T) {
 	require.ErrorAs(t, err, &apiError)
 	assert.Equal(t, 400, apiError.StatusCode)
 }
+
+func Test_sendWithRetryUsesRetryPolicyOfContext(t *testing.T) {
+	i := 0
+	mockCall := SendRequestWithBody(func(ctx context.Context, url string, data io.Reader, options corerest.RequestOptions) (*http.Response, error) {
+		i++
+		return nil, coreapi.APIError{StatusCode: 400}
+	})
+
+	maxRetries := 1
+	ctx := NewContextWithRetryPolicy(t.Context(), RetryPolicy{MaxRetries: &maxRetries})
+	_, err := SendWithRetryWithInitialTry(ctx, mockCall, "some/path", corerest.RequestOptions{}, []byte("body"), RetrySetting{MaxRetries: 5})
+	require.Error(t, err)
+	assert.Equal(t, 2, i)
+}
+
+func Test_sendWithRetryLimitsRequestsByRequestTimeoutOfContext(t *testing.T) {
+	mockCall := SendRequestWithBody(func(ctx context.Context, url string, data io.Reader, options corerest.RequestOptions) (*http.Response, error) {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	})
+
+	ctx := NewContextWithRetryPolicy(t.Context(), RetryPolicy{RequestTimeout: time.Millisecond})
+	_, err := SendWithRetryWithInitialTry(ctx, mockCall, "some/path", corerest.RequestOptions{}, []byte("body"), RetrySetting{MaxRetries: 5})
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func Test_applyRetryPolicy(t *testing.T) {
+	setting := RetrySetting{WaitTime: time.Second, MaxRetries: 15}
+	assert.Equal(t, setting, applyRetryPolicy(t.Context(), setting))
+
+	zero := 0
+	ctx := NewContextWithRetryPolicy(t.Context(), RetryPolicy{MaxRetries: &zero, RequestTimeout: time.Minute})
+	assert.Equal(t, RetrySetting{WaitTime: time.Second, MaxRetries: 0, RequestTimeout: time.Minute}, applyRetryPolicy(ctx, setting))
+}
+
+func Test_sendWithRetryWaitsAsRequestedByThrottledResponse(t *testing.T) {
+	i := 0
+	mockCall := SendRequestWithBody(func(ctx context.Context, endpoint string, data io.Reader, options corerest.RequestOptions) (*http.Response, error) {
+		req, _ := http.NewRequestWithContext(ctx, http.MethodPost, "http://localhost/"+endpoint, data)
+		if i < 2 {
+			i++
+			header := http.Header{}
+			header.Set("X-RateLimit-Reset", strconv.FormatInt(time.Now().Add(100*time.Millisecond).UnixMilli(), 10))
+			return &http.Response{StatusCode: http.StatusTooManyRequests, Header: header, Body: io.NopCloser(strings.NewReader("")), Request: req}, nil
+		}
+		return &http.Response{StatusCode: 200, Body: io.NopCloser(strings.NewReader("Success")), Request: req}, nil
+	})
+
+	start := time.Now()
+	gotResp, err := SendWithRetryWithInitialTry(t.Context(), mockCall, "some/path", corerest.RequestOptions{}, []byte("body"), RetrySetting{MaxRetries: 5, WaitTime: time.Minute})
+	require.NoError(t, err)
+	assert.Equal(t, 200, gotResp.StatusCode)
+	assert.Less(t, time.Since(start), 10*time.Second, "the wait time of the setting must not be used for throttled requests")
+}
+
+func Test_waitTime(t *testing.T) {
+	setting := RetrySetting{WaitTime: 3 * time.Second}
+	assert.Equal(t, 3*time.Second, waitTime(coreapi.APIError{StatusCode: 500}, setting))
+	assert.Equal(t, 5*time.Second, waitTime(throttledError{err: coreapi.APIError{StatusCode: 429}, retryAfter: 5 * time.Second}, setting))
+	assert.Equal(t, 5*time.Second, waitTime(fmt.Errorf("wrapped: %w", throttledError{err: coreapi.APIError{StatusCode: 429}, retryAfter: 5 * time.Second}), setting))
+}
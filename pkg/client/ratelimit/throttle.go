@@ -0,0 +1,185 @@
+/*
+ * @license
+ * Copyright 2025 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/log"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/throttle"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/report"
+)
+
+const (
+	retryAfterHeader         = "Retry-After"
+	rateLimitResetHeader     = "X-RateLimit-Reset"
+	rateLimitRemainingHeader = "X-RateLimit-Remaining"
+
+	// maxPause limits the pause of a single response, in order not to block forever on bogus headers or clock skews
+	maxPause = 5 * time.Minute
+)
+
+// RetryAfter returns how long to wait before sending further requests, if the given response states that requests are
+// throttled. Requests are throttled if the response has status 429 Too Many Requests, or if no requests remain until
+// the rate limit resets. The duration is taken from the Retry-After header, or from the X-RateLimit-Reset header. If
+// neither is set, a throttled response results in the minimum wait duration.
+func RetryAfter(resp *http.Response) (time.Duration, bool) {
+	return retryAfter(resp.StatusCode, resp.Header, time.Now())
+}
+
+func retryAfter(status int, header http.Header, now time.Time) (time.Duration, bool) {
+	tooManyRequests := status == http.StatusTooManyRequests
+	if tooManyRequests || status == http.StatusServiceUnavailable {
+		if d, ok := parseRetryAfter(header.Get(retryAfterHeader), now); ok {
+			return min(d, maxPause), true
+		}
+	}
+
+	if tooManyRequests || header.Get(rateLimitRemainingHeader) == "0" {
+		if reset, ok := parseRateLimitReset(header.Get(rateLimitResetHeader)); ok {
+			return min(max(reset.Sub(now), 0), maxPause), true
+		}
+	}
+
+	if tooManyRequests {
+		return throttle.MinWaitDuration, true
+	}
+	return 0, false
+}
+
+// parseRetryAfter parses the value of a Retry-After header, which is either a number of seconds or an HTTP date
+func parseRetryAfter(v string, now time.Time) (time.Duration, bool) {
+	if v == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(v); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if t, err := http.ParseTime(v); err == nil {
+		return max(t.Sub(now), 0), true
+	}
+	return 0, false
+}
+
+// parseRateLimitReset parses the value of a X-RateLimit-Reset header, which is a Unix timestamp. Depending on the API,
+// the timestamp is given in seconds, milliseconds, or microseconds.
+func parseRateLimitReset(v string) (time.Time, bool) {
+	ts, err := strconv.ParseInt(v, 10, 64)
+	if err != nil || ts <= 0 {
+		return time.Time{}, false
+	}
+	switch {
+	case ts > 1e15:
+		return time.UnixMicro(ts), true
+	case ts > 1e12:
+		return time.UnixMilli(ts), true
+	default:
+		return time.Unix(ts, 0), true
+	}
+}
+
+// Throttle pauses all requests to an environment while the environment throttles requests
+type Throttle struct {
+	mu    sync.Mutex
+	until time.Time
+}
+
+// NewThrottle creates a new Throttle that does not pause requests until a response states that requests are throttled
+func NewThrottle() *Throttle {
+	return &Throttle{}
+}
+
+// Wait blocks until the current pause, if any, ends or the context is done. It returns how long it waited.
+func (t *Throttle) Wait(ctx context.Context) (time.Duration, error) {
+	t.mu.Lock()
+	d := time.Until(t.until)
+	t.mu.Unlock()
+
+	if d <= 0 {
+		return 0, nil
+	}
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return 0, ctx.Err()
+	case <-timer.C:
+		return d, nil
+	}
+}
+
+// Update pauses all requests if the given response states that requests are throttled. It returns the duration of
+// the pause, and whether the pause was started or extended by the response.
+func (t *Throttle) Update(resp *http.Response) (time.Duration, bool) {
+	d, throttled := RetryAfter(resp)
+	if !throttled {
+		return 0, false
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	until := time.Now().Add(d)
+	if !until.After(t.until) {
+		return d, false
+	}
+	t.until = until
+	return d, true
+}
+
+// ThrottlingTransport is a http.RoundTripper that pauses all requests sent using the same Throttle while the server
+// throttles requests
+type ThrottlingTransport struct {
+	http.RoundTripper
+	throttle *Throttle
+}
+
+// NewThrottlingTransport creates a new http transport that honours the Retry-After and X-RateLimit headers of responses
+// by pausing all requests sent using the given throttle
+func NewThrottlingTransport(baseTransport http.RoundTripper, throttle *Throttle) *ThrottlingTransport {
+	if baseTransport == nil {
+		baseTransport = http.DefaultTransport
+	}
+	return &ThrottlingTransport{
+		RoundTripper: baseTransport,
+		throttle:     throttle,
+	}
+}
+
+func (t *ThrottlingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+	if _, err := t.throttle.Wait(ctx); err != nil {
+		return nil, err
+	}
+
+	resp, err := t.RoundTripper.RoundTrip(req)
+	if err != nil {
+		return resp, err
+	}
+
+	if d, started := t.throttle.Update(resp); started {
+		msg := fmt.Sprintf("Environment throttled request %s %s (HTTP %d), pausing all requests to it for %s", req.Method, req.URL.Path, resp.StatusCode, d.Round(time.Millisecond))
+		log.WithCtxFields(ctx).Warn("%s", msg)
+		report.GetDetailerFromContextOrDiscard(ctx).Add(report.Detail{Type: report.DetailTypeWarn, Message: msg})
+	}
+	return resp, nil
+}
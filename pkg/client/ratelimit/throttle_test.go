@@ -0,0 +1,175 @@
+//go:build unit
+
+/*
+ * @license
+ * Copyright 2025 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package ratelimit
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/throttle"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/report"
+)
+
+func Test_retryAfter(t *testing.T) {
+	now := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	headers := func(kv ...string) http.Header {
+		h := http.Header{}
+		for i := 0; i < len(kv); i += 2 {
+			h.Set(kv[i], kv[i+1])
+		}
+		return h
+	}
+
+	tests := []struct {
+		name          string
+		status        int
+		header        http.Header
+		wantDuration  time.Duration
+		wantThrottled bool
+	}{
+		{
+			name:   "successful response",
+			status: http.StatusOK,
+			header: headers(rateLimitRemainingHeader, "10", rateLimitResetHeader, strconv.FormatInt(now.Add(time.Second).Unix(), 10)),
+		},
+		{
+			name:          "Retry-After in seconds",
+			status:        http.StatusTooManyRequests,
+			header:        headers(retryAfterHeader, "7"),
+			wantDuration:  7 * time.Second,
+			wantThrottled: true,
+		},
+		{
+			name:          "Retry-After as HTTP date",
+			status:        http.StatusServiceUnavailable,
+			header:        headers(retryAfterHeader, now.Add(30*time.Second).Format(http.TimeFormat)),
+			wantDuration:  30 * time.Second,
+			wantThrottled: true,
+		},
+		{
+			name:          "X-RateLimit-Reset in seconds",
+			status:        http.StatusTooManyRequests,
+			header:        headers(rateLimitResetHeader, strconv.FormatInt(now.Add(2*time.Second).Unix(), 10)),
+			wantDuration:  2 * time.Second,
+			wantThrottled: true,
+		},
+		{
+			name:          "X-RateLimit-Reset in milliseconds",
+			status:        http.StatusTooManyRequests,
+			header:        headers(rateLimitResetHeader, strconv.FormatInt(now.Add(1500*time.Millisecond).UnixMilli(), 10)),
+			wantDuration:  1500 * time.Millisecond,
+			wantThrottled: true,
+		},
+		{
+			name:          "X-RateLimit-Reset in microseconds",
+			status:        http.StatusTooManyRequests,
+			header:        headers(rateLimitResetHeader, strconv.FormatInt(now.Add(250*time.Millisecond).UnixMicro(), 10)),
+			wantDuration:  250 * time.Millisecond,
+			wantThrottled: true,
+		},
+		{
+			name:          "no remaining requests",
+			status:        http.StatusOK,
+			header:        headers(rateLimitRemainingHeader, "0", rateLimitResetHeader, strconv.FormatInt(now.Add(3*time.Second).Unix(), 10)),
+			wantDuration:  3 * time.Second,
+			wantThrottled: true,
+		},
+		{
+			name:          "reset in the past",
+			status:        http.StatusTooManyRequests,
+			header:        headers(rateLimitResetHeader, strconv.FormatInt(now.Add(-time.Second).Unix(), 10)),
+			wantDuration:  0,
+			wantThrottled: true,
+		},
+		{
+			name:          "pause is limited",
+			status:        http.StatusTooManyRequests,
+			header:        headers(retryAfterHeader, "86400"),
+			wantDuration:  maxPause,
+			wantThrottled: true,
+		},
+		{
+			name:          "too many requests without headers",
+			status:        http.StatusTooManyRequests,
+			header:        headers(),
+			wantDuration:  throttle.MinWaitDuration,
+			wantThrottled: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d, throttled := retryAfter(tt.status, tt.header, now)
+			assert.Equal(t, tt.wantThrottled, throttled)
+			assert.Equal(t, tt.wantDuration, d)
+		})
+	}
+}
+
+func TestThrottlingTransport_PausesAllRequestsSharingTheThrottle(t *testing.T) {
+	var requests atomic.Int32
+	testServer := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		if requests.Add(1) == 1 {
+			res.Header().Set(rateLimitResetHeader, strconv.FormatInt(time.Now().Add(300*time.Millisecond).UnixMilli(), 10))
+			res.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		res.WriteHeader(http.StatusOK)
+	}))
+	defer testServer.Close()
+
+	th := NewThrottle()
+	first := http.Client{Transport: NewThrottlingTransport(testServer.Client().Transport, th)}
+	second := http.Client{Transport: NewThrottlingTransport(testServer.Client().Transport, th)}
+
+	detailer := report.NewDefaultDetailer()
+	req, err := http.NewRequestWithContext(report.NewContextWithDetailer(t.Context(), detailer), http.MethodGet, testServer.URL+"/api", nil)
+	require.NoError(t, err)
+	resp, err := first.Do(req)
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+	require.Len(t, detailer.GetAll(), 1)
+	assert.Equal(t, report.DetailTypeWarn, detailer.GetAll()[0].Type)
+	assert.Contains(t, detailer.GetAll()[0].Message, "pausing all requests")
+
+	start := time.Now()
+	resp, err = second.Get(testServer.URL)
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.GreaterOrEqual(t, time.Since(start), 200*time.Millisecond)
+}
+
+func TestThrottle_WaitIsCanceledWithContext(t *testing.T) {
+	th := NewThrottle()
+	th.until = time.Now().Add(time.Hour)
+
+	ctx, cancel := context.WithTimeout(t.Context(), 10*time.Millisecond)
+	defer cancel()
+	_, err := th.Wait(ctx)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
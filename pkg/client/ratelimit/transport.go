@@ -0,0 +1,54 @@
+/*
+ * @license
+ * Copyright 2025 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package ratelimit
+
+import (
+	"net/http"
+
+	"golang.org/x/time/rate"
+)
+
+// NewLimiter creates a token-bucket limiter allowing the given number of requests per second. Bursts of up to one
+// second worth of requests, but at least one request, are allowed.
+func NewLimiter(requestsPerSecond float64) *rate.Limiter {
+	return rate.NewLimiter(rate.Limit(requestsPerSecond), max(int(requestsPerSecond), 1))
+}
+
+// Transport is a http.RoundTripper that waits for the limiter before sending each request
+type Transport struct {
+	http.RoundTripper
+	limiter *rate.Limiter
+}
+
+// NewTransport creates a new http transport that sends requests only as fast as the given limiter allows. Transports
+// sharing a limiter share its rate.
+func NewTransport(baseTransport http.RoundTripper, limiter *rate.Limiter) *Transport {
+	if baseTransport == nil {
+		baseTransport = http.DefaultTransport
+	}
+	return &Transport{
+		RoundTripper: baseTransport,
+		limiter:      limiter,
+	}
+}
+
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if err := t.limiter.Wait(req.Context()); err != nil {
+		return nil, err
+	}
+	return t.RoundTripper.RoundTrip(req)
+}
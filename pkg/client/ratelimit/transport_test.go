@@ -0,0 +1,76 @@
+//go:build unit
+
+/*
+ * @license
+ * Copyright 2025 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package ratelimit
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTransport_LimitsRequestsPerSecond(t *testing.T) {
+	testServer := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		res.WriteHeader(200)
+	}))
+	defer testServer.Close()
+
+	limiter := NewLimiter(10)
+	client := http.Client{Transport: NewTransport(testServer.Client().Transport, limiter)}
+
+	start := time.Now()
+	for range 15 { // a burst of 10 requests, then 5 requests at 10 requests per second
+		resp, err := client.Get(testServer.URL)
+		require.NoError(t, err)
+		resp.Body.Close()
+	}
+	assert.GreaterOrEqual(t, time.Since(start), 400*time.Millisecond)
+}
+
+func TestTransport_SharesLimiter(t *testing.T) {
+	testServer := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		res.WriteHeader(200)
+	}))
+	defer testServer.Close()
+
+	limiter := NewLimiter(1)
+	first := http.Client{Transport: NewTransport(testServer.Client().Transport, limiter)}
+	second := http.Client{Transport: NewTransport(testServer.Client().Transport, limiter)}
+
+	resp, err := first.Get(testServer.URL)
+	require.NoError(t, err)
+	resp.Body.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, testServer.URL, nil)
+	require.NoError(t, err)
+
+	_, err = second.Do(req)
+	assert.Error(t, err, "the second request must wait for the token consumed by the first client")
+}
+
+func TestNewLimiter(t *testing.T) {
+	assert.Equal(t, 1, NewLimiter(0.5).Burst())
+	assert.Equal(t, 20, NewLimiter(20).Burst())
+}
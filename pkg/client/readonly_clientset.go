@@ -0,0 +1,45 @@
+/*
+ * @license
+ * Copyright 2025 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package client
+
+import (
+	"context"
+	"errors"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/client/dtclient"
+)
+
+// ErrReadOnly is returned by read-only clients for all requests that would modify remote objects
+var ErrReadOnly = errors.New("client is read-only")
+
+var _ SettingsClient = (*ReadOnlySettingsClient)(nil)
+
+// ReadOnlySettingsClient wraps a SettingsClient and refuses all requests that would modify settings objects. It is
+// used to read actual remote objects in dry-runs.
+type ReadOnlySettingsClient struct {
+	SettingsClient
+}
+
+// Upsert implements SettingsClient and always returns ErrReadOnly
+func (c ReadOnlySettingsClient) Upsert(context.Context, dtclient.SettingsObject, dtclient.UpsertSettingsOptions) (dtclient.DynatraceEntity, error) {
+	return dtclient.DynatraceEntity{}, ErrReadOnly
+}
+
+// Delete implements SettingsClient and always returns ErrReadOnly
+func (c ReadOnlySettingsClient) Delete(context.Context, string) error {
+	return ErrReadOnly
+}
@@ -18,6 +18,7 @@ package config
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/json"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/coordinate"
@@ -25,11 +26,15 @@ import (
 	configErrors "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/errors"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter"
 	compoundParam "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter/compound"
+	conditionalParam "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter/conditional"
 	envParam "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter/environment"
 	fileParam "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter/file"
+	jsonPathParam "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter/jsonpath"
 	listParam "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter/list"
 	refParam "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter/reference"
+	secretParam "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter/secret"
 	valueParam "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter/value"
+	variableParam "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter/variable"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/template"
 )
 
@@ -91,10 +96,97 @@ type Config struct {
 	// Skip flag indicates if the deployment of this configuration should be skipped. It is resolved during project loading.
 	Skip bool
 
+	// Labels are free-form labels of the configuration, which can be used to select configurations
+	Labels []string
+
+	// Hooks are local commands that are run around the deployment of the configuration
+	Hooks Hooks
+
+	// DeploymentPolicy overrides how the deployment of the configuration is retried and timed out
+	DeploymentPolicy DeploymentPolicy
+
+	// Verification defines the checks of the deployed object after deploying the configuration
+	Verification Verification
+
 	// OriginObjectId is the DT object ID of the object when it was downloaded from an environment
 	OriginObjectId string
 }
 
+// Hooks are local shell commands that are run around a deployment. Empty commands are not run.
+type Hooks struct {
+	// PreDeploy is run before deploying. If it fails, the deployment fails.
+	PreDeploy string
+	// PostDeploy is run after a successful deployment
+	PostDeploy string
+	// OnError is run after a failed deployment
+	OnError string
+}
+
+// Verification defines how the remote object of a config is verified after deploying the config. A verification
+// fails if any of its checks fails.
+type Verification struct {
+	// Expectations are values the deployed object must contain
+	Expectations []Expectation
+	// EqualsRendered states that all values of the rendered config must be equal in the deployed object
+	EqualsRendered bool
+	// Ignore are the paths of values of the rendered config that are not compared if EqualsRendered is set
+	Ignore []string
+}
+
+// IsEmpty returns whether the verification doesn't check anything
+func (v Verification) IsEmpty() bool {
+	return len(v.Expectations) == 0 && !v.EqualsRendered
+}
+
+// Expectation is a value the deployed object must contain
+type Expectation struct {
+	// Path is the JSONPath-like expression pointing to the value, e.g. "$.rules[0].enabled"
+	Path string
+	// Value is the expected value in the form of unmarshalled JSON
+	Value any
+}
+
+// DeploymentPolicy defines how the deployment of a config is retried and timed out. Values that are not set keep the
+// default behaviour of the config type.
+type DeploymentPolicy struct {
+	// MaxRetries is the maximum number of retries of failed requests
+	MaxRetries *int
+	// Backoff is the time to wait between retries
+	Backoff time.Duration
+	// RequestTimeout limits the duration of each single request
+	RequestTimeout time.Duration
+	// Deadline limits the overall duration of the deployment of the config, including all retries
+	Deadline time.Duration
+	// MaxConcurrency is the maximum number of configs of the config's Settings 2.0 schema that are deployed
+	// concurrently to an environment. It applies to all configs of the schema. If it is 0, the schema is not limited.
+	MaxConcurrency int
+}
+
+// With returns the policy overridden by all values that are set in the given policy
+func (p DeploymentPolicy) With(override DeploymentPolicy) DeploymentPolicy {
+	if override.MaxRetries != nil {
+		p.MaxRetries = override.MaxRetries
+	}
+	if override.Backoff > 0 {
+		p.Backoff = override.Backoff
+	}
+	if override.RequestTimeout > 0 {
+		p.RequestTimeout = override.RequestTimeout
+	}
+	if override.Deadline > 0 {
+		p.Deadline = override.Deadline
+	}
+	if override.MaxConcurrency > 0 {
+		p.MaxConcurrency = override.MaxConcurrency
+	}
+	return p
+}
+
+// IsEmpty returns whether no value of the policy is set
+func (p DeploymentPolicy) IsEmpty() bool {
+	return p == DeploymentPolicy{}
+}
+
 func (c *Config) Render(properties map[string]interface{}) (string, error) {
 	if c == nil || c.Template == nil {
 		return "", nil
@@ -148,6 +240,10 @@ var DefaultParameterParsers = map[string]parameter.ParameterSerDe{
 	compoundParam.CompoundParameterType:       compoundParam.CompoundParameterSerde,
 	listParam.ListParameterType:               listParam.ListParameterSerde,
 	fileParam.FileParameterType:               fileParam.FileParameterSerde,
+	secretParam.SecretParameterType:           secretParam.SecretParameterSerde,
+	jsonPathParam.JSONPathParameterType:       jsonPathParam.JSONPathParameterSerde,
+	conditionalParam.ConditionalParameterType: conditionalParam.ConditionalParameterSerde,
+	variableParam.VariableParameterType:       variableParam.VariableParameterSerde,
 }
 
 func (c *Config) References() []coordinate.Coordinate {
@@ -228,6 +324,8 @@ func GetNameForConfig(c Config) (any, error) {
 		return v.ResolveValue(parameter.ResolveContext{ParameterName: NameParameter})
 	case *envParam.EnvironmentVariableParameter:
 		return v.ResolveValue(parameter.ResolveContext{ParameterName: NameParameter})
+	case *variableParam.VariableParameter:
+		return v.ResolveValue(parameter.ResolveContext{ParameterName: NameParameter})
 	default:
 		return c.Parameters[NameParameter], nil
 	}
@@ -38,6 +38,15 @@ type ResolvedEntity struct {
 	// Skip flag indicating that this entity was skipped
 	// if an entity is skipped, there will be no properties
 	Skip bool
+
+	// Response is the body of the API response to the deployment of the config.
+	// For classic APIs and Settings 2.0, whose responses only identify the
+	// created object, it contains the ID and name of the object as JSON.
+	Response []byte
+
+	// DryRun flag indicating that the config was not actually deployed, e.g.
+	// during a dry-run, and that Response is not the response of an actual deployment
+	DryRun bool
 }
 
 // ResolvePropValue retrieves the value associated with the specified key in a nested map.
@@ -17,6 +17,7 @@ package compound
 import (
 	"bytes"
 	"fmt"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/secret"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/strings"
 	template2 "github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/template"
 	"github.com/google/go-cmp/cmp"
@@ -72,14 +73,23 @@ func (p *CompoundParameter) ResolveValue(context parameter.ResolveContext) (inte
 	}
 
 	out := bytes.Buffer{}
-	err := p.format.Execute(&out, compoundData)
+	err := p.format.Execute(&out, secret.Reveal(compoundData))
 
 	if err != nil {
 		return nil, fmt.Errorf("error resolving compound value: %w", err)
 	}
 
 	str := out.String()
-	return template2.EscapeSpecialCharactersInValue(str, template2.FullStringEscapeFunction)
+	escaped, err := template2.EscapeSpecialCharactersInValue(str, template2.FullStringEscapeFunction)
+	if err != nil {
+		return nil, err
+	}
+
+	// values composed of secrets are secrets themselves
+	if secret.ContainsMasked(compoundData) {
+		return secret.MaskedString(escaped.(string)), nil
+	}
+	return escaped, nil
 
 }
 
@@ -17,6 +17,7 @@
 package compound
 
 import (
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/secret"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/strings"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -125,6 +126,43 @@ func TestResolveValue(t *testing.T) {
 	assert.Equal(t, "Hello World!", strings.ToString(result))
 }
 
+func TestResolveValue_ComposedOfSecret(t *testing.T) {
+	context := parameter.ResolveContext{
+		ResolvedParameterValues: parameter.Properties{
+			"token": secret.MaskedString("the-token"),
+		},
+	}
+	compoundParameter, err := New("testName", "Bearer {{ .token }}", []parameter.ParameterReference{
+		{Property: "token"},
+	})
+	require.NoError(t, err)
+
+	result, err := compoundParameter.ResolveValue(context)
+	require.NoError(t, err)
+
+	assert.Equal(t, secret.MaskedString("Bearer the-token"), result)
+}
+
+func TestResolveValue_UsesTemplateFunctions(t *testing.T) {
+	context := parameter.ResolveContext{
+		ResolvedParameterValues: parameter.Properties{
+			"name": "my-app",
+			"tags": `[ "a","b" ]`,
+		},
+	}
+	compoundParameter, err := New("testName", `{{ .name | upper | replace "-" "_" }}: {{ .tags | join ";" }} {{ .missing | default "none" }}`, []parameter.ParameterReference{
+		{Property: "name"},
+		{Property: "tags"},
+		{Property: "missing"},
+	})
+	require.NoError(t, err)
+
+	result, err := compoundParameter.ResolveValue(context)
+	require.NoError(t, err)
+
+	assert.Equal(t, "MY_APP: a;b none", strings.ToString(result))
+}
+
 func TestResolveComplexValue(t *testing.T) {
 	testFormat := "{{ .person.name }} is {{ .person.age }} years old"
 	context := parameter.ResolveContext{
@@ -0,0 +1,291 @@
+/*
+ * @license
+ * Copyright 2025 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package conditional
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/maps"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/secret"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/strings"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/template"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/coordinate"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/entities"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter"
+)
+
+// ConditionalParameterType specifies the type of the parameter used in config files
+const ConditionalParameterType = "conditional"
+
+var ConditionalParameterSerde = parameter.ParameterSerDe{
+	Serializer:   writeConditionalParameter,
+	Deserializer: parseConditionalParameter,
+}
+
+// ConditionalParameter evaluates to the value of the first case whose condition matches, or to the default value if
+// none matches.
+type ConditionalParameter struct {
+	Cases   []Case
+	Default interface{}
+
+	references []parameter.ParameterReference
+}
+
+// Case is a value that is used if its condition matches
+type Case struct {
+	When  Condition
+	Value interface{}
+}
+
+// Condition matches if all of its set fields match
+type Condition struct {
+	// Environments of which the config's environment must be one
+	Environments []string
+	// Groups of which the config's environment group must be one
+	Groups []string
+	// Parameter is the name of another parameter of the same config, whose value must be equal to Equals
+	Parameter string
+	Equals    interface{}
+}
+
+func New(config coordinate.Coordinate, cases []Case, defaultValue interface{}) *ConditionalParameter {
+	var references []parameter.ParameterReference
+	for _, c := range cases {
+		if c.When.Parameter != "" {
+			references = append(references, parameter.ParameterReference{Config: config, Property: c.When.Parameter})
+		}
+	}
+
+	return &ConditionalParameter{
+		Cases:      cases,
+		Default:    defaultValue,
+		references: references,
+	}
+}
+
+// this forces the compiler to check if ConditionalParameter is of type Parameter
+var _ parameter.Parameter = (*ConditionalParameter)(nil)
+
+func (p *ConditionalParameter) GetType() string {
+	return ConditionalParameterType
+}
+
+// GetReferences returns the parameters the conditions depend on, so that they are resolved first
+func (p *ConditionalParameter) GetReferences() []parameter.ParameterReference {
+	return p.references
+}
+
+func (p *ConditionalParameter) ResolveValue(context parameter.ResolveContext) (interface{}, error) {
+	for _, c := range p.Cases {
+		matches, err := c.When.matches(context)
+		if err != nil {
+			return nil, parameter.NewParameterResolveValueError(context, err.Error())
+		}
+		if matches {
+			return template.EscapeSpecialCharactersInValue(c.Value, template.FullStringEscapeFunction)
+		}
+	}
+	return template.EscapeSpecialCharactersInValue(p.Default, template.FullStringEscapeFunction)
+}
+
+func (c Condition) matches(context parameter.ResolveContext) (bool, error) {
+	if len(c.Environments) > 0 && !slices.Contains(c.Environments, context.Environment) {
+		return false, nil
+	}
+	if len(c.Groups) > 0 && !slices.Contains(c.Groups, context.Group) {
+		return false, nil
+	}
+	if c.Parameter == "" {
+		return true, nil
+	}
+
+	m := make(map[interface{}]any, len(context.ResolvedParameterValues))
+	for k, v := range context.ResolvedParameterValues {
+		m[k] = v
+	}
+	val, found := entities.ResolvePropValue(c.Parameter, m)
+	if !found {
+		return false, fmt.Errorf("parameter %q has not been resolved yet or does not exist", c.Parameter)
+	}
+
+	// resolved values are escaped, so the expected value has to be escaped the same way to compare them
+	expected, err := template.EscapeSpecialCharactersInValue(c.Equals, template.FullStringEscapeFunction)
+	if err != nil {
+		return false, err
+	}
+	return fmt.Sprint(secret.Reveal(val)) == fmt.Sprint(expected), nil
+}
+
+const conditionsField = "conditions"
+const whenField = "when"
+const valueField = "value"
+const defaultField = "default"
+const environmentField = "environment"
+const groupField = "group"
+const parameterField = "parameter"
+const equalsField = "equals"
+
+// parseConditionalParameter parses a given context into an instance of ConditionalParameter.
+// It requires a list of `conditions`, each with a `when` clause and a `value`, and a `default` value.
+func parseConditionalParameter(context parameter.ParameterParserContext) (parameter.Parameter, error) {
+	rawConditions, ok := context.Value[conditionsField].([]interface{})
+	if !ok || len(rawConditions) == 0 {
+		return nil, parameter.NewParameterParserError(context, fmt.Sprintf("`%s` must be a non-empty list", conditionsField))
+	}
+
+	defaultValue, ok := context.Value[defaultField]
+	if !ok {
+		return nil, parameter.NewParameterParserError(context, fmt.Sprintf("missing property `%s`", defaultField))
+	}
+
+	cases := make([]Case, 0, len(rawConditions))
+	for i, raw := range rawConditions {
+		c, err := parseCase(raw)
+		if err != nil {
+			return nil, parameter.NewParameterParserError(context, fmt.Sprintf("invalid condition %d: %s", i+1, err))
+		}
+		if c.When.Parameter == context.ParameterName {
+			return nil, parameter.NewParameterParserError(context, fmt.Sprintf("invalid condition %d: parameter cannot depend on itself", i+1))
+		}
+		cases = append(cases, c)
+	}
+
+	return New(context.Coordinate, cases, defaultValue), nil
+}
+
+func parseCase(raw interface{}) (Case, error) {
+	m, ok := toStringMap(raw)
+	if !ok {
+		return Case{}, fmt.Errorf("must be a map with `%s` and `%s`", whenField, valueField)
+	}
+
+	value, ok := m[valueField]
+	if !ok {
+		return Case{}, fmt.Errorf("missing property `%s`", valueField)
+	}
+
+	when, ok := toStringMap(m[whenField])
+	if !ok || len(when) == 0 {
+		return Case{}, fmt.Errorf("`%s` must be a map of `%s`, `%s` or `%s` and `%s`", whenField, environmentField, groupField, parameterField, equalsField)
+	}
+
+	var condition Condition
+	for k, v := range when {
+		var err error
+		switch k {
+		case environmentField:
+			condition.Environments, err = toStringList(k, v)
+		case groupField:
+			condition.Groups, err = toStringList(k, v)
+		case parameterField:
+			condition.Parameter = strings.ToString(v)
+			if condition.Parameter == "" {
+				err = fmt.Errorf("`%s` must not be empty", parameterField)
+			}
+		case equalsField:
+			condition.Equals = v
+		default:
+			err = fmt.Errorf("unknown property `%s` in `%s`", k, whenField)
+		}
+		if err != nil {
+			return Case{}, err
+		}
+	}
+
+	_, hasEquals := when[equalsField]
+	if (condition.Parameter != "") != hasEquals {
+		return Case{}, fmt.Errorf("`%s` and `%s` must be set together", parameterField, equalsField)
+	}
+
+	return Case{When: condition, Value: value}, nil
+}
+
+// toStringMap converts maps parsed from YAML to maps with string keys
+func toStringMap(v interface{}) (map[string]interface{}, bool) {
+	m, ok := maps.ToStringKeys(v).(map[string]interface{})
+	return m, ok
+}
+
+// toStringList accepts a single string or a list of strings
+func toStringList(field string, v interface{}) ([]string, error) {
+	switch l := v.(type) {
+	case string:
+		if l != "" {
+			return []string{l}, nil
+		}
+	case []interface{}:
+		result := make([]string, 0, len(l))
+		for _, e := range l {
+			s, ok := e.(string)
+			if !ok || s == "" {
+				return nil, fmt.Errorf("`%s` must only contain non-empty strings, but found %v", field, e)
+			}
+			result = append(result, s)
+		}
+		if len(result) > 0 {
+			return result, nil
+		}
+	}
+	return nil, fmt.Errorf("`%s` must be a non-empty string or a list of strings", field)
+}
+
+func writeConditionalParameter(context parameter.ParameterWriterContext) (map[string]interface{}, error) {
+	conditionalParam, ok := context.Parameter.(*ConditionalParameter)
+
+	if !ok {
+		return nil, parameter.NewParameterWriterError(context, "unexpected type. parameter is not of type `ConditionalParameter`")
+	}
+
+	conditions := make([]interface{}, 0, len(conditionalParam.Cases))
+	for _, c := range conditionalParam.Cases {
+		when := make(map[string]interface{})
+		if len(c.When.Environments) > 0 {
+			when[environmentField] = fromStringList(c.When.Environments)
+		}
+		if len(c.When.Groups) > 0 {
+			when[groupField] = fromStringList(c.When.Groups)
+		}
+		if c.When.Parameter != "" {
+			when[parameterField] = c.When.Parameter
+			when[equalsField] = c.When.Equals
+		}
+
+		conditions = append(conditions, map[string]interface{}{
+			whenField:  when,
+			valueField: c.Value,
+		})
+	}
+
+	return map[string]interface{}{
+		conditionsField: conditions,
+		defaultField:    conditionalParam.Default,
+	}, nil
+}
+
+// fromStringList writes single values as string, and multiple values as list
+func fromStringList(l []string) interface{} {
+	if len(l) == 1 {
+		return l[0]
+	}
+
+	result := make([]interface{}, len(l))
+	for i, s := range l {
+		result[i] = s
+	}
+	return result
+}
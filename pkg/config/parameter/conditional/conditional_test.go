@@ -0,0 +1,194 @@
+//go:build unit
+
+/*
+ * @license
+ * Copyright 2025 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package conditional
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/secret"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/coordinate"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter"
+)
+
+var testCoordinate = coordinate.Coordinate{Project: "project", Type: "dashboard", ConfigId: "dashboard-1"}
+
+func TestParseConditionalParameter(t *testing.T) {
+	param, err := parseConditionalParameter(parameter.ParameterParserContext{
+		Coordinate:    testCoordinate,
+		ParameterName: "threshold",
+		Value: map[string]interface{}{
+			"conditions": []interface{}{
+				map[interface{}]interface{}{"when": map[interface{}]interface{}{"environment": "prod"}, "value": 100},
+				map[interface{}]interface{}{"when": map[interface{}]interface{}{"group": []interface{}{"staging", "test"}}, "value": 50},
+				map[interface{}]interface{}{"when": map[interface{}]interface{}{"parameter": "tier", "equals": "gold"}, "value": 80},
+			},
+			"default": 10,
+		},
+	})
+	require.NoError(t, err)
+
+	conditionalParam, ok := param.(*ConditionalParameter)
+	require.True(t, ok, "parsed parameter should be conditional parameter")
+	assert.Equal(t, "conditional", conditionalParam.GetType())
+	assert.Equal(t, []Case{
+		{When: Condition{Environments: []string{"prod"}}, Value: 100},
+		{When: Condition{Groups: []string{"staging", "test"}}, Value: 50},
+		{When: Condition{Parameter: "tier", Equals: "gold"}, Value: 80},
+	}, conditionalParam.Cases)
+	assert.Equal(t, 10, conditionalParam.Default)
+	assert.Equal(t, []parameter.ParameterReference{{Config: testCoordinate, Property: "tier"}}, conditionalParam.GetReferences())
+}
+
+func TestParseConditionalParameter_Errors(t *testing.T) {
+	tests := []struct {
+		name  string
+		value map[string]interface{}
+	}{
+		{
+			"missing conditions",
+			map[string]interface{}{"default": 1},
+		},
+		{
+			"empty conditions",
+			map[string]interface{}{"conditions": []interface{}{}, "default": 1},
+		},
+		{
+			"missing default",
+			map[string]interface{}{"conditions": []interface{}{map[interface{}]interface{}{"when": map[interface{}]interface{}{"environment": "prod"}, "value": 1}}},
+		},
+		{
+			"condition not a map",
+			map[string]interface{}{"conditions": []interface{}{"prod"}, "default": 1},
+		},
+		{
+			"missing value",
+			map[string]interface{}{"conditions": []interface{}{map[interface{}]interface{}{"when": map[interface{}]interface{}{"environment": "prod"}}}, "default": 1},
+		},
+		{
+			"missing when",
+			map[string]interface{}{"conditions": []interface{}{map[interface{}]interface{}{"value": 1}}, "default": 1},
+		},
+		{
+			"empty when",
+			map[string]interface{}{"conditions": []interface{}{map[interface{}]interface{}{"when": map[interface{}]interface{}{}, "value": 1}}, "default": 1},
+		},
+		{
+			"unknown property in when",
+			map[string]interface{}{"conditions": []interface{}{map[interface{}]interface{}{"when": map[interface{}]interface{}{"region": "eu"}, "value": 1}}, "default": 1},
+		},
+		{
+			"invalid environment",
+			map[string]interface{}{"conditions": []interface{}{map[interface{}]interface{}{"when": map[interface{}]interface{}{"environment": []interface{}{1}}, "value": 1}}, "default": 1},
+		},
+		{
+			"parameter without equals",
+			map[string]interface{}{"conditions": []interface{}{map[interface{}]interface{}{"when": map[interface{}]interface{}{"parameter": "tier"}, "value": 1}}, "default": 1},
+		},
+		{
+			"equals without parameter",
+			map[string]interface{}{"conditions": []interface{}{map[interface{}]interface{}{"when": map[interface{}]interface{}{"equals": "gold"}, "value": 1}}, "default": 1},
+		},
+		{
+			"parameter referencing itself",
+			map[string]interface{}{"conditions": []interface{}{map[interface{}]interface{}{"when": map[interface{}]interface{}{"parameter": "threshold", "equals": 1}, "value": 1}}, "default": 1},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parseConditionalParameter(parameter.ParameterParserContext{Coordinate: testCoordinate, ParameterName: "threshold", Value: tt.value})
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestResolveValue(t *testing.T) {
+	param := New(testCoordinate, []Case{
+		{When: Condition{Environments: []string{"prod"}}, Value: "prod value"},
+		{When: Condition{Groups: []string{"staging"}, Parameter: "tier", Equals: "gold"}, Value: "gold staging value"},
+		{When: Condition{Parameter: "enabled", Equals: true}, Value: `"enabled" value`},
+	}, "default value")
+
+	tests := []struct {
+		name     string
+		context  parameter.ResolveContext
+		expected interface{}
+	}{
+		{
+			"matches environment",
+			parameter.ResolveContext{Environment: "prod", Group: "production", ResolvedParameterValues: parameter.Properties{"tier": "gold", "enabled": true}},
+			"prod value",
+		},
+		{
+			"matches group and parameter",
+			parameter.ResolveContext{Environment: "staging-1", Group: "staging", ResolvedParameterValues: parameter.Properties{"tier": "gold", "enabled": true}},
+			"gold staging value",
+		},
+		{
+			"matches masked parameter",
+			parameter.ResolveContext{Environment: "staging-1", Group: "staging", ResolvedParameterValues: parameter.Properties{"tier": secret.MaskedString("gold"), "enabled": true}},
+			"gold staging value",
+		},
+		{
+			"matches boolean parameter and escapes value",
+			parameter.ResolveContext{Environment: "dev", Group: "development", ResolvedParameterValues: parameter.Properties{"tier": "gold", "enabled": true}},
+			`\"enabled\" value`,
+		},
+		{
+			"falls back to default",
+			parameter.ResolveContext{Environment: "dev", Group: "development", ResolvedParameterValues: parameter.Properties{"tier": "silver", "enabled": false}},
+			"default value",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := param.ResolveValue(tt.context)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func TestResolveValue_FailsOnMissingParameter(t *testing.T) {
+	param := New(testCoordinate, []Case{
+		{When: Condition{Parameter: "tier", Equals: "gold"}, Value: 1},
+	}, 0)
+
+	_, err := param.ResolveValue(parameter.ResolveContext{Environment: "dev", ResolvedParameterValues: parameter.Properties{}})
+	assert.Error(t, err)
+}
+
+func TestWriteConditionalParameter(t *testing.T) {
+	param := New(testCoordinate, []Case{
+		{When: Condition{Environments: []string{"prod"}}, Value: 100},
+		{When: Condition{Groups: []string{"staging", "test"}, Parameter: "tier", Equals: "gold"}, Value: 50},
+	}, 10)
+
+	result, err := writeConditionalParameter(parameter.ParameterWriterContext{Coordinate: testCoordinate, Parameter: param})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"conditions": []interface{}{
+			map[string]interface{}{"when": map[string]interface{}{"environment": "prod"}, "value": 100},
+			map[string]interface{}{"when": map[string]interface{}{"group": []interface{}{"staging", "test"}, "parameter": "tier", "equals": "gold"}, "value": 50},
+		},
+		"default": 10,
+	}, result)
+}
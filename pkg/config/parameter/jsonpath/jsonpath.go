@@ -0,0 +1,172 @@
+/*
+ * @license
+ * Copyright 2025 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package jsonpath
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	jsonutils "github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/json"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/strings"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/template"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/coordinate"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/entities"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter"
+)
+
+// JSONPathParameterType specifies the type of the parameter used in config files
+const JSONPathParameterType = "jsonPath"
+
+var JSONPathParameterSerde = parameter.ParameterSerDe{
+	Serializer:   writeJSONPathParameter,
+	Deserializer: parseJSONPathParameter,
+}
+
+// JSONPathParameter is a parameter which evaluates to the value a JSONPath expression points to in the API response
+// to the deployment of the referenced config.
+type JSONPathParameter struct {
+	// Config is the coordinate of the referenced config
+	Config coordinate.Coordinate
+	// Path is the JSONPath expression, e.g. "$.rules[0].id". See jsonutils.Path for the supported syntax.
+	Path string
+}
+
+// entityResolver is implemented by property resolvers which also provide the resolved entities, e.g. entities.EntityMap
+type entityResolver interface {
+	GetResolvedEntity(config coordinate.Coordinate) (entities.ResolvedEntity, bool)
+}
+
+// this forces the compiler to check if JSONPathParameter is of type Parameter
+var _ parameter.Parameter = (*JSONPathParameter)(nil)
+
+func (p *JSONPathParameter) GetType() string {
+	return JSONPathParameterType
+}
+
+func (p *JSONPathParameter) GetReferences() []parameter.ParameterReference {
+	return []parameter.ParameterReference{{Config: p.Config, Property: p.Path}}
+}
+
+// ResolveValue evaluates the path against the response of the referenced config, which must have been deployed
+// already. If the referenced config was not actually deployed, e.g. during a dry-run, a placeholder is returned.
+func (p *JSONPathParameter) ResolveValue(context parameter.ResolveContext) (interface{}, error) {
+	resolver, ok := context.PropertyResolver.(entityResolver)
+	if !ok {
+		return nil, parameter.NewParameterResolveValueError(context, "no resolver for the responses of configs is defined")
+	}
+
+	entity, found := resolver.GetResolvedEntity(p.Config)
+	if !found {
+		return nil, parameter.NewParameterResolveValueError(context, fmt.Sprintf("config %s has not been resolved yet or does not exist", p.Config))
+	}
+
+	if entity.DryRun {
+		return fmt.Sprintf("<%s of %s>", p.Path, p.Config), nil
+	}
+
+	if len(entity.Response) == 0 {
+		return nil, parameter.NewParameterResolveValueError(context, fmt.Sprintf("no response is available for config %s", p.Config))
+	}
+
+	path, err := jsonutils.ParsePath(p.Path)
+	if err != nil {
+		return nil, parameter.NewParameterResolveValueError(context, fmt.Sprintf("invalid path %q: %s", p.Path, err))
+	}
+
+	// numbers are kept as they are, as large IDs would lose precision as float64
+	var doc any
+	decoder := json.NewDecoder(bytes.NewReader(entity.Response))
+	decoder.UseNumber()
+	if err := decoder.Decode(&doc); err != nil {
+		return nil, parameter.NewParameterResolveValueError(context, fmt.Sprintf("response of config %s is not valid JSON: %s", p.Config, err))
+	}
+
+	val, found := path.Get(doc)
+	if !found {
+		return nil, parameter.NewParameterResolveValueError(context, fmt.Sprintf("path %q does not exist in the response of config %s", p.Path, p.Config))
+	}
+
+	return template.EscapeSpecialCharactersInValue(val, template.FullStringEscapeFunction)
+}
+
+const projectField = "project"
+const typeField = "configType"
+const idField = "configId"
+const pathField = "path"
+
+// parseJSONPathParameter parses a JSONPathParameter from a given context. It requires the `configId` of the referenced
+// config and the `path`. A missing project or type is filled in from the current context.
+func parseJSONPathParameter(context parameter.ParameterParserContext) (parameter.Parameter, error) {
+	coord := context.Coordinate
+
+	val, ok := context.Value[idField]
+	if !ok {
+		return nil, parameter.NewParameterParserError(context, fmt.Sprintf("missing `%s` - please specify which config should be referenced", idField))
+	}
+	coord.ConfigId = strings.ToString(val)
+
+	_, typeSet := context.Value[typeField]
+	if typeSet {
+		coord.Type = strings.ToString(context.Value[typeField])
+	}
+
+	if val, ok := context.Value[projectField]; ok {
+		if !typeSet {
+			return nil, parameter.NewParameterParserError(context, fmt.Sprintf("`%s` is set, but `%s` isn't! please specify `%s`", projectField, typeField, typeField))
+		}
+		coord.Project = strings.ToString(val)
+	}
+
+	if coord == context.Coordinate {
+		return nil, parameter.NewParameterParserError(context, "a config cannot reference its own response")
+	}
+
+	p, ok := context.Value[pathField].(string)
+	if !ok || p == "" {
+		return nil, parameter.NewParameterParserError(context, fmt.Sprintf("missing `%s` - please specify the JSONPath expression to evaluate", pathField))
+	}
+	if _, err := jsonutils.ParsePath(p); err != nil {
+		return nil, parameter.NewParameterParserError(context, fmt.Sprintf("invalid `%s`: %s", pathField, err))
+	}
+
+	return &JSONPathParameter{Config: coord, Path: p}, nil
+}
+
+func writeJSONPathParameter(context parameter.ParameterWriterContext) (map[string]interface{}, error) {
+	jsonPathParam, ok := context.Parameter.(*JSONPathParameter)
+
+	if !ok {
+		return nil, parameter.NewParameterWriterError(context, "unexpected type. parameter is not of type `JSONPathParameter`")
+	}
+
+	result := make(map[string]interface{})
+	sameProject := context.Coordinate.Project == jsonPathParam.Config.Project
+
+	if !sameProject {
+		result[projectField] = jsonPathParam.Config.Project
+	}
+
+	if !sameProject || context.Coordinate.Type != jsonPathParam.Config.Type {
+		result[typeField] = jsonPathParam.Config.Type
+	}
+
+	result[idField] = jsonPathParam.Config.ConfigId
+	result[pathField] = jsonPathParam.Path
+
+	return result, nil
+}
@@ -0,0 +1,196 @@
+//go:build unit
+
+/*
+ * @license
+ * Copyright 2025 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package jsonpath
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/coordinate"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/entities"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter"
+)
+
+var (
+	currentConfig    = coordinate.Coordinate{Project: "project", Type: "builtin:alerting.profile", ConfigId: "current"}
+	referencedConfig = coordinate.Coordinate{Project: "project", Type: "builtin:alerting.profile", ConfigId: "other"}
+)
+
+func TestParseJSONPathParameter(t *testing.T) {
+	tests := []struct {
+		name  string
+		value map[string]interface{}
+		want  *JSONPathParameter
+	}{
+		{
+			name:  "fills project and type from current config",
+			value: map[string]interface{}{"configId": "other", "path": "$.rules[0].id"},
+			want:  &JSONPathParameter{Config: referencedConfig, Path: "$.rules[0].id"},
+		},
+		{
+			name:  "fills project from current config",
+			value: map[string]interface{}{"configType": "workflow", "configId": "current", "path": "$.id"},
+			want:  &JSONPathParameter{Config: coordinate.Coordinate{Project: "project", Type: "workflow", ConfigId: "current"}, Path: "$.id"},
+		},
+		{
+			name:  "all set",
+			value: map[string]interface{}{"project": "other-project", "configType": "workflow", "configId": "wf", "path": "$['name']"},
+			want:  &JSONPathParameter{Config: coordinate.Coordinate{Project: "other-project", Type: "workflow", ConfigId: "wf"}, Path: "$['name']"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			param, err := parseJSONPathParameter(parameter.ParameterParserContext{Coordinate: currentConfig, Value: tt.value})
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, param)
+			assert.Equal(t, []parameter.ParameterReference{{Config: tt.want.Config, Property: tt.want.Path}}, param.GetReferences())
+		})
+	}
+}
+
+func TestParseJSONPathParameter_Errors(t *testing.T) {
+	tests := []struct {
+		name  string
+		value map[string]interface{}
+	}{
+		{"missing configId", map[string]interface{}{"path": "$.id"}},
+		{"project without type", map[string]interface{}{"project": "other-project", "configId": "other", "path": "$.id"}},
+		{"own config", map[string]interface{}{"configId": "current", "path": "$.id"}},
+		{"missing path", map[string]interface{}{"configId": "other"}},
+		{"empty path", map[string]interface{}{"configId": "other", "path": ""}},
+		{"path not a string", map[string]interface{}{"configId": "other", "path": 1}},
+		{"invalid path", map[string]interface{}{"configId": "other", "path": "rules.id"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parseJSONPathParameter(parameter.ParameterParserContext{Coordinate: currentConfig, Value: tt.value})
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestWriteJSONPathParameter(t *testing.T) {
+	tests := []struct {
+		name  string
+		param *JSONPathParameter
+		want  map[string]interface{}
+	}{
+		{
+			name:  "same project and type",
+			param: &JSONPathParameter{Config: referencedConfig, Path: "$.id"},
+			want:  map[string]interface{}{"configId": "other", "path": "$.id"},
+		},
+		{
+			name:  "other type",
+			param: &JSONPathParameter{Config: coordinate.Coordinate{Project: "project", Type: "workflow", ConfigId: "wf"}, Path: "$.id"},
+			want:  map[string]interface{}{"configType": "workflow", "configId": "wf", "path": "$.id"},
+		},
+		{
+			name:  "other project",
+			param: &JSONPathParameter{Config: coordinate.Coordinate{Project: "other-project", Type: "builtin:alerting.profile", ConfigId: "wf"}, Path: "$.id"},
+			want:  map[string]interface{}{"project": "other-project", "configType": "builtin:alerting.profile", "configId": "wf", "path": "$.id"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := writeJSONPathParameter(parameter.ParameterWriterContext{Coordinate: currentConfig, Parameter: tt.param})
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, result)
+		})
+	}
+}
+
+func TestResolveValue(t *testing.T) {
+	entityMap := entities.New()
+	entityMap.Put(entities.ResolvedEntity{
+		Coordinate: referencedConfig,
+		Response:   []byte(`{"id":"abc","version":12345678901234567,"rules":[{"name":"a \"quoted\" rule"}],"nested":{"enabled":true}}`),
+	})
+
+	tests := []struct {
+		path string
+		want any
+	}{
+		{"$.id", "abc"},
+		{"$.version", "12345678901234567"},
+		{"$.rules[0].name", `a \"quoted\" rule`},
+		{"$.nested.enabled", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			param := &JSONPathParameter{Config: referencedConfig, Path: tt.path}
+
+			result, err := param.ResolveValue(parameter.ResolveContext{PropertyResolver: entityMap, ConfigCoordinate: currentConfig})
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, toComparable(result))
+		})
+	}
+}
+
+func TestResolveValue_ReturnsPlaceholderForDryRun(t *testing.T) {
+	entityMap := entities.New()
+	entityMap.Put(entities.ResolvedEntity{Coordinate: referencedConfig, DryRun: true})
+	param := &JSONPathParameter{Config: referencedConfig, Path: "$.id"}
+
+	result, err := param.ResolveValue(parameter.ResolveContext{PropertyResolver: entityMap, ConfigCoordinate: currentConfig})
+	require.NoError(t, err)
+	assert.Equal(t, "<$.id of project:builtin:alerting.profile:other>", result)
+}
+
+func TestResolveValue_Errors(t *testing.T) {
+	tests := []struct {
+		name     string
+		entities []entities.ResolvedEntity
+		path     string
+	}{
+		{"config not resolved", nil, "$.id"},
+		{"no response", []entities.ResolvedEntity{{Coordinate: referencedConfig}}, "$.id"},
+		{"invalid response", []entities.ResolvedEntity{{Coordinate: referencedConfig, Response: []byte("not json")}}, "$.id"},
+		{"path does not exist", []entities.ResolvedEntity{{Coordinate: referencedConfig, Response: []byte(`{"id":"abc"}`)}}, "$.name"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entityMap := entities.New()
+			for _, e := range tt.entities {
+				entityMap.Put(e)
+			}
+			param := &JSONPathParameter{Config: referencedConfig, Path: tt.path}
+
+			_, err := param.ResolveValue(parameter.ResolveContext{PropertyResolver: entityMap, ConfigCoordinate: currentConfig})
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestResolveValue_FailsWithoutEntityResolver(t *testing.T) {
+	param := &JSONPathParameter{Config: referencedConfig, Path: "$.id"}
+
+	_, err := param.ResolveValue(parameter.ResolveContext{ConfigCoordinate: currentConfig})
+	assert.Error(t, err)
+}
+
+// toComparable converts JSON numbers to strings, as they are rendered the same way
+func toComparable(v any) any {
+	if n, ok := v.(interface{ String() string }); ok {
+		return n.String()
+	}
+	return v
+}
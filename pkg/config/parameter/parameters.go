@@ -88,6 +88,8 @@ type ParameterParserContext struct {
 	Fs            afero.Fs
 	Value         map[string]interface {
 	}
+	// Variables holds the variables defined for the project and environment the config is loaded for
+	Variables map[string]interface{}
 }
 
 type ParameterParserError struct {
@@ -0,0 +1,241 @@
+/*
+ * @license
+ * Copyright 2025 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package secret
+
+import (
+	"bytes"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/spf13/afero"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/secret"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/template"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter"
+)
+
+// SecretParameterType specifies the type of the parameter used in config files
+const SecretParameterType = "secret"
+
+var SecretParameterSerde = parameter.ParameterSerDe{
+	Serializer:   writeSecretParameter,
+	Deserializer: parseSecretParameter,
+}
+
+// SecretParameter defines a parameter whose value is a secret read from a file, or from the output of a command.
+// The value is resolved as secret.MaskedString, so that it is masked everywhere except in rendered templates.
+type SecretParameter struct {
+	// Fs is the file system relative paths are resolved on
+	Fs afero.Fs
+	// Path of the file containing the secret. Either Path or Command is set.
+	Path string
+	// Command is run without shell, and its output is the secret. The first element is the executable, the others
+	// are its arguments. Either Path or Command is set.
+	Command []string
+}
+
+// this forces the compiler to check if SecretParameter is of type Parameter
+var _ parameter.Parameter = (*SecretParameter)(nil)
+
+func (p *SecretParameter) GetType() string {
+	return SecretParameterType
+}
+
+func (p *SecretParameter) GetReferences() []parameter.ParameterReference {
+	// secret parameters cannot have references
+	return []parameter.ParameterReference{}
+}
+
+func (p *SecretParameter) ResolveValue(context parameter.ResolveContext) (interface{}, error) {
+	val, err := resolved.get(p.source(), p.read)
+	if err != nil {
+		return nil, parameter.NewParameterResolveValueError(context, err.Error())
+	}
+
+	// files and command outputs usually end with a line break, which is not part of the secret
+	val = strings.TrimRight(val, "\r\n")
+
+	escaped, err := template.EscapeSpecialCharactersInValue(val, template.FullStringEscapeFunction)
+	if err != nil {
+		return nil, parameter.NewParameterResolveValueError(context, "failed to escape secret")
+	}
+	return secret.MaskedString(escaped.(string)), nil
+}
+
+func (p *SecretParameter) read() (string, error) {
+	if len(p.Command) > 0 {
+		return p.runCommand()
+	}
+	return p.readFile()
+}
+
+// fs returns the file system the file of the secret is read from
+func (p *SecretParameter) fs() afero.Fs {
+	if filepath.IsAbs(p.Path) || p.Fs == nil {
+		// absolute paths, like secrets mounted into containers, are not relative to the config
+		return afero.NewOsFs()
+	}
+	return p.Fs
+}
+
+// source returns the file or command the secret is read from. Parameters of different configs referring to the same
+// file or command have equal sources.
+func (p *SecretParameter) source() secretSource {
+	if len(p.Command) > 0 {
+		return secretSource{command: strings.Join(p.Command, "\x00")}
+	}
+
+	fs := p.fs()
+	if bp, ok := fs.(*afero.BasePathFs); ok {
+		if path, err := bp.RealPath(p.Path); err == nil {
+			return secretSource{path: path}
+		}
+	}
+	if _, ok := fs.(*afero.OsFs); ok {
+		return secretSource{path: p.Path}
+	}
+	return secretSource{fs: fs, path: p.Path}
+}
+
+func (p *SecretParameter) readFile() (string, error) {
+	content, err := afero.ReadFile(p.fs(), p.Path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read secret from file %q: %w", p.Path, err)
+	}
+	return string(content), nil
+}
+
+func (p *SecretParameter) runCommand() (string, error) {
+	var stdout, stderr bytes.Buffer
+	cmd := exec.Command(p.Command[0], p.Command[1:]...)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	// the output is never part of errors, as it might contain the secret
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("failed to read secret from command %q: %w: %s", p.Command[0], err, strings.TrimSpace(stderr.String()))
+	}
+	return stdout.String(), nil
+}
+
+// secretSource identifies the file or command a secret is read from. fs is only set for file systems whose paths
+// cannot be mapped to paths of the OS.
+type secretSource struct {
+	fs      afero.Fs
+	path    string
+	command string
+}
+
+// resolved caches the secrets of the current run by their source, so that each file is read and each command is run
+// only once, no matter how many configs and environments use the secret
+var resolved = secretCache{entries: map[secretSource]*cachedSecret{}}
+
+type cachedSecret struct {
+	once  sync.Once
+	value string
+	err   error
+}
+
+type secretCache struct {
+	mu      sync.Mutex
+	entries map[secretSource]*cachedSecret
+}
+
+// get returns the secret of the given source, calling read only on the first request of the source
+func (c *secretCache) get(source secretSource, read func() (string, error)) (string, error) {
+	c.mu.Lock()
+	entry, found := c.entries[source]
+	if !found {
+		entry = &cachedSecret{}
+		c.entries[source] = entry
+	}
+	c.mu.Unlock()
+
+	entry.once.Do(func() {
+		entry.value, entry.err = read()
+	})
+	return entry.value, entry.err
+}
+
+// parseSecretParameter parses a SecretParameter from a given context.
+// It requires either a `path` or a `command` field to be set.
+func parseSecretParameter(context parameter.ParameterParserContext) (parameter.Parameter, error) {
+	path, hasPath := context.Value["path"]
+	command, hasCommand := context.Value["command"]
+
+	switch {
+	case hasPath && hasCommand:
+		return nil, parameter.NewParameterParserError(context, "only one of `path` and `command` can be set")
+	case hasPath:
+		p, ok := path.(string)
+		if !ok || p == "" {
+			return nil, parameter.NewParameterParserError(context, "`path` must be a non-empty string")
+		}
+		return &SecretParameter{Fs: context.Fs, Path: p}, nil
+	case hasCommand:
+		c, err := toCommand(command)
+		if err != nil {
+			return nil, parameter.NewParameterParserError(context, err.Error())
+		}
+		return &SecretParameter{Command: c}, nil
+	default:
+		return nil, parameter.NewParameterParserError(context, "missing property `path` or `command`")
+	}
+}
+
+func toCommand(v interface{}) ([]string, error) {
+	list, ok := v.([]interface{})
+	if !ok || len(list) == 0 {
+		return nil, fmt.Errorf("`command` must be a non-empty list of the executable and its arguments")
+	}
+
+	command := make([]string, 0, len(list))
+	for _, e := range list {
+		s, ok := e.(string)
+		if !ok {
+			return nil, fmt.Errorf("`command` must only contain strings, but found %v", e)
+		}
+		command = append(command, s)
+	}
+	return command, nil
+}
+
+// writeSecretParameter writes the source of the secret, but never its value
+func writeSecretParameter(context parameter.ParameterWriterContext) (map[string]interface{}, error) {
+	secretParam, ok := context.Parameter.(*SecretParameter)
+
+	if !ok {
+		return nil, parameter.NewParameterWriterError(context, "unexpected type. parameter is not of type `SecretParameter`")
+	}
+
+	result := make(map[string]interface{})
+
+	if len(secretParam.Command) > 0 {
+		command := make([]interface{}, len(secretParam.Command))
+		for i, c := range secretParam.Command {
+			command[i] = c
+		}
+		result["command"] = command
+	} else {
+		result["path"] = secretParam.Path
+	}
+
+	return result, nil
+}
@@ -0,0 +1,132 @@
+//go:build unit
+
+/*
+ * @license
+ * Copyright 2025 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package secret
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/secret"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter"
+	envParam "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter/environment"
+)
+
+func TestParseSecretParameter(t *testing.T) {
+	fs := afero.NewMemMapFs()
+
+	param, err := parseSecretParameter(parameter.ParameterParserContext{Fs: fs, Value: map[string]any{"path": "token.txt"}})
+	require.NoError(t, err)
+	assert.Equal(t, "secret", param.GetType())
+	assert.Equal(t, &SecretParameter{Fs: fs, Path: "token.txt"}, param)
+	assert.Empty(t, param.GetReferences())
+
+	param, err = parseSecretParameter(parameter.ParameterParserContext{Value: map[string]any{"command": []any{"vault", "kv", "get", "-field=token", "secret/webhook"}}})
+	require.NoError(t, err)
+	assert.Equal(t, &SecretParameter{Command: []string{"vault", "kv", "get", "-field=token", "secret/webhook"}}, param)
+}
+
+func TestParseSecretParameter_Errors(t *testing.T) {
+	tests := []struct {
+		name  string
+		value map[string]any
+	}{
+		{"missing path and command", map[string]any{}},
+		{"path and command", map[string]any{"path": "token.txt", "command": []any{"cat"}}},
+		{"empty path", map[string]any{"path": ""}},
+		{"command is no list", map[string]any{"command": "vault kv get"}},
+		{"empty command", map[string]any{"command": []any{}}},
+		{"command contains no strings", map[string]any{"command": []any{"cat", 1}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			param, err := parseSecretParameter(parameter.ParameterParserContext{Value: tt.value})
+			assert.Nil(t, param)
+			assert.IsType(t, parameter.ParameterParserError{}, err)
+		})
+	}
+}
+
+func TestResolveValue_FromFile(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	require.NoError(t, afero.WriteFile(fs, "token.txt", []byte("my \"token\"\n"), 0644))
+
+	result, err := (&SecretParameter{Fs: fs, Path: "token.txt"}).ResolveValue(parameter.ResolveContext{})
+	require.NoError(t, err)
+	assert.Equal(t, secret.MaskedString(`my \"token\"`), result, "value is masked, escaped and without line break")
+}
+
+func TestResolveValue_FromFileWithAbsolutePath(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "token.txt")
+	require.NoError(t, afero.WriteFile(afero.NewOsFs(), path, []byte("token"), 0644))
+
+	result, err := (&SecretParameter{Fs: afero.NewMemMapFs(), Path: path}).ResolveValue(parameter.ResolveContext{})
+	require.NoError(t, err)
+	assert.Equal(t, secret.MaskedString("token"), result)
+}
+
+func TestResolveValue_FromCommand(t *testing.T) {
+	result, err := (&SecretParameter{Command: []string{"echo", "token"}}).ResolveValue(parameter.ResolveContext{})
+	require.NoError(t, err)
+	assert.Equal(t, secret.MaskedString("token"), result)
+}
+
+func TestResolveValue_ResolvesEachSourceOnce(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("command of this test requires a POSIX shell")
+	}
+
+	runs := filepath.Join(t.TempDir(), "runs.txt")
+	command := []string{"sh", "-c", "echo run >> " + runs + " && echo token"}
+	for range 2 {
+		result, err := (&SecretParameter{Command: command}).ResolveValue(parameter.ResolveContext{})
+		require.NoError(t, err)
+		assert.Equal(t, secret.MaskedString("token"), result)
+	}
+
+	content, err := os.ReadFile(runs)
+	require.NoError(t, err)
+	assert.Equal(t, "run\n", string(content), "command is only run once")
+}
+
+func TestResolveValue_Errors(t *testing.T) {
+	_, err := (&SecretParameter{Fs: afero.NewMemMapFs(), Path: "missing.txt"}).ResolveValue(parameter.ResolveContext{})
+	assert.IsType(t, parameter.ParameterResolveValueError{}, err)
+
+	_, err = (&SecretParameter{Command: []string{"this-command-does-not-exist"}}).ResolveValue(parameter.ResolveContext{})
+	assert.IsType(t, parameter.ParameterResolveValueError{}, err)
+}
+
+func TestWriteSecretParameter(t *testing.T) {
+	result, err := writeSecretParameter(parameter.ParameterWriterContext{Parameter: &SecretParameter{Path: "token.txt"}})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"path": "token.txt"}, result)
+
+	result, err = writeSecretParameter(parameter.ParameterWriterContext{Parameter: &SecretParameter{Command: []string{"vault", "kv", "get"}}})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"command": []any{"vault", "kv", "get"}}, result)
+
+	_, err = writeSecretParameter(parameter.ParameterWriterContext{Parameter: envParam.New("env")})
+	assert.IsType(t, &parameter.ParameterWriterError{}, err)
+}
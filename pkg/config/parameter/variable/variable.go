@@ -0,0 +1,94 @@
+/*
+ * @license
+ * Copyright 2025 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package variable
+
+import (
+	"fmt"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/strings"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/template"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter"
+)
+
+// VariableParameterType specifies the type of the parameter used in config files
+const VariableParameterType = "variable"
+
+var VariableParameterSerde = parameter.ParameterSerDe{
+	Serializer:   writeVariableParameter,
+	Deserializer: parseVariableParameter,
+}
+
+// VariableParameter represents the value of a variable defined in the manifest or in the variables file of the
+// project. Like the value of a ValueParameter, the value is resolved at config load time.
+type VariableParameter struct {
+	Name  string
+	Value interface{}
+}
+
+func New(name string, value interface{}) *VariableParameter {
+	return &VariableParameter{Name: name, Value: value}
+}
+
+// this forces the compiler to check if VariableParameter is of type Parameter
+var _ parameter.Parameter = (*VariableParameter)(nil)
+
+func (p *VariableParameter) GetType() string {
+	return VariableParameterType
+}
+
+func (p *VariableParameter) GetReferences() []parameter.ParameterReference {
+	// the variable parameter cannot have references, as its value is resolved at load time
+	return []parameter.ParameterReference{}
+}
+
+func (p *VariableParameter) ResolveValue(_ parameter.ResolveContext) (interface{}, error) {
+	return template.EscapeSpecialCharactersInValue(p.Value, template.FullStringEscapeFunction)
+}
+
+// parseVariableParameter parses a given context into an instance of VariableParameter.
+// The only required property is `name`, which must be the name of a variable defined for the environment.
+func parseVariableParameter(context parameter.ParameterParserContext) (parameter.Parameter, error) {
+	val, ok := context.Value["name"]
+	if !ok {
+		return nil, parameter.NewParameterParserError(context, "missing property `name`")
+	}
+
+	name := strings.ToString(val)
+	if name == "" {
+		return nil, parameter.NewParameterParserError(context, "`name` must not be empty")
+	}
+
+	value, found := context.Variables[name]
+	if !found {
+		return nil, parameter.NewParameterParserError(context, fmt.Sprintf("variable %q is not defined for environment %q - please define it in the manifest or in the variables file of the project", name, context.Environment))
+	}
+
+	return New(name, value), nil
+}
+
+// writeVariableParameter writes the name of the variable, but not its value, which is defined elsewhere
+func writeVariableParameter(context parameter.ParameterWriterContext) (map[string]interface{}, error) {
+	variableParam, ok := context.Parameter.(*VariableParameter)
+
+	if !ok {
+		return nil, parameter.NewParameterWriterError(context, "unexpected type. parameter is not of type `VariableParameter`")
+	}
+
+	return map[string]interface{}{
+		"name": variableParam.Name,
+	}, nil
+}
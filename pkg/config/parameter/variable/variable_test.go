@@ -0,0 +1,103 @@
+//go:build unit
+
+/*
+ * @license
+ * Copyright 2025 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package variable
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/coordinate"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter"
+)
+
+var testCoordinate = coordinate.Coordinate{Project: "project", Type: "dashboard", ConfigId: "dashboard-1"}
+
+func TestParseVariableParameter(t *testing.T) {
+	param, err := parseVariableParameter(parameter.ParameterParserContext{
+		Coordinate:    testCoordinate,
+		ParameterName: "owner",
+		Environment:   "dev",
+		Value:         map[string]interface{}{"name": "team"},
+		Variables:     map[string]interface{}{"team": "platform", "threshold": 10},
+	})
+	require.NoError(t, err)
+
+	variableParam, ok := param.(*VariableParameter)
+	require.True(t, ok, "parsed parameter should be variable parameter")
+	assert.Equal(t, "variable", variableParam.GetType())
+	assert.Equal(t, "team", variableParam.Name)
+	assert.Equal(t, "platform", variableParam.Value)
+	assert.Empty(t, variableParam.GetReferences())
+}
+
+func TestParseVariableParameter_Errors(t *testing.T) {
+	tests := []struct {
+		name  string
+		value map[string]interface{}
+	}{
+		{
+			"missing name",
+			map[string]interface{}{},
+		},
+		{
+			"empty name",
+			map[string]interface{}{"name": ""},
+		},
+		{
+			"undefined variable",
+			map[string]interface{}{"name": "region"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parseVariableParameter(parameter.ParameterParserContext{
+				Coordinate:    testCoordinate,
+				ParameterName: "owner",
+				Environment:   "dev",
+				Value:         tt.value,
+				Variables:     map[string]interface{}{"team": "platform"},
+			})
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestParseVariableParameter_UndefinedVariableNamesEnvironment(t *testing.T) {
+	_, err := parseVariableParameter(parameter.ParameterParserContext{
+		Coordinate:    testCoordinate,
+		ParameterName: "owner",
+		Environment:   "prod",
+		Value:         map[string]interface{}{"name": "team"},
+	})
+	assert.ErrorContains(t, err, `variable "team" is not defined for environment "prod"`)
+}
+
+func TestResolveValue(t *testing.T) {
+	result, err := New("team", `"platform" team`).ResolveValue(parameter.ResolveContext{})
+	require.NoError(t, err)
+	assert.Equal(t, `\"platform\" team`, result)
+}
+
+func TestWriteVariableParameter(t *testing.T) {
+	result, err := writeVariableParameter(parameter.ParameterWriterContext{Coordinate: testCoordinate, Parameter: New("team", "platform")})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"name": "team"}, result)
+}
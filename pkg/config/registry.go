@@ -0,0 +1,235 @@
+/*
+ * @license
+ * Copyright 2025 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package config
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/featureflags"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/api"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/client"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/entities"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter"
+)
+
+// RemoteObject is the remote representation of a config
+type RemoteObject struct {
+	// ID is the ID of the remote object
+	ID string
+	// Payload is the JSON payload of the remote object as returned by the API
+	Payload []byte
+	// Scope is the scope of the remote object, if objects of its type have one (e.g. settings)
+	Scope string
+}
+
+// DownloadOptions restrict the configs downloaded by the Download behaviour of a type
+type DownloadOptions struct {
+	// ProjectName is the name of the project the downloaded configs are part of
+	ProjectName string
+	// APIs are the classic APIs to download. They are only used by the classic API type.
+	APIs api.APIs
+	// SchemaIDs are the settings schemas to download. They are only used by the settings type, which downloads all
+	// schemas if none are given.
+	SchemaIDs []string
+}
+
+// TypeDefinition is a config type together with the type specific parameters defined in the 'type' section of a config
+type TypeDefinition struct {
+	Type Type
+	// Scope is the serialized definition of the scope parameter, if the type supports one
+	Scope any
+	// InsertAfter is the serialized definition of the insertAfter parameter, if the type supports one
+	InsertAfter any
+}
+
+// TypeHandler bundles the behaviour of one config type. Behaviours that are not set are not supported by the type.
+type TypeHandler struct {
+	// ID is the ID of the type
+	ID TypeID
+	// Key identifies the type in the 'type' section of a config, e.g. 'settings' in 'type: {settings: {...}}'
+	Key string
+	// Shorthand states that the type is defined by its key alone, e.g. 'type: bucket'
+	Shorthand bool
+	// Enabled reports whether the type is available. Types without it are always available.
+	Enabled func() bool
+	// CoordinateTypes are the types of the coordinates of configs of the type, if they differ from its ID, e.g. the
+	// resources of automations
+	CoordinateTypes []string
+
+	// Parse creates the type definition from the value of the key in the 'type' section. The value is nil for
+	// shorthand types.
+	Parse func(data any) (TypeDefinition, error)
+	// Marshal returns the value of the 'type' section for the type definition
+	Marshal func(t TypeDefinition) (any, error)
+	// Validate verifies that the type definition is complete and valid
+	Validate func(t TypeDefinition) error
+	// Deploy writes the rendered config to the environment
+	Deploy func(ctx context.Context, clients *client.ClientSet, properties parameter.Properties, renderedConfig string, c *Config) (entities.ResolvedEntity, error)
+	// Lookup finds the remote object the config would be deployed to. If no such object exists, found is false and no
+	// error is returned.
+	Lookup func(ctx context.Context, clients *client.ClientSet, properties parameter.Properties, c *Config) (obj RemoteObject, found bool, err error)
+	// Download downloads the configs of the type from the environment, grouped by the type of their coordinates
+	Download func(ctx context.Context, clients *client.ClientSet, opts DownloadOptions) (map[string][]Config, error)
+	// Delete deletes the given objects from the environment
+	Delete func(ctx context.Context, clients *client.ClientSet, entries []DeletePointer) error
+}
+
+// IsEnabled reports whether the type is available
+func (h TypeHandler) IsEnabled() bool {
+	return h.Enabled == nil || h.Enabled()
+}
+
+// merge returns the handler with all behaviours that are set in other replaced
+func (h TypeHandler) merge(other TypeHandler) TypeHandler {
+	if other.Key != "" {
+		h.Key = other.Key
+		h.Shorthand = other.Shorthand
+	}
+	if other.Enabled != nil {
+		h.Enabled = other.Enabled
+	}
+	if other.CoordinateTypes != nil {
+		h.CoordinateTypes = other.CoordinateTypes
+	}
+	if other.Parse != nil {
+		h.Parse = other.Parse
+	}
+	if other.Marshal != nil {
+		h.Marshal = other.Marshal
+	}
+	if other.Validate != nil {
+		h.Validate = other.Validate
+	}
+	if other.Deploy != nil {
+		h.Deploy = other.Deploy
+	}
+	if other.Lookup != nil {
+		h.Lookup = other.Lookup
+	}
+	if other.Download != nil {
+		h.Download = other.Download
+	}
+	if other.Delete != nil {
+		h.Delete = other.Delete
+	}
+	return h
+}
+
+// TypeRegistry holds the handlers of all known config types
+type TypeRegistry struct {
+	mu       sync.RWMutex
+	handlers map[TypeID]TypeHandler
+}
+
+// NewTypeRegistry creates a new TypeRegistry containing the given handlers
+func NewTypeRegistry(handlers ...TypeHandler) *TypeRegistry {
+	r := &TypeRegistry{handlers: make(map[TypeID]TypeHandler, len(handlers))}
+	for _, h := range handlers {
+		r.Register(h)
+	}
+	return r
+}
+
+// Types is the registry of all config types. It contains the built-in types, whose behaviours are registered by the
+// packages implementing them.
+var Types = NewTypeRegistry(
+	TypeHandler{ID: ClassicApiTypeID, Key: "api"},
+	TypeHandler{ID: SettingsTypeID, Key: "settings"},
+	TypeHandler{ID: AutomationTypeID, Key: "automation", CoordinateTypes: []string{string(Workflow), string(BusinessCalendar), string(SchedulingRule)}},
+	TypeHandler{ID: BucketTypeID, Key: "bucket", Shorthand: true},
+	TypeHandler{ID: DocumentTypeID, Key: "document"},
+	TypeHandler{ID: OpenPipelineTypeID, Key: "openpipeline", Enabled: featureflags.OpenPipeline.Enabled},
+	TypeHandler{ID: SegmentID, Key: "segment", Shorthand: true, Enabled: featureflags.Segments.Enabled},
+	TypeHandler{ID: ServiceLevelObjectiveID, Key: "slo-v2", Shorthand: true, Enabled: featureflags.ServiceLevelObjective.Enabled},
+)
+
+// Register registers the given handler. If a handler of the same type is already registered, the behaviours set in
+// the given handler replace the registered ones. This allows registering the behaviours of a type separately.
+func (r *TypeRegistry) Register(h TypeHandler) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if registered, found := r.handlers[h.ID]; found {
+		h = registered.merge(h)
+	}
+	r.handlers[h.ID] = h
+}
+
+// Get returns the handler of the given type, if the type is registered and enabled
+func (r *TypeRegistry) Get(id TypeID) (TypeHandler, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	h, found := r.handlers[id]
+	if !found || !h.IsEnabled() {
+		return TypeHandler{}, false
+	}
+	return h, true
+}
+
+// Has reports whether a handler is registered for the given type, regardless of whether the type is enabled
+func (r *TypeRegistry) Has(id TypeID) bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	_, found := r.handlers[id]
+	return found
+}
+
+// GetByCoordinateType returns the ID of the registered type of configs with the given coordinate type, regardless of
+// whether the type is enabled. Classic APIs and settings schemas are not registered by their coordinate types.
+func (r *TypeRegistry) GetByCoordinateType(coordinateType string) (TypeID, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if _, found := r.handlers[TypeID(coordinateType)]; found {
+		return TypeID(coordinateType), true
+	}
+	for id, h := range r.handlers {
+		if slices.Contains(h.CoordinateTypes, coordinateType) {
+			return id, true
+		}
+	}
+	return "", false
+}
+
+// GetByKey returns the handler of the type with the given key in the 'type' section of a config, if the type is
+// registered. Only shorthand types are returned if shorthand is set, and only other types if not.
+// In contrast to Get, disabled types are returned as well, so that their keys are not mistaken for anything else.
+func (r *TypeRegistry) GetByKey(key string, shorthand bool) (TypeHandler, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	for _, h := range r.handlers {
+		if h.Key == key && h.Shorthand == shorthand {
+			return h, true
+		}
+	}
+	return TypeHandler{}, false
+}
+
+// All returns the handlers of all registered and enabled types, sorted by ID
+func (r *TypeRegistry) All() []TypeHandler {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	result := make([]TypeHandler, 0, len(r.handlers))
+	for _, h := range r.handlers {
+		if h.IsEnabled() {
+			result = append(result, h)
+		}
+	}
+	slices.SortFunc(result, func(a, b TypeHandler) int { return strings.Compare(string(a.ID), string(b.ID)) })
+	return result
+}
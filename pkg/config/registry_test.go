@@ -0,0 +1,117 @@
+//go:build unit
+
+/*
+ * @license
+ * Copyright 2025 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package config
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTypeRegistry_RegisterMergesBehaviours(t *testing.T) {
+	r := NewTypeRegistry(TypeHandler{ID: "custom", Key: "custom"})
+
+	parseErr := errors.New("parse")
+	validateErr := errors.New("validate")
+	r.Register(TypeHandler{ID: "custom", Parse: func(any) (TypeDefinition, error) { return TypeDefinition{}, parseErr }})
+	r.Register(TypeHandler{ID: "custom", Validate: func(TypeDefinition) error { return validateErr }})
+
+	h, found := r.Get("custom")
+	require.True(t, found)
+	assert.Equal(t, "custom", h.Key)
+	require.NotNil(t, h.Parse)
+	_, err := h.Parse(nil)
+	assert.ErrorIs(t, err, parseErr)
+	require.NotNil(t, h.Validate)
+	assert.ErrorIs(t, h.Validate(TypeDefinition{}), validateErr)
+	assert.Nil(t, h.Deploy)
+}
+
+func TestTypeRegistry_DisabledTypes(t *testing.T) {
+	enabled := false
+	r := NewTypeRegistry(
+		TypeHandler{ID: "b", Key: "b", Shorthand: true, Enabled: func() bool { return enabled }},
+		TypeHandler{ID: "a", Key: "a"},
+	)
+
+	_, found := r.Get("b")
+	assert.False(t, found)
+	assert.True(t, r.Has("b"))
+	assert.Len(t, r.All(), 1)
+
+	h, found := r.GetByKey("b", true)
+	require.True(t, found, "disabled types must be found by their key")
+	assert.False(t, h.IsEnabled())
+
+	enabled = true
+	_, found = r.Get("b")
+	assert.True(t, found)
+
+	var ids []TypeID
+	for _, h := range r.All() {
+		ids = append(ids, h.ID)
+	}
+	assert.Equal(t, []TypeID{"a", "b"}, ids)
+}
+
+func TestTypeRegistry_GetByKey(t *testing.T) {
+	r := NewTypeRegistry(
+		TypeHandler{ID: "short", Key: "short", Shorthand: true},
+		TypeHandler{ID: "full", Key: "full"},
+	)
+
+	h, found := r.GetByKey("short", true)
+	require.True(t, found)
+	assert.Equal(t, TypeID("short"), h.ID)
+
+	_, found = r.GetByKey("short", false)
+	assert.False(t, found)
+
+	_, found = r.GetByKey("full", true)
+	assert.False(t, found)
+
+	_, found = r.GetByKey("unknown", false)
+	assert.False(t, found)
+}
+
+func TestTypeRegistry_GetByCoordinateType(t *testing.T) {
+	r := NewTypeRegistry(
+		TypeHandler{ID: "bucket", Key: "bucket"},
+		TypeHandler{ID: "automation", Key: "automation", CoordinateTypes: []string{"workflow", "scheduling-rule"}},
+	)
+
+	id, found := r.GetByCoordinateType("bucket")
+	require.True(t, found)
+	assert.Equal(t, TypeID("bucket"), id)
+
+	id, found = r.GetByCoordinateType("scheduling-rule")
+	require.True(t, found)
+	assert.Equal(t, TypeID("automation"), id)
+
+	_, found = r.GetByCoordinateType("builtin:alerting.profile")
+	assert.False(t, found)
+}
+
+func TestTypes_ContainsBuiltInTypes(t *testing.T) {
+	for _, id := range []TypeID{ClassicApiTypeID, SettingsTypeID, AutomationTypeID, BucketTypeID, DocumentTypeID, OpenPipelineTypeID, SegmentID, ServiceLevelObjectiveID} {
+		assert.True(t, Types.Has(id), "type %q is not registered", id)
+	}
+}
@@ -0,0 +1,358 @@
+/*
+ * @license
+ * Copyright 2025 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package template
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+	templ "text/template" // nosemgrep: go.lang.security.audit.xss.import-text-template.import-text-template
+	"text/template/parse"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/maps"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/template"
+)
+
+// funcMap holds the functions available in config templates and formats of compound parameters. All functions are
+// deterministic, so rendering the same template with the same values always produces the same result.
+//
+// Parameter values are already JSON-escaped when they are passed to a template. Functions working on the content of
+// strings therefore unescape them first, and escape their results again using template.FullStringEscapeFunction, so
+// that any result can be placed inside a JSON string. Functions that merely combine values, like join, keep them as
+// they are.
+var funcMap = templ.FuncMap{
+	"default":   defaultValue,
+	"get":       get,
+	"join":      join,
+	"list":      list,
+	"toJson":    toJSON,
+	"upper":     onUnescaped(strings.ToUpper),
+	"lower":     onUnescaped(strings.ToLower),
+	"trim":      onUnescaped(strings.TrimSpace),
+	"replace":   replace,
+	"indent":    indent,
+	"b64enc":    b64enc,
+	"b64dec":    b64dec,
+	"sha256sum": sha256sum,
+}
+
+// unescape returns the actual value of the given JSON-escaped string. Strings that are not escaped, like string
+// literals of templates containing quotes, are returned unchanged.
+func unescape(s string) string {
+	var unescaped string
+	if err := json.Unmarshal([]byte(`"`+s+`"`), &unescaped); err != nil {
+		return s
+	}
+	return unescaped
+}
+
+// onUnescaped returns a template function applying f to the unescaped value of its argument, and escaping the result
+func onUnescaped(f func(string) string) func(string) (string, error) {
+	return func(s string) (string, error) {
+		return template.FullStringEscapeFunction(f(unescape(s)))
+	}
+}
+
+// defaultValue returns the given value, or the default value if the value is empty.
+// Usage: {{ .value | default "fallback" }}
+func defaultValue(def interface{}, value ...interface{}) interface{} {
+	if len(value) == 0 || isEmpty(value[0]) {
+		return def
+	}
+	return value[0]
+}
+
+// get returns the value of the given, possibly nested, key of data, or nil if it is not defined.
+// Usage: {{ get . "value" | default "fallback" }}
+func get(data interface{}, keys ...string) interface{} {
+	for _, key := range keys {
+		v := reflect.ValueOf(data)
+		for v.Kind() == reflect.Interface || v.Kind() == reflect.Pointer {
+			if v.IsNil() {
+				return nil
+			}
+			v = v.Elem()
+		}
+		if v.Kind() != reflect.Map || v.Type().Key().Kind() != reflect.String {
+			return nil
+		}
+		value := v.MapIndex(reflect.ValueOf(key).Convert(v.Type().Key()))
+		if !value.IsValid() {
+			return nil
+		}
+		data = value.Interface()
+	}
+	return data
+}
+
+// passUndefinedKeysToDefault rewrites all field references passed to default, like {{ .value | default "fallback" }}
+// or {{ default "fallback" .value }}, into calls of get. As templates are rendered with missingkey=error, undefined
+// keys would otherwise fail the rendering instead of reaching default.
+func passUndefinedKeysToDefault(node parse.Node) {
+	switch n := node.(type) {
+	case *parse.ListNode:
+		if n == nil {
+			return
+		}
+		for _, child := range n.Nodes {
+			passUndefinedKeysToDefault(child)
+		}
+	case *parse.ActionNode:
+		passUndefinedKeysToDefaultInPipe(n.Pipe)
+	case *parse.IfNode:
+		passUndefinedKeysToDefaultInBranch(&n.BranchNode)
+	case *parse.RangeNode:
+		passUndefinedKeysToDefaultInBranch(&n.BranchNode)
+	case *parse.WithNode:
+		passUndefinedKeysToDefaultInBranch(&n.BranchNode)
+	case *parse.TemplateNode:
+		passUndefinedKeysToDefaultInPipe(n.Pipe)
+	}
+}
+
+func passUndefinedKeysToDefaultInBranch(n *parse.BranchNode) {
+	passUndefinedKeysToDefaultInPipe(n.Pipe)
+	passUndefinedKeysToDefault(n.List)
+	passUndefinedKeysToDefault(n.ElseList)
+}
+
+func passUndefinedKeysToDefaultInPipe(pipe *parse.PipeNode) {
+	if pipe == nil {
+		return
+	}
+	for i, cmd := range pipe.Cmds {
+		if isDefaultCommand(cmd) {
+			for j, arg := range cmd.Args {
+				if field, ok := arg.(*parse.FieldNode); ok {
+					cmd.Args[j] = &parse.PipeNode{NodeType: parse.NodePipe, Pos: field.Pos, Cmds: []*parse.CommandNode{getCommand(field)}}
+				}
+			}
+		} else if i+1 < len(pipe.Cmds) && isDefaultCommand(pipe.Cmds[i+1]) && len(cmd.Args) == 1 {
+			if field, ok := cmd.Args[0].(*parse.FieldNode); ok {
+				pipe.Cmds[i] = getCommand(field)
+			}
+		}
+		for _, arg := range cmd.Args {
+			if p, ok := arg.(*parse.PipeNode); ok {
+				passUndefinedKeysToDefaultInPipe(p)
+			}
+		}
+	}
+}
+
+func isDefaultCommand(cmd *parse.CommandNode) bool {
+	if len(cmd.Args) == 0 {
+		return false
+	}
+	ident, ok := cmd.Args[0].(*parse.IdentifierNode)
+	return ok && ident.Ident == "default"
+}
+
+// getCommand returns a command calling get for the keys of the given field, e.g. {{ get . "a" "b" }} for {{ .a.b }}
+func getCommand(field *parse.FieldNode) *parse.CommandNode {
+	args := []parse.Node{parse.NewIdentifier("get").SetPos(field.Pos), &parse.DotNode{NodeType: parse.NodeDot, Pos: field.Pos}}
+	for _, key := range field.Ident {
+		args = append(args, &parse.StringNode{NodeType: parse.NodeString, Pos: field.Pos, Quoted: fmt.Sprintf("%q", key), Text: key})
+	}
+	return &parse.CommandNode{NodeType: parse.NodeCommand, Pos: field.Pos, Args: args}
+}
+
+func isEmpty(value interface{}) bool {
+	if value == nil {
+		return true
+	}
+	v := reflect.ValueOf(value)
+	switch v.Kind() {
+	case reflect.String, reflect.Slice, reflect.Map, reflect.Array:
+		return v.Len() == 0
+	case reflect.Pointer, reflect.Interface:
+		return v.IsNil()
+	default:
+		return false
+	}
+}
+
+// join concatenates the items of the given list, which may also be the value of a list parameter, with the separator.
+// Usage: {{ .values | join ", " }}
+func join(sep string, value interface{}) (string, error) {
+	items, err := list(value)
+	if err != nil {
+		return "", err
+	}
+
+	s := make([]string, len(items))
+	for i, item := range items {
+		s[i] = fmt.Sprint(item)
+	}
+	return strings.Join(s, sep), nil
+}
+
+// list returns the items of the given value, so that they can be used with range. The value can be the value of a list
+// parameter, or any list of values. String items are escaped, unless they are taken from a list parameter, whose
+// items already are.
+// Usage: {{ range list .values }}...{{ end }}
+func list(value interface{}) ([]interface{}, error) {
+	if s, ok := value.(string); ok {
+		return listParameterItems(s)
+	}
+
+	v := reflect.ValueOf(value)
+	if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+		return nil, fmt.Errorf("value of type %T is not a list", value)
+	}
+
+	items := make([]interface{}, v.Len())
+	for i := range items {
+		item, err := template.EscapeSpecialCharactersInValue(v.Index(i).Interface(), template.FullStringEscapeFunction)
+		if err != nil {
+			return nil, err
+		}
+		items[i] = item
+	}
+	return items, nil
+}
+
+// listParameterItems returns the items of the given value of a list parameter, which looks like `[ "a","b" ]`. As list
+// parameters escape their items, they are returned without changing them.
+func listParameterItems(value string) ([]interface{}, error) {
+	var raw []json.RawMessage
+	if err := json.Unmarshal([]byte(value), &raw); err != nil {
+		return nil, fmt.Errorf("value %q is not a list: %w", value, err)
+	}
+
+	items := make([]interface{}, len(raw))
+	for i, r := range raw {
+		s := string(bytes.TrimSpace(r))
+		if len(s) < 2 || s[0] != '"' {
+			return nil, fmt.Errorf("value %q is not a list of strings", value)
+		}
+		items[i] = s[1 : len(s)-1]
+	}
+	return items, nil
+}
+
+// toJSON returns the given value as JSON. As strings are already escaped, they are only put in quotes. Keys of maps are
+// sorted to keep the result stable.
+// Usage: "tags": {{ toJson (list .tags) }}
+func toJSON(value interface{}) (string, error) {
+	b := strings.Builder{}
+	if err := writeJSON(&b, value); err != nil {
+		return "", err
+	}
+	return b.String(), nil
+}
+
+func writeJSON(b *strings.Builder, value interface{}) error {
+	switch v := value.(type) {
+	case string:
+		b.WriteString(`"` + v + `"`)
+		return nil
+	case map[interface{}]interface{}:
+		return writeJSON(b, maps.ToStringMap(v))
+	}
+
+	rv := reflect.ValueOf(value)
+	switch rv.Kind() {
+	case reflect.Slice, reflect.Array:
+		b.WriteString("[")
+		for i := 0; i < rv.Len(); i++ {
+			if i > 0 {
+				b.WriteString(",")
+			}
+			if err := writeJSON(b, rv.Index(i).Interface()); err != nil {
+				return err
+			}
+		}
+		b.WriteString("]")
+		return nil
+	case reflect.Map:
+		if rv.Type().Key().Kind() != reflect.String {
+			return fmt.Errorf("keys of map of type %T are not strings", value)
+		}
+		keys := make([]string, 0, rv.Len())
+		for _, k := range rv.MapKeys() {
+			keys = append(keys, k.String())
+		}
+		slices.Sort(keys)
+
+		b.WriteString("{")
+		for i, k := range keys {
+			if i > 0 {
+				b.WriteString(",")
+			}
+			escapedKey, err := template.FullStringEscapeFunction(k)
+			if err != nil {
+				return err
+			}
+			b.WriteString(`"` + escapedKey + `":`)
+			if err := writeJSON(b, rv.MapIndex(reflect.ValueOf(k).Convert(rv.Type().Key())).Interface()); err != nil {
+				return err
+			}
+		}
+		b.WriteString("}")
+		return nil
+	default:
+		j, err := json.Marshal(value)
+		if err != nil {
+			return err
+		}
+		b.Write(j)
+		return nil
+	}
+}
+
+// replace replaces all occurrences of old in the given string with replacement.
+// Usage: {{ .value | replace "-" "_" }}
+func replace(old, replacement, s string) (string, error) {
+	return template.FullStringEscapeFunction(strings.ReplaceAll(unescape(s), old, replacement))
+}
+
+// indent indents each line of the given string by the given number of spaces.
+// Usage: {{ .value | indent 4 }}
+func indent(spaces int, s string) (string, error) {
+	pad := strings.Repeat(" ", spaces)
+	return template.FullStringEscapeFunction(pad + strings.ReplaceAll(unescape(s), "\n", "\n"+pad))
+}
+
+// b64enc returns the standard base64 encoding of the given string.
+// Usage: {{ .value | b64enc }}
+func b64enc(s string) string {
+	return base64.StdEncoding.EncodeToString([]byte(unescape(s)))
+}
+
+// b64dec returns the escaped decoded value of the given standard base64 encoded string.
+// Usage: {{ .value | b64dec }}
+func b64dec(s string) (string, error) {
+	decoded, err := base64.StdEncoding.DecodeString(s)
+	if err != nil {
+		return "", fmt.Errorf("failed to decode base64 value: %w", err)
+	}
+	return template.FullStringEscapeFunction(string(decoded))
+}
+
+// sha256sum returns the hex encoded SHA-256 hash of the given string.
+// Usage: {{ .value | sha256sum }}
+func sha256sum(s string) string {
+	sum := sha256.Sum256([]byte(unescape(s)))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,234 @@
+//go:build unit
+
+/*
+ * @license
+ * Copyright 2025 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package template
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRender_TemplateFunctions(t *testing.T) {
+	tests := []struct {
+		name       string
+		template   string
+		properties map[string]interface{}
+		want       string
+	}{
+		{
+			"default uses value if set",
+			`{{ .val | default "fallback" }}`,
+			map[string]interface{}{"val": "value"},
+			"value",
+		},
+		{
+			"default uses fallback if value is empty",
+			`{{ .val | default "fallback" }}`,
+			map[string]interface{}{"val": ""},
+			"fallback",
+		},
+		{
+			"default uses fallback if value is nil",
+			`{{ .val | default 42 }}`,
+			map[string]interface{}{"val": nil},
+			"42",
+		},
+		{
+			"join joins items of list parameter",
+			`{{ .val | join ", " }}`,
+			map[string]interface{}{"val": `[ "a","b\"c" ]`},
+			`a, b\"c`,
+		},
+		{
+			"join joins and escapes items of list",
+			`{{ .val | join ", " }}`,
+			map[string]interface{}{"val": []interface{}{"a", `b"c`, 1}},
+			`a, b\"c, 1`,
+		},
+		{
+			"range over list parameter",
+			`[{{ range $i, $v := list .val }}{{ if $i }},{{ end }}{"name": "{{ $v }}"}{{ end }}]`,
+			map[string]interface{}{"val": `[ "a","b" ]`},
+			`[{"name": "a"},{"name": "b"}]`,
+		},
+		{
+			"toJson keeps escaped strings and sorts keys",
+			`{{ toJson .val }}`,
+			map[string]interface{}{"val": map[string]interface{}{"b": []interface{}{1, true, nil}, "a": `x\"y`}},
+			`{"a":"x\"y","b":[1,true,null]}`,
+		},
+		{
+			"toJson of list parameter items",
+			`{{ toJson (list .val) }}`,
+			map[string]interface{}{"val": `[ "a","b" ]`},
+			`["a","b"]`,
+		},
+		{
+			"default uses fallback if value is undefined",
+			`{{ .missing | default "fallback" }}`,
+			map[string]interface{}{},
+			"fallback",
+		},
+		{
+			"default uses fallback if value is undefined and passed as argument",
+			`{{ default "fallback" .missing }}`,
+			map[string]interface{}{},
+			"fallback",
+		},
+		{
+			"default uses fallback if nested value is undefined",
+			`{{ if true }}{{ .val.missing | default "fallback" }}{{ end }}`,
+			map[string]interface{}{"val": map[string]interface{}{}},
+			"fallback",
+		},
+		{
+			"get returns defined value",
+			`{{ get . "val" | default "fallback" }}`,
+			map[string]interface{}{"val": "value"},
+			"value",
+		},
+		{
+			"upper and lower",
+			`{{ upper .val }} {{ lower .val }}`,
+			map[string]interface{}{"val": "MixedCase"},
+			"MIXEDCASE mixedcase",
+		},
+		{
+			"trim",
+			`{{ trim .val }}`,
+			map[string]interface{}{"val": "  value "},
+			"value",
+		},
+		{
+			"replace",
+			`{{ .val | replace "-" "_" }}`,
+			map[string]interface{}{"val": "a-b-c"},
+			"a_b_c",
+		},
+		{
+			"indent",
+			`{{ .val | indent 2 }}`,
+			map[string]interface{}{"val": `a\n\"b\"`},
+			`  a\n  \"b\"`,
+		},
+		{
+			"b64enc",
+			`{{ b64enc .val }}`,
+			map[string]interface{}{"val": "hello"},
+			"aGVsbG8=",
+		},
+		{
+			"b64dec escapes decoded value",
+			`{{ b64dec .val }}`,
+			map[string]interface{}{"val": "ImhlbGxvIg=="},
+			`\"hello\"`,
+		},
+		{
+			"upper keeps escaped quotes and newlines",
+			`{{ upper .val }}`,
+			map[string]interface{}{"val": `say \"hi\"\nnow`},
+			`SAY \"HI\"\nNOW`,
+		},
+		{
+			"lower keeps escaped quotes and newlines",
+			`{{ lower .val }}`,
+			map[string]interface{}{"val": `SAY \"HI\"\nNOW`},
+			`say \"hi\"\nnow`,
+		},
+		{
+			"trim removes escaped newlines",
+			`{{ trim .val }}`,
+			map[string]interface{}{"val": `  \"a\"\n`},
+			`\"a\"`,
+		},
+		{
+			"replace quotes",
+			`{{ .val | replace "\"" "'" }}`,
+			map[string]interface{}{"val": `say \"hi\"`},
+			`say 'hi'`,
+		},
+		{
+			"replace newlines",
+			`{{ .val | replace "\n" "\"" }}`,
+			map[string]interface{}{"val": `a\nb`},
+			`a\"b`,
+		},
+		{
+			"b64enc encodes actual value",
+			`{{ b64enc .val }}`,
+			map[string]interface{}{"val": `\"hi\"\n`},
+			"ImhpIgo=",
+		},
+		{
+			"sha256sum hashes actual value",
+			`{{ sha256sum .val }}`,
+			map[string]interface{}{"val": `\"hi\"\nthere`},
+			"824b86bdc7321261e85df66440a3b1cb911eab8437be992ae808a203d6fd5c48",
+		},
+		{
+			"sha256sum",
+			`{{ sha256sum .val }}`,
+			map[string]interface{}{"val": "hello"},
+			"2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Render(&InMemoryTemplate{id: "id", content: tt.template}, tt.properties)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestRender_TemplateFunctionErrors(t *testing.T) {
+	tests := []struct {
+		name       string
+		template   string
+		properties map[string]interface{}
+	}{
+		{
+			"join of non list value",
+			`{{ .val | join ", " }}`,
+			map[string]interface{}{"val": 1},
+		},
+		{
+			"list of string that is not a list parameter",
+			`{{ range list .val }}{{ . }}{{ end }}`,
+			map[string]interface{}{"val": "a,b"},
+		},
+		{
+			"undefined value not passed to default",
+			`{{ .missing | upper }}`,
+			map[string]interface{}{},
+		},
+		{
+			"b64dec of invalid value",
+			`{{ b64dec .val }}`,
+			map[string]interface{}{"val": "not base64!"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Render(&InMemoryTemplate{id: "id", content: tt.template}, tt.properties)
+			assert.Error(t, err)
+		})
+	}
+}
@@ -19,11 +19,24 @@ import (
 	"fmt"
 	"strings"
 	templ "text/template" // nosemgrep: go.lang.security.audit.xss.import-text-template.import-text-template
+
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/secret"
 )
 
 // Render tries to render a given template with the given properties and returns the
 // resulting string. if any error occurs during rendering, an error is returned.
 func Render(template Template, properties map[string]interface{}) (string, error) {
+	// secrets are only revealed when rendering, so that they are masked in all other places
+	return render(template, secret.Reveal(properties))
+}
+
+// RenderMasked works like Render, but keeps the values of secrets masked. It must be used instead of Render if the
+// rendered template is shown to users, e.g. when comparing it with remote objects.
+func RenderMasked(template Template, properties map[string]interface{}) (string, error) {
+	return render(template, properties)
+}
+
+func render(template Template, data any) (string, error) {
 	content, err := template.Content()
 	if err != nil {
 		return "", fmt.Errorf("failure trying to render template %s: %w", template.ID(), err)
@@ -41,7 +54,7 @@ func Render(template Template, properties map[string]interface{}) (string, error
 
 	result := bytes.Buffer{}
 
-	err = parsedTemplate.Execute(&result, properties)
+	err = parsedTemplate.Execute(&result, data)
 	if err != nil {
 		return "", fmt.Errorf("failure trying to render template %s: %w", template.ID(), err)
 	}
@@ -50,7 +63,18 @@ func Render(template Template, properties map[string]interface{}) (string, error
 }
 
 // ParseTemplate creates go Template with the given id from the given string content
-// in any error occurs creating the template, an erro is returned
+// in any error occurs creating the template, an erro is returned.
+// The template can use the functions of funcMap. Referencing an undefined key fails when rendering, unless the value
+// is passed to default.
 func ParseTemplate(id, content string) (*templ.Template, error) {
-	return templ.New(id).Option("missingkey=error").Parse(content)
+	t, err := templ.New(id).Option("missingkey=error").Funcs(funcMap).Parse(content)
+	if err != nil {
+		return nil, err
+	}
+	for _, tmpl := range t.Templates() {
+		if tmpl.Tree != nil {
+			passUndefinedKeysToDefault(tmpl.Tree.Root)
+		}
+	}
+	return t, nil
 }
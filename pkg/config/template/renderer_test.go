@@ -22,6 +22,8 @@ import (
 	"reflect"
 	"testing"
 	templ "text/template" // nosemgrep: go.lang.security.audit.xss.import-text-template.import-text-template
+
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/secret"
 )
 
 const (
@@ -35,8 +37,8 @@ const (
 
 func TestParseTemplate(t *testing.T) {
 
-	emptyTemplate, _ := templ.New("").Option("missingkey=error").Parse("")
-	expectedTemplate, _ := templ.New("id").Option("missingkey=error").Parse(simpleTemplateString)
+	emptyTemplate, _ := templ.New("").Option("missingkey=error").Funcs(funcMap).Parse("")
+	expectedTemplate, _ := templ.New("id").Option("missingkey=error").Funcs(funcMap).Parse(simpleTemplateString)
 
 	type args struct {
 		id      string
@@ -83,7 +85,14 @@ func TestParseTemplate(t *testing.T) {
 				t.Errorf("ParseTemplate() error = %v, wantErr %v", err, tt.wantErr)
 				return
 			}
-			if !reflect.DeepEqual(got, tt.want) {
+			// functions can't be compared, so only the parsed templates are
+			if tt.want == nil {
+				if got != nil {
+					t.Errorf("ParseTemplate() got = %v, want %v", got, tt.want)
+				}
+				return
+			}
+			if got.Name() != tt.want.Name() || !reflect.DeepEqual(got.Tree, tt.want.Tree) {
 				t.Errorf("ParseTemplate() got = %v, want %v", got, tt.want)
 			}
 		})
@@ -107,6 +116,15 @@ func TestRender(t *testing.T) {
 			`{ "key": the-key }`,
 			false,
 		},
+		{
+			"renders actual value of secrets",
+			&InMemoryTemplate{
+				content: simpleTemplateString,
+			},
+			map[string]interface{}{"val": secret.MaskedString("the-secret")},
+			`{ "key": the-secret }`,
+			false,
+		},
 		{
 			"renders simple template containing three subsequent {",
 			&InMemoryTemplate{
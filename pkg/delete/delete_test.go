@@ -1201,3 +1201,18 @@ func TestDelete_SLOv2(t *testing.T) {
 		assert.NoError(t, err)
 	})
 }
+
+func TestDelete_SkipsTypesThatCannotBeDeleted(t *testing.T) {
+	t.Setenv(featureflags.OpenPipeline.EnvName(), "true")
+
+	entriesToDelete := delete.DeleteEntries{
+		"openpipeline": {
+			{Type: "openpipeline", Project: "project", Identifier: "logs"},
+		},
+		api.DashboardShareSettings: {
+			{Type: api.DashboardShareSettings, Identifier: "dashboard"},
+		},
+	}
+	err := delete.Configs(t.Context(), client.ClientSet{}, entriesToDelete)
+	assert.NoError(t, err)
+}
@@ -17,42 +17,9 @@
 package pointer
 
 import (
-	"fmt"
-
-	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/coordinate"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config"
 )
 
 // DeletePointer contains all data needed to identify an object to be deleted from a Dynatrace environment.
-// DeletePointer is similar but not fully equivalent to config.Coordinate as it may contain an Identifier that is either
-// a Name or a ConfigID - only in case of a ConfigID is it actually equivalent to a Coordinate
-type DeletePointer struct {
-	Project string
-	Type    string
-
-	//Identifier will either be the Name of a classic Config API object, or a configID for newer types like Settings
-	Identifier string
-
-	// Scope is the Entity ID / information necessary to delete the entity. This is required for sub-path entities.
-	Scope string
-
-	// ActionType and Domain are used when deleting key-user-actions-web entities
-	ActionType, Domain string
-
-	//OriginObjectId is DT ID of the configuration. Mutually exclusive with Identifier.
-	OriginObjectId string
-}
-
-func (d DeletePointer) AsCoordinate() coordinate.Coordinate {
-	return coordinate.Coordinate{
-		Project:  d.Project,
-		Type:     d.Type,
-		ConfigId: d.Identifier,
-	}
-}
-
-func (d DeletePointer) String() string {
-	if d.Project != "" {
-		return d.AsCoordinate().String()
-	}
-	return fmt.Sprintf("%s:%s", d.Type, d.Identifier)
-}
+// It is defined in package config, so that config types can delete their objects without depending on this package.
+type DeletePointer = config.DeletePointer
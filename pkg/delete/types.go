@@ -0,0 +1,140 @@
+/*
+ * @license
+ * Copyright 2025 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package delete
+
+import (
+	"context"
+	"errors"
+	"slices"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/log"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/log/field"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/api"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/client"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/delete/internal/automation"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/delete/internal/bucket"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/delete/internal/classic"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/delete/internal/document"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/delete/internal/segment"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/delete/internal/setting"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/delete/internal/slo"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/delete/pointer"
+)
+
+// automationDeletionOrder is the order in which automation resources are deleted, so that no resource is deleted while
+// others still use it
+var automationDeletionOrder = []config.AutomationResource{config.Workflow, config.SchedulingRule, config.BusinessCalendar}
+
+// init registers how configs of the built-in config types are deleted. Each behaviour is passed the entries of all
+// coordinate types of its config type, e.g. of all classic APIs or all settings schemas.
+func init() {
+	for _, h := range []config.TypeHandler{
+		{ID: config.ClassicApiTypeID, Delete: func(ctx context.Context, clients *client.ClientSet, entries []pointer.DeletePointer) error {
+			// dashboard share settings cannot be deleted
+			lenBefore := len(entries)
+			entries = slices.DeleteFunc(entries, func(e pointer.DeletePointer) bool { return e.Type == api.DashboardShareSettings })
+			if len(entries) < lenBefore {
+				log.WithCtxFields(ctx).Warn("Classic config of type %s cannot be deleted. Note, that they can be removed by deleting the associated dashboard.", api.DashboardShareSettings)
+			}
+			if len(entries) == 0 {
+				return nil
+			}
+			if clients.ConfigClient == nil {
+				logSkippedDeletion(ctx, entries, "Classic")
+				return nil
+			}
+			return classic.Delete(ctx, clients.ConfigClient, entries)
+		}},
+		{ID: config.SettingsTypeID, Delete: func(ctx context.Context, clients *client.ClientSet, entries []pointer.DeletePointer) error {
+			if clients.SettingsClient == nil {
+				logSkippedDeletion(ctx, entries, "Settings")
+				return nil
+			}
+			var errs []error
+			for _, schemaEntries := range byType(entries) {
+				errs = append(errs, setting.Delete(ctx, clients.SettingsClient, schemaEntries))
+			}
+			return errors.Join(errs...)
+		}},
+		{ID: config.AutomationTypeID, Delete: func(ctx context.Context, clients *client.ClientSet, entries []pointer.DeletePointer) error {
+			if clients.AutClient == nil {
+				logSkippedDeletion(ctx, entries, "Automation")
+				return nil
+			}
+			entriesByResource := byType(entries)
+			var errs []error
+			for _, resource := range automationDeletionOrder {
+				if resourceEntries := entriesByResource[string(resource)]; len(resourceEntries) > 0 {
+					errs = append(errs, automation.Delete(ctx, clients.AutClient, resource, resourceEntries))
+				}
+			}
+			return errors.Join(errs...)
+		}},
+		{ID: config.BucketTypeID, Delete: func(ctx context.Context, clients *client.ClientSet, entries []pointer.DeletePointer) error {
+			if clients.BucketClient == nil {
+				logSkippedDeletion(ctx, entries, "Grail Bucket")
+				return nil
+			}
+			return bucket.Delete(ctx, clients.BucketClient, entries)
+		}},
+		{ID: config.DocumentTypeID, Delete: func(ctx context.Context, clients *client.ClientSet, entries []pointer.DeletePointer) error {
+			if clients.DocumentClient == nil {
+				logSkippedDeletion(ctx, entries, "Document")
+				return nil
+			}
+			return document.Delete(ctx, clients.DocumentClient, entries)
+		}},
+		{ID: config.OpenPipelineTypeID, Delete: func(ctx context.Context, _ *client.ClientSet, entries []pointer.DeletePointer) error {
+			log.WithCtxFields(ctx).WithFields(field.Type(string(config.OpenPipelineTypeID))).Warn("Skipped deletion of %d OpenPipeline configuration(s) as OpenPipelines cannot be deleted.", len(entries))
+			return nil
+		}},
+		{ID: config.SegmentID, Delete: func(ctx context.Context, clients *client.ClientSet, entries []pointer.DeletePointer) error {
+			if clients.SegmentClient == nil {
+				logSkippedDeletion(ctx, entries, string(config.SegmentID))
+				return nil
+			}
+			return segment.Delete(ctx, clients.SegmentClient, entries)
+		}},
+		{ID: config.ServiceLevelObjectiveID, Delete: func(ctx context.Context, clients *client.ClientSet, entries []pointer.DeletePointer) error {
+			if clients.ServiceLevelObjectiveClient == nil {
+				logSkippedDeletion(ctx, entries, string(config.ServiceLevelObjectiveID))
+				return nil
+			}
+			return slo.Delete(ctx, clients.ServiceLevelObjectiveClient, entries)
+		}},
+	} {
+		config.Types.Register(h)
+	}
+}
+
+// byType groups the given entries by their type
+func byType(entries []pointer.DeletePointer) map[string][]pointer.DeletePointer {
+	result := make(map[string][]pointer.DeletePointer)
+	for _, e := range entries {
+		result[e.Type] = append(result[e.Type], e)
+	}
+	return result
+}
+
+func logSkippedDeletion(ctx context.Context, entries []pointer.DeletePointer, kind string) {
+	logger := log.WithCtxFields(ctx)
+	if len(entries) > 0 {
+		logger = logger.WithFields(field.Type(entries[0].Type))
+	}
+	logger.Warn("Skipped deletion of %d %s configuration(s) as API client was unavailable.", len(entries), kind)
+}
@@ -0,0 +1,255 @@
+/*
+ * @license
+ * Copyright 2025 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package checkpoint implements deployment checkpoints. A checkpoint records all configs that were deployed
+// successfully, together with their resolved properties, so that a failed deployment can be resumed without
+// deploying these configs again.
+// Properties containing secrets are not persisted. Their names are recorded instead, so that they can be resolved
+// again when the deployment is resumed.
+package checkpoint
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/spf13/afero"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/secret"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/coordinate"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/entities"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter"
+)
+
+// Checkpoint holds the successfully deployed configs of all environments of a deployment. It is safe for concurrent use.
+// All methods can be called on a nil *Checkpoint, which behaves like an empty checkpoint that does not record anything.
+type Checkpoint struct {
+	mu           sync.Mutex
+	environments map[string]*Environment
+}
+
+// Environment holds the successfully deployed configs of a single environment. It is safe for concurrent use.
+// All methods can be called on a nil *Environment, which behaves like an empty checkpoint that does not record anything.
+type Environment struct {
+	entities *entities.EntityMap
+
+	mu sync.Mutex
+	// secretProperties holds the names of the secret properties of loaded entities, which were not persisted
+	secretProperties map[coordinate.Coordinate][]string
+}
+
+type persistedEntity struct {
+	Coordinate       coordinate.Coordinate `json:"coordinate"`
+	EntityName       string                `json:"entityName"`
+	Properties       parameter.Properties  `json:"properties"`
+	SecretProperties []string              `json:"secretProperties,omitempty"`
+	Response         []byte                `json:"response,omitempty"`
+}
+
+func newEnvironment() *Environment {
+	return &Environment{entities: entities.New(), secretProperties: map[coordinate.Coordinate][]string{}}
+}
+
+type persistedCheckpoint struct {
+	Environments map[string][]persistedEntity `json:"environments"`
+}
+
+// New returns an empty checkpoint
+func New() *Checkpoint {
+	return &Checkpoint{environments: map[string]*Environment{}}
+}
+
+// Environment returns the checkpoint of the environment with the given name, creating an empty one if needed
+func (c *Checkpoint) Environment(name string) *Environment {
+	if c == nil {
+		return nil
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e, found := c.environments[name]
+	if !found {
+		e = newEnvironment()
+		c.environments[name] = e
+	}
+	return e
+}
+
+// Get returns the resolved entity of the config with the given coordinate, if it was deployed successfully
+func (e *Environment) Get(c coordinate.Coordinate) (entities.ResolvedEntity, bool) {
+	if e == nil {
+		return entities.ResolvedEntity{}, false
+	}
+	return e.entities.GetResolvedEntity(c)
+}
+
+// SecretProperties returns the names of the secret properties of the config with the given coordinate that were not
+// loaded with the checkpoint. They need to be resolved again before the resolved entity of the config is used.
+func (e *Environment) SecretProperties(c coordinate.Coordinate) []string {
+	if e == nil {
+		return nil
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.secretProperties[c]
+}
+
+// Put records the given resolved entity of a successfully deployed config
+func (e *Environment) Put(r entities.ResolvedEntity) {
+	if e == nil {
+		return
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	delete(e.secretProperties, r.Coordinate)
+	e.entities.Put(r)
+}
+
+// Entities returns all recorded resolved entities, sorted by their coordinate
+func (e *Environment) Entities() []entities.ResolvedEntity {
+	if e == nil {
+		return nil
+	}
+
+	all := e.entities.Get()
+	result := make([]entities.ResolvedEntity, 0, len(all))
+	for _, r := range all {
+		result = append(result, r)
+	}
+	slices.SortFunc(result, func(a, b entities.ResolvedEntity) int {
+		return strings.Compare(a.Coordinate.String(), b.Coordinate.String())
+	})
+	return result
+}
+
+func (c *Checkpoint) MarshalJSON() ([]byte, error) {
+	p := persistedCheckpoint{Environments: map[string][]persistedEntity{}}
+	if c != nil {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		for name, e := range c.environments {
+			persisted := []persistedEntity{}
+			for _, r := range e.Entities() {
+				persisted = append(persisted, toPersistedEntity(r, e.SecretProperties(r.Coordinate)))
+			}
+			p.Environments[name] = persisted
+		}
+	}
+	return json.Marshal(p)
+}
+
+func (c *Checkpoint) UnmarshalJSON(b []byte) error {
+	var p persistedCheckpoint
+	if err := json.Unmarshal(b, &p); err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.environments = make(map[string]*Environment, len(p.Environments))
+	for name, persisted := range p.Environments {
+		e := newEnvironment()
+		for _, r := range persisted {
+			e.entities.Put(entities.ResolvedEntity{Coordinate: r.Coordinate, EntityName: r.EntityName, Properties: r.Properties, Response: r.Response})
+			if len(r.SecretProperties) > 0 {
+				e.secretProperties[r.Coordinate] = r.SecretProperties
+			}
+		}
+		c.environments[name] = e
+	}
+	return nil
+}
+
+// toPersistedEntity returns the persisted form of the given resolved entity. Properties containing secrets are left out,
+// as only their masked value could be written. Their names are recorded along with the names of secret properties that
+// were not loaded in the first place.
+func toPersistedEntity(r entities.ResolvedEntity, unresolvedSecrets []string) persistedEntity {
+	properties := make(parameter.Properties, len(r.Properties))
+	secretProperties := slices.Clone(unresolvedSecrets)
+	for name, v := range r.Properties {
+		if secret.ContainsMasked(v) {
+			secretProperties = append(secretProperties, name)
+			continue
+		}
+		properties[name] = v
+	}
+	slices.Sort(secretProperties)
+
+	return persistedEntity{
+		Coordinate:       r.Coordinate,
+		EntityName:       r.EntityName,
+		Properties:       properties,
+		SecretProperties: slices.Compact(secretProperties),
+		Response:         r.Response,
+	}
+}
+
+// Load reads the checkpoint from the given file. If the file does not exist, an empty checkpoint is returned.
+func Load(fs afero.Fs, path string) (*Checkpoint, error) {
+	b, err := afero.ReadFile(fs, path)
+	if errors.Is(err, os.ErrNotExist) {
+		return New(), nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read checkpoint file %q: %w", path, err)
+	}
+
+	c := New()
+	if err := json.Unmarshal(b, c); err != nil {
+		return nil, fmt.Errorf("failed to parse checkpoint file %q: %w", path, err)
+	}
+	return c, nil
+}
+
+// Write writes the given checkpoint to the given file, creating its directory if needed
+func Write(fs afero.Fs, path string, c *Checkpoint) error {
+	if err := fs.MkdirAll(filepath.Dir(path), 0777); err != nil {
+		return fmt.Errorf("failed to create directory of checkpoint file %q: %w", path, err)
+	}
+
+	b, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to serialize checkpoint: %w", err)
+	}
+
+	if err := afero.WriteFile(fs, path, b, 0644); err != nil {
+		return fmt.Errorf("failed to write checkpoint file %q: %w", path, err)
+	}
+	return nil
+}
+
+type ctxKeyEnvironment struct{}
+
+// NewContextWithEnvironment returns a new context with the given Environment checkpoint attached
+func NewContextWithEnvironment(ctx context.Context, e *Environment) context.Context {
+	return context.WithValue(ctx, ctxKeyEnvironment{}, e)
+}
+
+// GetEnvironmentFromContext returns the Environment checkpoint attached to the context, or nil if none is attached
+func GetEnvironmentFromContext(ctx context.Context) *Environment {
+	if e, ok := ctx.Value(ctxKeyEnvironment{}).(*Environment); ok {
+		return e
+	}
+	return nil
+}
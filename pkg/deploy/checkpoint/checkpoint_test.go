@@ -0,0 +1,126 @@
+//go:build unit
+
+/*
+ * @license
+ * Copyright 2025 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package checkpoint_test
+
+import (
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/secret"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/coordinate"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/entities"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy/checkpoint"
+)
+
+func TestCheckpoint_WriteAndLoad(t *testing.T) {
+	fs := afero.NewMemMapFs()
+
+	cp := checkpoint.New()
+	cp.Environment("dev").Put(entities.ResolvedEntity{
+		Coordinate: coordinate.Coordinate{Project: "p", Type: "t", ConfigId: "b"},
+		EntityName: "b",
+		Properties: parameter.Properties{"id": "id-b", "name": "b"},
+		Response:   []byte(`{"id":"id-b"}`),
+	})
+	cp.Environment("dev").Put(entities.ResolvedEntity{
+		Coordinate: coordinate.Coordinate{Project: "p", Type: "t", ConfigId: "a"},
+		EntityName: "a",
+		Properties: parameter.Properties{"id": "id-a"},
+	})
+	cp.Environment("prod").Put(entities.ResolvedEntity{
+		Coordinate: coordinate.Coordinate{Project: "p", Type: "t", ConfigId: "a"},
+		EntityName: "a",
+		Properties: parameter.Properties{"id": "other-id-a"},
+	})
+
+	require.NoError(t, checkpoint.Write(fs, "out/checkpoint.json", cp))
+
+	loaded, err := checkpoint.Load(fs, "out/checkpoint.json")
+	require.NoError(t, err)
+	assert.Equal(t, cp.Environment("dev").Entities(), loaded.Environment("dev").Entities())
+	assert.Equal(t, cp.Environment("prod").Entities(), loaded.Environment("prod").Entities())
+
+	e, found := loaded.Environment("prod").Get(coordinate.Coordinate{Project: "p", Type: "t", ConfigId: "a"})
+	require.True(t, found)
+	assert.Equal(t, "other-id-a", e.Properties["id"])
+}
+
+func TestCheckpoint_DoesNotPersistSecretProperties(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	coord := coordinate.Coordinate{Project: "p", Type: "t", ConfigId: "a"}
+
+	cp := checkpoint.New()
+	cp.Environment("dev").Put(entities.ResolvedEntity{
+		Coordinate: coord,
+		EntityName: "a",
+		Properties: parameter.Properties{"id": "id-a", "token": secret.MaskedString("the-secret"), "nested": map[string]any{"key": secret.MaskedString("the-secret")}},
+	})
+	require.NoError(t, checkpoint.Write(fs, "checkpoint.json", cp))
+
+	b, err := afero.ReadFile(fs, "checkpoint.json")
+	require.NoError(t, err)
+	assert.NotContains(t, string(b), "the-secret")
+	assert.NotContains(t, string(b), "****")
+
+	loaded, err := checkpoint.Load(fs, "checkpoint.json")
+	require.NoError(t, err)
+	e, found := loaded.Environment("dev").Get(coord)
+	require.True(t, found)
+	assert.Equal(t, parameter.Properties{"id": "id-a"}, e.Properties)
+	assert.Equal(t, []string{"nested", "token"}, loaded.Environment("dev").SecretProperties(coord))
+
+	// secrets that were not resolved again are kept when the checkpoint is written again
+	require.NoError(t, checkpoint.Write(fs, "checkpoint.json", loaded))
+	reloaded, err := checkpoint.Load(fs, "checkpoint.json")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"nested", "token"}, reloaded.Environment("dev").SecretProperties(coord))
+
+	// putting the resolved entity again completes it
+	loaded.Environment("dev").Put(entities.ResolvedEntity{Coordinate: coord, Properties: parameter.Properties{"id": "id-a", "token": "the-secret"}})
+	assert.Empty(t, loaded.Environment("dev").SecretProperties(coord))
+}
+
+func TestLoad_ReturnsEmptyCheckpointIfFileDoesNotExist(t *testing.T) {
+	cp, err := checkpoint.Load(afero.NewMemMapFs(), "checkpoint.json")
+	require.NoError(t, err)
+	assert.Empty(t, cp.Environment("dev").Entities())
+}
+
+func TestLoad_FailsOnInvalidFile(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	require.NoError(t, afero.WriteFile(fs, "checkpoint.json", []byte("{"), 0644))
+
+	_, err := checkpoint.Load(fs, "checkpoint.json")
+	assert.Error(t, err)
+}
+
+func TestCheckpoint_NilCheckpointIsEmpty(t *testing.T) {
+	var cp *checkpoint.Checkpoint
+	e := cp.Environment("dev")
+	e.Put(entities.ResolvedEntity{Coordinate: coordinate.Coordinate{ConfigId: "a"}})
+
+	_, found := e.Get(coordinate.Coordinate{ConfigId: "a"})
+	assert.False(t, found)
+	assert.Empty(t, e.Entities())
+	assert.Nil(t, checkpoint.GetEnvironmentFromContext(t.Context()))
+}
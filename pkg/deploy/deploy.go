@@ -18,6 +18,8 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"maps"
+	"slices"
 	"sync"
 	"time"
 
@@ -28,24 +30,23 @@ import (
 	"github.com/dynatrace/dynatrace-configuration-as-code-core/api/rest"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/cmd/monaco/dynatrace"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/environment"
-	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/featureflags"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/log"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/log/field"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/multierror"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/api"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/client"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/client/dtclient"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/entities"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter/jsonpath"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy/checkpoint"
 	deployErrors "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy/errors"
-	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy/internal/automation"
-	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy/internal/bucket"
-	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy/internal/classic"
-	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy/internal/document"
-	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy/internal/openpipeline"
-	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy/internal/segment"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy/internal/remote"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy/internal/setting"
-	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy/internal/slo"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy/internal/validate"
+	deploymentLock "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy/lock"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy/state"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/graph"
 	project "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/project/v2"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/report"
@@ -59,21 +60,68 @@ type DeployConfigsOptions struct {
 	// DryRun states that the deployment shall just run in dry-run mode, meaning
 	// that actual deployment of the configuration to a tenant will be skipped
 	DryRun bool
+	// SkipUnchanged states that the current remote state of each configuration is fetched before deploying it, and
+	// configurations whose remote object is equal to the rendered configuration are not written again
+	SkipUnchanged bool
+	// States holds the deployment state of each environment by its name. If the state of an environment is set, the
+	// remote objects recorded in it are preferred when looking up existing objects, and each successful deployment is
+	// recorded in it. States are never modified in dry-run mode.
+	States map[string]*state.State
+	// Checkpoint records all successfully deployed configs. Configs already recorded in it, e.g. by a previous failed
+	// deployment, are not deployed again, but references to them are resolved using the recorded properties.
+	// The checkpoint is not used in dry-run mode.
+	Checkpoint *checkpoint.Checkpoint
+	// RollbackOnFailure states that the remote object of each config is fetched before deploying it. If the deployment
+	// to an environment fails, all updated objects of that environment are restored and all created objects are deleted.
+	RollbackOnFailure bool
+	// Stages orders the environments into stages, which are deployed one after the other. The deployment stops after
+	// the first stage with errors. Each environment must be part of a stage. If no stages are given, all environments
+	// are deployed in a single stage.
+	Stages []Stage
+	// ParallelEnvironments is the maximum number of environments of a stage that are deployed concurrently. If it is
+	// less than 2, environments are deployed one after the other.
+	ParallelEnvironments int
+	// EnvironmentHooks holds the hooks run around the deployment of each environment by environment name
+	EnvironmentHooks map[string]config.Hooks
+	// ProjectHooks holds the hooks run around the deployment of the configs of a project to an environment by project
+	// ID. They are run once per environment, inside the hooks of the environment.
+	ProjectHooks map[string]config.Hooks
+	// DefaultDeploymentPolicy defines how configs are retried and timed out, unless their type or the config itself
+	// defines otherwise
+	DefaultDeploymentPolicy config.DeploymentPolicy
+	// DeploymentPolicies holds the deployment policies by config type. The policy of a config takes precedence over
+	// the policy of its type, which takes precedence over the DefaultDeploymentPolicy.
+	DeploymentPolicies map[string]config.DeploymentPolicy
+	// ConcurrentDeployments holds the maximum number of configs deployed concurrently by environment name. For
+	// environments without a limit, the limit defined by the environment.ConcurrentDeploymentsEnvKey applies.
+	ConcurrentDeployments map[string]int
+	// MaxConcurrencyPerType holds the maximum number of configs of a type that are deployed concurrently to an
+	// environment by config type. It takes precedence over the api.API.MaxConcurrency of classic config APIs.
+	MaxConcurrencyPerType map[string]int
+	// Locker locks each environment for the duration of the deployment, so that no other deployment using the same
+	// locks deploys to it concurrently. If it is nil, environments are not locked. Environments are not locked in
+	// dry-run mode.
+	Locker *deploymentLock.Locker
+
+	// apis holds all classic config APIs. It is set once by Deploy, so that it isn't created again for each config.
+	apis api.APIs
 }
 
 var (
-	lock                         sync.Mutex
-	concurrentDeploymentsLimiter *rest.ConcurrentRequestLimiter
+	lock sync.Mutex
 
-	skipError = errors.New("skip error")
+	skipError      = errors.New("skip error")
+	unchangedError = errors.New("unchanged error")
 )
 
 func Deploy(ctx context.Context, projects []project.Project, environmentClients dynatrace.EnvironmentClients, opts DeployConfigsOptions) error {
 	maxConcurrentDeployments := environment.GetEnvValueIntLog(environment.ConcurrentDeploymentsEnvKey)
 	if maxConcurrentDeployments > 0 {
 		log.Info("%s set, limiting concurrent deployments to %d", environment.ConcurrentDeploymentsEnvKey, maxConcurrentDeployments)
-		concurrentDeploymentsLimiter = rest.NewConcurrentRequestLimiter(maxConcurrentDeployments)
 	}
+	opts.ConcurrentDeployments = concurrentDeploymentsPerEnvironment(environmentClients, opts.ConcurrentDeployments, maxConcurrentDeployments)
+	opts.apis = api.NewAPIs()
+
 	deploymentErrs := make(deployErrors.EnvironmentDeploymentErrors)
 
 	// note: Currently the validation works 'environment-independent', but that might be something we should reconsider to improve error messages
@@ -95,23 +143,54 @@ func Deploy(ctx context.Context, projects []project.Project, environmentClients
 	preloadCaches(ctx, projects, environmentClients)
 	g := graph.New(projects, environmentClients.Names())
 
-	for env, clientset := range environmentClients {
-		ctx := newContextWithEnvironment(ctx, env)
-		log.WithCtxFields(ctx).Info("Deploying configurations to environment %q...", env.Name)
+	stages, err := getRolloutStages(environmentClients, opts.Stages)
+	if err != nil {
+		return err
+	}
 
-		sortedConfigs, err := g.GetIndependentlySortedConfigs(env.Name)
+	if !opts.DryRun && opts.Locker != nil {
+		lockCtx, locks, err := acquireLocks(ctx, opts.Locker, environmentClients.Names())
 		if err != nil {
-			return fmt.Errorf("failed to get independently sorted configs for environment %q: %w", env.Name, err)
+			return err
 		}
+		defer releaseLocks(locks)
+		ctx = lockCtx
+	}
 
-		if err = deployComponents(ctx, sortedConfigs, clientset); err != nil {
-			log.WithFields(field.Environment(env.Name, env.Group), field.Error(err)).Error("Deployment failed for environment %q: %v", env.Name, err)
-			deploymentErrs = deploymentErrs.Append(env.Name, err)
-			if !opts.ContinueOnErr && !opts.DryRun {
-				return deploymentErrs
+	for i, stage := range stages {
+		if stage.Name != "" {
+			log.Info("Deploying stage %q (%d/%d)...", stage.Name, i+1, len(stages))
+		}
+
+		if !opts.DryRun {
+			for _, gate := range stage.Gates {
+				if err := gate.Wait(ctx, stage.Name); err != nil {
+					return fmt.Errorf("gate of stage %q did not pass: %w", stage.Name, err)
+				}
 			}
-		} else {
-			log.WithFields(field.Environment(env.Name, env.Group)).Info("Deployment successful for environment %q", env.Name)
+		}
+
+		sortedConfigs := make(map[string][]graph.SortedComponent, len(stage.environments))
+		for _, env := range stage.environments {
+			sc, err := g.GetIndependentlySortedConfigs(env.Name)
+			if err != nil {
+				return fmt.Errorf("failed to get independently sorted configs for environment %q: %w", env.Name, err)
+			}
+			sortedConfigs[env.Name] = sc
+		}
+
+		stageErrs := deployStage(ctx, stage.environments, sortedConfigs, environmentClients, opts)
+		for env, errs := range stageErrs {
+			deploymentErrs = deploymentErrs.Append(env, errs...)
+		}
+		stageFailed := len(stageErrs) > 0
+		if stageFailed && !opts.ContinueOnErr && !opts.DryRun {
+			return deploymentErrs
+		}
+
+		if stageFailed && !opts.DryRun && i < len(stages)-1 {
+			log.Error("Deployment of stage %q failed, the following stages are not deployed", stage.Name)
+			return deploymentErrs
 		}
 	}
 
@@ -122,16 +201,156 @@ func Deploy(ctx context.Context, projects []project.Project, environmentClients
 	return nil
 }
 
-func deployComponents(ctx context.Context, components []graph.SortedComponent, clientset *client.ClientSet) error {
+// acquireLocks locks all given environments in alphabetical order. If any environment can't be locked, the locks
+// acquired so far are released again. The returned context is cancelled if any of the locks is taken over by another
+// deployment.
+func acquireLocks(ctx context.Context, locker *deploymentLock.Locker, environments []string) (context.Context, []*deploymentLock.Lock, error) {
+	environments = slices.Sorted(slices.Values(environments))
+	locks := make([]*deploymentLock.Lock, 0, len(environments))
+	for _, env := range environments {
+		l, err := locker.Acquire(ctx, env)
+		if err != nil {
+			releaseLocks(locks)
+			return nil, nil, fmt.Errorf("failed to lock environment %q: %w", env, err)
+		}
+		log.WithFields(field.Environment(env, "")).Debug("Locked environment %q", env)
+		locks = append(locks, l)
+		ctx = l.Context()
+	}
+	return ctx, locks, nil
+}
+
+func releaseLocks(locks []*deploymentLock.Lock) {
+	for _, l := range locks {
+		if err := l.Release(); err != nil {
+			log.WithFields(field.Environment(l.Info().Environment, ""), field.Error(err)).Error("Failed to release lock of environment %q: %v", l.Info().Environment, err)
+		}
+	}
+}
+
+// deployStage deploys the given environments, up to opts.ParallelEnvironments of them concurrently, and returns the
+// errors of all failed environments. Unless the deployment continues on errors, no further environments are started
+// after the first failed one.
+func deployStage(ctx context.Context, envs []dynatrace.EnvironmentInfo, sortedConfigs map[string][]graph.SortedComponent, environmentClients dynatrace.EnvironmentClients, opts DeployConfigsOptions) deployErrors.EnvironmentDeploymentErrors {
+	var (
+		mu   sync.Mutex
+		wg   sync.WaitGroup
+		errs = make(deployErrors.EnvironmentDeploymentErrors)
+	)
+	failed := func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return len(errs) > 0
+	}
+
+	slots := make(chan struct{}, max(opts.ParallelEnvironments, 1))
+	for _, env := range envs {
+		slots <- struct{}{}
+		if failed() && !opts.ContinueOnErr && !opts.DryRun {
+			break
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-slots }()
+
+			if err := deployEnvironment(ctx, env, sortedConfigs[env.Name], environmentClients[env], opts); err != nil {
+				mu.Lock()
+				defer mu.Unlock()
+				errs = errs.Append(env.Name, err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	return errs
+}
+
+func deployEnvironment(ctx context.Context, env dynatrace.EnvironmentInfo, sortedConfigs []graph.SortedComponent, clientset *client.ClientSet, opts DeployConfigsOptions) error {
+	ctx = newContextWithEnvironment(ctx, env)
+	if limit := opts.ConcurrentDeployments[env.Name]; limit > 0 {
+		ctx = newContextWithDeploymentLimiter(ctx, rest.NewConcurrentRequestLimiter(limit))
+	}
+	ctx = newContextWithTypeLimiters(ctx, newTypeLimiters(maxConcurrencyPerType(opts.apis, sortedConfigs, opts.MaxConcurrencyPerType)))
+	if !opts.DryRun {
+		ctx = state.NewContextWithState(ctx, opts.States[env.Name])
+		ctx = checkpoint.NewContextWithEnvironment(ctx, opts.Checkpoint.Environment(env.Name))
+	}
+	log.WithCtxFields(ctx).Info("Deploying configurations to environment %q...", env.Name)
+
+	var j *journal
+	if opts.RollbackOnFailure && !opts.DryRun {
+		j = newJournal()
+		ctx = newContextWithJournal(ctx, j)
+	}
+
+	if err := deployComponentsWithHooks(ctx, env, sortedConfigs, clientset, opts); err != nil {
+		log.WithFields(field.Environment(env.Name, env.Group), field.Error(err)).Error("Deployment failed for environment %q: %v", env.Name, err)
+		if j != nil {
+			if rollbackErr := rollback(ctx, sortedConfigs, clientset, j); rollbackErr != nil {
+				err = errors.Join(err, rollbackErr)
+			}
+		}
+		if !opts.DryRun {
+			if hookErr := runHook(ctx, opts.EnvironmentHooks[env.Name], hookInput{Event: hookEventOnError, Environment: env.Name, Group: env.Group, Error: err.Error()}); hookErr != nil {
+				log.WithFields(field.Environment(env.Name, env.Group), field.Error(hookErr)).Error("Failed to run hook: %v", hookErr)
+			}
+		}
+		return err
+	}
+
+	log.WithFields(field.Environment(env.Name, env.Group)).Info("Deployment successful for environment %q", env.Name)
+	return nil
+}
+
+// deployComponentsWithHooks deploys the given components, running the preDeploy and postDeploy hooks of the environment
+// and of each project with configs in the components once before and after. If deploying the components fails, the
+// onError hooks of the projects are run. Hooks are not run in dry-run mode.
+func deployComponentsWithHooks(ctx context.Context, env dynatrace.EnvironmentInfo, components []graph.SortedComponent, clientset *client.ClientSet, opts DeployConfigsOptions) error {
+	if opts.DryRun {
+		return deployComponents(ctx, components, clientset, opts)
+	}
+
+	hooks := opts.EnvironmentHooks[env.Name]
+	if err := runHook(ctx, hooks, hookInput{Event: hookEventPreDeploy, Environment: env.Name, Group: env.Group}); err != nil {
+		return err
+	}
+
+	projects := projectsOf(components)
+	err := runProjectHooks(ctx, projects, opts, hookInput{Event: hookEventPreDeploy, Environment: env.Name, Group: env.Group})
+	if err == nil {
+		err = deployComponents(ctx, components, clientset, opts)
+	}
+	if err != nil {
+		for _, p := range projects {
+			if hookErr := runHook(ctx, opts.ProjectHooks[p], hookInput{Event: hookEventOnError, Environment: env.Name, Group: env.Group, Project: p, Error: err.Error()}); hookErr != nil {
+				log.WithFields(field.Environment(env.Name, env.Group), field.Error(hookErr)).Error("Failed to run hook: %v", hookErr)
+			}
+		}
+		return err
+	}
+
+	if err := runProjectHooks(ctx, projects, opts, hookInput{Event: hookEventPostDeploy, Environment: env.Name, Group: env.Group}); err != nil {
+		return err
+	}
+	return runHook(ctx, hooks, hookInput{Event: hookEventPostDeploy, Environment: env.Name, Group: env.Group})
+}
+
+func deployComponents(ctx context.Context, components []graph.SortedComponent, clientset *client.ClientSet, opts DeployConfigsOptions) error {
 	log.WithCtxFields(ctx).Info("Deploying %d independent configuration sets in parallel...", len(components))
 	errCount := 0
 	errChan := make(chan error, len(components))
 
 	resolvedEntities := entities.New()
+	for _, e := range checkpoint.GetEnvironmentFromContext(ctx).Entities() {
+		resolvedEntities.Put(e)
+	}
+
 	// Iterate over components and launch a goroutine for each component deployment.
 	for i := range components {
 		go func(ctx context.Context, component graph.SortedComponent) {
-			errChan <- deployGraph(ctx, component.Graph, clientset, resolvedEntities)
+			errChan <- deployGraph(ctx, component.Graph, clientset, resolvedEntities, opts)
 		}(context.WithValue(ctx, log.CtxGraphComponentId{}, log.CtxValGraphComponentId(i)), components[i])
 	}
 
@@ -154,7 +373,7 @@ func deployComponents(ctx context.Context, components []graph.SortedComponent, c
 	return nil
 }
 
-func deployGraph(ctx context.Context, configGraph *simple.DirectedGraph, clientset *client.ClientSet, resolvedEntities *entities.EntityMap) error {
+func deployGraph(ctx context.Context, configGraph *simple.DirectedGraph, clientset *client.ClientSet, resolvedEntities *entities.EntityMap, opts DeployConfigsOptions) error {
 	g := simple.NewDirectedGraph()
 	gonum.Copy(g, configGraph)
 
@@ -166,10 +385,8 @@ func deployGraph(ctx context.Context, configGraph *simple.DirectedGraph, clients
 
 		for _, root := range roots {
 			node := root.(graph.ConfigNode)
-			time.Sleep(api.NewAPIs()[node.Config.Coordinate.Type].DeployWaitDuration)
-
 			go func(ctx context.Context, node graph.ConfigNode) {
-				errChan <- deployNode(ctx, node, configGraph, clientset, resolvedEntities)
+				errChan <- deployNode(ctx, node, configGraph, clientset, resolvedEntities, opts)
 			}(context.WithValue(ctx, log.CtxKeyCoord{}, node.Config.Coordinate), node)
 		}
 
@@ -195,18 +412,59 @@ func deployGraph(ctx context.Context, configGraph *simple.DirectedGraph, clients
 	return nil
 }
 
-func deployNode(ctx context.Context, n graph.ConfigNode, configGraph graph.ConfigGraph, clientset *client.ClientSet, resolvedEntities *entities.EntityMap) error {
+func deployNode(ctx context.Context, n graph.ConfigNode, configGraph graph.ConfigGraph, clientset *client.ClientSet, resolvedEntities *entities.EntityMap, opts DeployConfigsOptions) error {
 	ctx = report.NewContextWithDetailer(ctx, report.NewDefaultDetailer())
-	resolvedEntity, err := deployConfig(ctx, n.Config, clientset, resolvedEntities)
-	details := report.GetDetailerFromContextOrDiscard(ctx).GetAll()
 
-	if err != nil {
-		if errors.Is(err, skipError) {
-			report.GetReporterFromContextOrDiscard(ctx).ReportDeployment(n.Config.Coordinate, report.StateExcluded, details, nil)
-		} else {
-			report.GetReporterFromContextOrDiscard(ctx).ReportDeployment(n.Config.Coordinate, report.StateError, details, err)
+	if resumedEntity, found := checkpoint.GetEnvironmentFromContext(ctx).Get(n.Config.Coordinate); found {
+		if err := resolveSecretProperties(ctx, n.Config, resumedEntity, resolvedEntities); err != nil {
+			log.WithCtxFields(ctx).WithFields(field.Error(err), field.StatusDeploymentFailed()).Error("Failed to resume config deployed by a previous deployment: %v", err)
+			report.GetDetailerFromContextOrDiscard(ctx).Add(report.Detail{Type: report.DetailTypeError, Message: err.Error()})
+			report.GetReporterFromContextOrDiscard(ctx).ReportDeployment(n.Config.Coordinate, report.StateError, report.GetDetailerFromContextOrDiscard(ctx).GetAll(), err)
+
+			lock.Lock()
+			removeChildren(ctx, n, n, configGraph, true)
+			lock.Unlock()
+			return err
+		}
+		report.GetDetailerFromContextOrDiscard(ctx).Add(report.Detail{Type: report.DetailTypeInfo, Message: "Deployed by a previous deployment according to the checkpoint"})
+		report.GetReporterFromContextOrDiscard(ctx).ReportDeployment(n.Config.Coordinate, report.StateSuccess, report.GetDetailerFromContextOrDiscard(ctx).GetAll(), nil)
+		log.WithCtxFields(ctx).WithFields(field.StatusDeploymentSkipped()).Info("Config was already deployed according to the checkpoint")
+		return nil
+	}
+
+	resolvedEntity, err := deployConfig(ctx, n.Config, clientset, resolvedEntities, opts)
+	if err == nil && !opts.DryRun && isReferencedByJSONPath(n, configGraph) {
+		if err = useRemoteObjectAsResponse(ctx, n.Config, clientset, &resolvedEntity); err != nil {
+			log.WithCtxFields(ctx).WithFields(field.Error(err), field.StatusDeploymentFailed()).Error("Deployment failed - %v", err)
+			report.GetDetailerFromContextOrDiscard(ctx).Add(report.Detail{Type: report.DetailTypeError, Message: err.Error()})
 		}
-	} else {
+	}
+	if err == nil {
+		entityID, _ := resolvedEntity.Properties[config.IdParameter].(string)
+		if err = runConfigHooks(ctx, n.Config, opts, hookInput{Event: hookEventPostDeploy, Properties: resolvedEntity.Properties, EntityID: entityID}); err != nil {
+			log.WithCtxFields(ctx).WithFields(field.Error(err), field.StatusDeploymentFailed()).Error("Deployment failed - %v", err)
+			report.GetDetailerFromContextOrDiscard(ctx).Add(report.Detail{Type: report.DetailTypeError, Message: err.Error()})
+		}
+	}
+	if err != nil && !errors.Is(err, skipError) && !errors.Is(err, unchangedError) {
+		runOnErrorHooks(ctx, n.Config, opts, err)
+	}
+	details := report.GetDetailerFromContextOrDiscard(ctx).GetAll()
+
+	switch {
+	case errors.Is(err, skipError):
+		report.GetReporterFromContextOrDiscard(ctx).ReportDeployment(n.Config.Coordinate, report.StateExcluded, details, nil)
+	case errors.As(err, &verificationError{}):
+		report.GetReporterFromContextOrDiscard(ctx).ReportDeployment(n.Config.Coordinate, report.StateVerificationFailed, details, err)
+	case errors.Is(err, unchangedError):
+		report.GetReporterFromContextOrDiscard(ctx).ReportDeployment(n.Config.Coordinate, report.StateUnchanged, details, nil)
+		resolvedEntities.Put(resolvedEntity)
+		checkpoint.GetEnvironmentFromContext(ctx).Put(resolvedEntity)
+		log.WithCtxFields(ctx).WithFields(field.StatusDeploymentSkipped()).Info("Remote configuration is unchanged")
+		return nil
+	case err != nil:
+		report.GetReporterFromContextOrDiscard(ctx).ReportDeployment(n.Config.Coordinate, report.StateError, details, err)
+	default:
 		report.GetReporterFromContextOrDiscard(ctx).ReportDeployment(n.Config.Coordinate, report.StateSuccess, details, nil)
 	}
 
@@ -224,10 +482,83 @@ func deployNode(ctx context.Context, n graph.ConfigNode, configGraph graph.Confi
 	}
 
 	resolvedEntities.Put(resolvedEntity)
+	checkpoint.GetEnvironmentFromContext(ctx).Put(resolvedEntity)
 	log.WithCtxFields(ctx).WithFields(field.StatusDeployed()).Info("Deployment successful")
 	return nil
 }
 
+// isReferencedByJSONPath returns whether any config depending on the given node references the response to its
+// deployment with a jsonPath parameter
+func isReferencedByJSONPath(n graph.ConfigNode, configGraph graph.ConfigGraph) bool {
+	lock.Lock()
+	defer lock.Unlock()
+
+	children := configGraph.From(n.ID())
+	for children.Next() {
+		child := children.Node().(graph.ConfigNode)
+		for _, p := range child.Config.Parameters {
+			if p.GetType() != jsonpath.JSONPathParameterType {
+				continue
+			}
+			for _, ref := range p.GetReferences() {
+				if ref.Config == n.Config.Coordinate {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// useRemoteObjectAsResponse replaces the response of deployed classic API and settings configs, which the clients make
+// up from the ID and name of the object only, by the deployed remote object. This is the same document a plan resolves
+// jsonPath parameters against.
+func useRemoteObjectAsResponse(ctx context.Context, c *config.Config, clientset *client.ClientSet, resolvedEntity *entities.ResolvedEntity) error {
+	switch c.Type.(type) {
+	case config.ClassicApiType, config.SettingsType:
+	default:
+		return nil
+	}
+
+	obj, found, err := remote.Get(ctx, clientset, resolvedEntity.Properties, c)
+	if err != nil {
+		return fmt.Errorf("failed to fetch deployed object to resolve jsonPath parameters referencing it: %w", err)
+	}
+	if !found {
+		return fmt.Errorf("deployed object to resolve jsonPath parameters referencing it was not found")
+	}
+	resolvedEntity.Response = obj.Payload
+	return nil
+}
+
+// resolveSecretProperties resolves the secret properties of a config resumed from the checkpoint again, as only their
+// names are stored in the checkpoint. The completed resolved entity replaces the one loaded from the checkpoint.
+func resolveSecretProperties(ctx context.Context, c *config.Config, resumedEntity entities.ResolvedEntity, resolvedEntities *entities.EntityMap) error {
+	cp := checkpoint.GetEnvironmentFromContext(ctx)
+	secretProperties := cp.SecretProperties(c.Coordinate)
+	if len(secretProperties) == 0 {
+		return nil
+	}
+
+	properties, errs := c.ResolveParameterValues(resolvedEntities)
+	if len(errs) > 0 {
+		return fmt.Errorf("failed to resolve secret properties %v again: %w", secretProperties, multierror.New(errs...))
+	}
+
+	resumedEntity.Properties = maps.Clone(resumedEntity.Properties)
+	for _, name := range secretProperties {
+		v, found := properties[name]
+		if !found {
+			return fmt.Errorf("secret property %q is not a parameter of the config and can't be resolved again", name)
+		}
+		resumedEntity.Properties[name] = v
+	}
+
+	resolvedEntities.Put(resumedEntity)
+	cp.Put(resumedEntity)
+	return nil
+}
+
 func removeChildren(ctx context.Context, parent, root graph.ConfigNode, configGraph graph.ConfigGraph, failed bool) {
 
 	children := configGraph.From(parent.ID())
@@ -262,10 +593,32 @@ func removeChildren(ctx context.Context, parent, root graph.ConfigNode, configGr
 	}
 }
 
-func deployConfig(ctx context.Context, c *config.Config, clientset *client.ClientSet, resolvedEntities config.EntityLookup) (entities.ResolvedEntity, error) {
-	if concurrentDeploymentsLimiter != nil {
-		concurrentDeploymentsLimiter.Acquire()
-		defer concurrentDeploymentsLimiter.Release()
+func deployConfig(ctx context.Context, c *config.Config, clientset *client.ClientSet, resolvedEntities config.EntityLookup, opts DeployConfigsOptions) (entities.ResolvedEntity, error) {
+	// the limiter of the type is acquired first, so that waiting for it doesn't block deployments of other types
+	if limiter := getTypeLimitersFromContext(ctx).get(c.Coordinate.Type); limiter != nil {
+		limiter.Acquire()
+		defer limiter.Release()
+	}
+	// some APIs require a pause between deployments of their configs. It is kept while only the limiter of the type is
+	// acquired, so that it delays further deployments of the type, but not of other types.
+	if !c.Skip {
+		if err := wait(ctx, opts.apis[c.Coordinate.Type].DeployWaitDuration); err != nil {
+			return entities.ResolvedEntity{}, err
+		}
+	}
+	if limiter := getDeploymentLimiterFromContext(ctx); limiter != nil {
+		limiter.Acquire()
+		defer limiter.Release()
+	}
+
+	policy := opts.DefaultDeploymentPolicy.With(opts.DeploymentPolicies[c.Coordinate.Type]).With(c.DeploymentPolicy)
+	if policy.Deadline > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, policy.Deadline)
+		defer cancel()
+	}
+	if !policy.IsEmpty() {
+		ctx = dtclient.NewContextWithRetryPolicy(ctx, dtclient.RetryPolicy{MaxRetries: policy.MaxRetries, WaitTime: policy.Backoff, RequestTimeout: policy.RequestTimeout})
 	}
 
 	if c.Skip {
@@ -288,50 +641,27 @@ func deployConfig(ctx context.Context, c *config.Config, clientset *client.Clien
 		return entities.ResolvedEntity{}, err
 	}
 
-	log.WithCtxFields(ctx).WithFields(field.StatusDeploying()).Info("Deploying config")
-	var resolvedEntity entities.ResolvedEntity
-	var deployErr error
-	switch c.Type.(type) {
-	case config.SettingsType:
-		resolvedEntity, deployErr = setting.Deploy(ctx, clientset.SettingsClient, properties, renderedConfig, c)
-
-	case config.ClassicApiType:
-		resolvedEntity, deployErr = classic.Deploy(ctx, clientset.ConfigClient, api.NewAPIs(), properties, renderedConfig, c)
-
-	case config.AutomationType:
-		resolvedEntity, deployErr = automation.Deploy(ctx, clientset.AutClient, properties, renderedConfig, c)
-
-	case config.BucketType:
-		resolvedEntity, deployErr = bucket.Deploy(ctx, clientset.BucketClient, properties, renderedConfig, c)
-
-	case config.DocumentType:
-		resolvedEntity, deployErr = document.Deploy(ctx, clientset.DocumentClient, properties, renderedConfig, c)
-
-	case config.OpenPipelineType:
-		if featureflags.OpenPipeline.Enabled() {
-			resolvedEntity, deployErr = openpipeline.Deploy(ctx, clientset.OpenPipelineClient, properties, renderedConfig, c)
-		} else {
-			deployErr = fmt.Errorf("unknown config-type (ID: %q)", c.Type.ID())
-		}
-
-	case config.Segment:
-		if featureflags.Segments.Enabled() {
-			resolvedEntity, deployErr = segment.Deploy(ctx, clientset.SegmentClient, properties, renderedConfig, c)
-		} else {
-			deployErr = fmt.Errorf("unknown config-type (ID: %q)", c.Type.ID())
+	if opts.SkipUnchanged && !opts.DryRun {
+		if resolvedEntity, unchanged := getUnchangedEntity(ctx, c, clientset, opts.apis, properties, renderedConfig); unchanged {
+			recordState(ctx, c, resolvedEntity, renderedConfig)
+			return resolvedEntity, unchangedError
 		}
+	}
 
-	case config.ServiceLevelObjective:
-		if featureflags.ServiceLevelObjective.Enabled() {
-			resolvedEntity, deployErr = slo.Deploy(ctx, clientset.ServiceLevelObjectiveClient, properties, renderedConfig, c)
-		} else {
-			deployErr = fmt.Errorf("unknown config-type (ID: %q)", c.Type.ID())
-		}
+	if err := runConfigHooks(ctx, c, opts, hookInput{Event: hookEventPreDeploy, Properties: properties}); err != nil {
+		log.WithCtxFields(ctx).WithFields(field.Error(err), field.StatusDeploymentFailed()).Error("Deployment failed - %v", err)
+		report.GetDetailerFromContextOrDiscard(ctx).Add(report.Detail{Type: report.DetailTypeError, Message: err.Error()})
+		return entities.ResolvedEntity{}, err
+	}
 
-	default:
-		deployErr = fmt.Errorf("unknown config-type (ID: %q)", c.Type.ID())
+	if err := takeSnapshot(ctx, c, clientset, properties); err != nil {
+		log.WithCtxFields(ctx).WithFields(field.Error(err), field.StatusDeploymentFailed()).Error("Failed to take snapshot of remote configuration for rollback: %v", err)
+		report.GetDetailerFromContextOrDiscard(ctx).Add(report.Detail{Type: report.DetailTypeError, Message: fmt.Sprintf("Failed to take snapshot for rollback: %v", err)})
+		return entities.ResolvedEntity{}, err
 	}
 
+	log.WithCtxFields(ctx).WithFields(field.StatusDeploying()).Info("Deploying config")
+	resolvedEntity, deployErr := writeConfig(ctx, c, clientset, properties, renderedConfig)
 	if deployErr != nil {
 		var responseErr coreapi.APIError
 		if errors.As(deployErr, &responseErr) {
@@ -342,9 +672,73 @@ func deployConfig(ctx context.Context, c *config.Config, clientset *client.Clien
 		log.WithCtxFields(ctx).WithFields(field.Error(deployErr)).Error("Deployment failed - Monaco Error: %v", deployErr)
 		return entities.ResolvedEntity{}, deployErr
 	}
+	resolvedEntity.DryRun = opts.DryRun
+
+	recordCreated(ctx, resolvedEntity)
+	recordState(ctx, c, resolvedEntity, renderedConfig)
+
+	if !opts.DryRun && !c.Verification.IsEmpty() {
+		if err := verifyDeployment(ctx, c, clientset, properties, renderedConfig); err != nil {
+			log.WithCtxFields(ctx).WithFields(field.Error(err), field.StatusDeploymentFailed()).Error("Deployment failed - %v", err)
+			report.GetDetailerFromContextOrDiscard(ctx).Add(report.Detail{Type: report.DetailTypeError, Message: err.Error()})
+			return entities.ResolvedEntity{}, err
+		}
+	}
 	return resolvedEntity, nil
 }
 
+// wait waits for the given duration, unless the context is cancelled before
+func wait(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return nil
+	}
+
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
+
+// writeConfig writes the rendered config to the environment using the deployer of its type
+func writeConfig(ctx context.Context, c *config.Config, clientset *client.ClientSet, properties parameter.Properties, renderedConfig string) (entities.ResolvedEntity, error) {
+	h, found := config.Types.Get(c.Type.ID())
+	if !found || h.Deploy == nil {
+		return entities.ResolvedEntity{}, fmt.Errorf("unknown config-type (ID: %q)", c.Type.ID())
+	}
+	return h.Deploy(ctx, clientset, properties, renderedConfig, c)
+}
+
+// recordState records the remote object of the deployed config in the deployment state attached to the context, if any
+func recordState(ctx context.Context, c *config.Config, resolvedEntity entities.ResolvedEntity, renderedConfig string) {
+	s := state.GetStateFromContext(ctx)
+	if s == nil {
+		return
+	}
+
+	// the numeric ID of such settings objects can't be used to find the object again
+	if _, ok := c.Type.(config.SettingsType); ok && setting.UsesNumericID(c) {
+		return
+	}
+
+	id, ok := resolvedEntity.Properties[config.IdParameter].(string)
+	if !ok || id == "" {
+		return
+	}
+	scope, _ := resolvedEntity.Properties[config.ScopeParameter].(string)
+
+	s.Put(state.Entry{
+		Coordinate: resolvedEntity.Coordinate,
+		ID:         id,
+		Scope:      scope,
+		Hash:       state.Hash(renderedConfig),
+		DeployedAt: time.Now().UTC(),
+	})
+}
+
 // logResponseError prints user-friendly messages based on the response errors status
 func logResponseError(ctx context.Context, responseErr coreapi.APIError) {
 	if responseErr.StatusCode >= 400 && responseErr.StatusCode <= 499 {
@@ -17,29 +17,43 @@
 package deploy_test
 
 import (
+	"context"
 	"fmt"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/featureflags"
 
+	"github.com/spf13/afero"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
 
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/cmd/monaco/dynatrace"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/idutils"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/secret"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/api"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/client"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/client/dtclient"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/coordinate"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/entities"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter/environment"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter/jsonpath"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter/reference"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter/value"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/template"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy/checkpoint"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy/errors"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy/internal/testutils"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy/lock"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy/state"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/graph"
 	project "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/project/v2"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/report"
 )
 
 var dashboardApi = api.API{ID: "dashboard", URLPath: "dashboard", DeprecatedBy: "dashboard-v2"}
@@ -1428,3 +1442,742 @@ func TestDeployDryRun(t *testing.T) {
 		assert.Empty(t, err)
 	})
 }
+
+func TestDeployConfigGraph_SkipsUnchangedConfigs(t *testing.T) {
+	unchanged := config.Config{
+		Template:    template.NewInMemoryTemplate("unchanged", `{"name": "a"}`),
+		Coordinate:  coordinate.Coordinate{Project: "proj", Type: "builtin:test", ConfigId: "unchanged"},
+		Type:        config.SettingsType{SchemaId: "builtin:test"},
+		Environment: "env",
+		Parameters:  config.Parameters{config.ScopeParameter: &value.ValueParameter{Value: "environment"}},
+	}
+	changed := config.Config{
+		Template:    template.NewInMemoryTemplate("changed", `{"name": "b"}`),
+		Coordinate:  coordinate.Coordinate{Project: "proj", Type: "builtin:test", ConfigId: "changed"},
+		Type:        config.SettingsType{SchemaId: "builtin:test"},
+		Environment: "env",
+		Parameters: config.Parameters{
+			config.ScopeParameter: &value.ValueParameter{Value: "environment"},
+			"ref":                 reference.New("proj", "builtin:test", "unchanged", config.IdParameter),
+		},
+	}
+
+	unchangedExternalID, err := idutils.GenerateExternalIDForSettingsObject(unchanged.Coordinate)
+	require.NoError(t, err)
+
+	c := client.NewMockSettingsClient(gomock.NewController(t))
+	c.EXPECT().Cache(gomock.Any(), gomock.Any()).AnyTimes()
+	c.EXPECT().List(gomock.Any(), "builtin:test", gomock.Any()).Times(2).DoAndReturn(
+		func(_ any, _ string, opts dtclient.ListSettingsOptions) ([]dtclient.DownloadSettingsObject, error) {
+			o := dtclient.DownloadSettingsObject{ExternalId: unchangedExternalID, ObjectId: "unchanged-id", Scope: "environment", Value: []byte(`{"name": "a"}`)}
+			if opts.Filter(o) {
+				return []dtclient.DownloadSettingsObject{o}, nil
+			}
+			return nil, nil
+		})
+	c.EXPECT().Upsert(gomock.Any(), gomock.Any(), gomock.Any()).Times(1).DoAndReturn(
+		func(_ any, obj dtclient.SettingsObject, _ dtclient.UpsertSettingsOptions) (dtclient.DynatraceEntity, error) {
+			assert.Equal(t, changed.Coordinate, obj.Coordinate)
+			return dtclient.DynatraceEntity{Id: "changed-id"}, nil
+		})
+
+	projects := []project.Project{
+		{
+			Id: "proj",
+			Configs: project.ConfigsPerTypePerEnvironments{
+				"env": project.ConfigsPerType{
+					"builtin:test": []config.Config{unchanged, changed},
+				},
+			},
+		},
+	}
+	clients := dynatrace.EnvironmentClients{
+		dynatrace.EnvironmentInfo{Name: "env"}: &client.ClientSet{SettingsClient: c},
+	}
+
+	fs := afero.NewMemMapFs()
+	reporter := report.NewDefaultReporter(fs, "report.jsonl")
+	ctx := report.NewContextWithReporter(t.Context(), reporter)
+
+	err = deploy.Deploy(ctx, projects, clients, deploy.DeployConfigsOptions{SkipUnchanged: true})
+	assert.NoError(t, err)
+
+	reporter.Stop()
+	records, err := report.ReadReportFile(fs, "report.jsonl")
+	require.NoError(t, err)
+
+	states := map[coordinate.Coordinate]report.RecordState{}
+	for _, r := range records {
+		if r.Type == report.TypeDeploy {
+			states[*r.Config] = r.State
+		}
+	}
+	assert.Equal(t, map[coordinate.Coordinate]report.RecordState{
+		unchanged.Coordinate: report.StateUnchanged,
+		changed.Coordinate:   report.StateSuccess,
+	}, states)
+}
+
+func TestDeployConfigGraph_DeploysConfigsDifferingFromRemoteObject(t *testing.T) {
+	tests := []struct {
+		name         string
+		remoteObject dtclient.DownloadSettingsObject
+	}{
+		{
+			name:         "field removed from config",
+			remoteObject: dtclient.DownloadSettingsObject{ObjectId: "id", Scope: "environment", Value: []byte(`{"name": "a", "removed": true}`)},
+		},
+		{
+			name:         "scope changed",
+			remoteObject: dtclient.DownloadSettingsObject{ObjectId: "id", Scope: "HOST-1", Value: []byte(`{"name": "a"}`)},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conf := newPlanTestSetting("conf", `{"name": "a"}`, false)
+
+			externalID, err := idutils.GenerateExternalIDForSettingsObject(conf.Coordinate)
+			require.NoError(t, err)
+			tt.remoteObject.ExternalId = externalID
+
+			c := client.NewMockSettingsClient(gomock.NewController(t))
+			c.EXPECT().Cache(gomock.Any(), gomock.Any()).AnyTimes()
+			c.EXPECT().List(gomock.Any(), "builtin:test", gomock.Any()).Times(1).DoAndReturn(
+				func(_ any, _ string, opts dtclient.ListSettingsOptions) ([]dtclient.DownloadSettingsObject, error) {
+					if opts.Filter(tt.remoteObject) {
+						return []dtclient.DownloadSettingsObject{tt.remoteObject}, nil
+					}
+					return nil, nil
+				})
+			c.EXPECT().Upsert(gomock.Any(), gomock.Any(), gomock.Any()).Times(1).Return(dtclient.DynatraceEntity{Id: "id"}, nil)
+
+			projects := []project.Project{{Id: "proj", Configs: project.ConfigsPerTypePerEnvironments{"env": {"builtin:test": {conf}}}}}
+			clients := dynatrace.EnvironmentClients{
+				dynatrace.EnvironmentInfo{Name: "env"}: &client.ClientSet{SettingsClient: c},
+			}
+
+			err = deploy.Deploy(t.Context(), projects, clients, deploy.DeployConfigsOptions{SkipUnchanged: true})
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestDeployConfigGraph_SkipsUnchangedConfigsNormalisedByAPI(t *testing.T) {
+	theAPI := api.NewAPIs()[api.KeyUserActionsWeb]
+	// the identifier of the remote object differs, but is removed from responses and ignored by the equality check of the API
+	unchanged := config.Config{
+		Template:    template.NewInMemoryTemplate("kua", `{"name": "kua", "actionType": "Load", "domain": "example.com", "meIdentifier": "APPLICATION_METHOD-OLD"}`),
+		Coordinate:  coordinate.Coordinate{Project: "proj", Type: theAPI.ID, ConfigId: "kua"},
+		Type:        config.ClassicApiType{Api: theAPI.ID},
+		Environment: "env",
+		Parameters: config.Parameters{
+			config.NameParameter:  &value.ValueParameter{Value: "kua"},
+			config.ScopeParameter: &value.ValueParameter{Value: "APPLICATION-1"},
+		},
+	}
+
+	c := client.NewMockConfigClient(gomock.NewController(t))
+	c.EXPECT().Cache(gomock.Any(), gomock.Any()).AnyTimes()
+	c.EXPECT().ExistsWithName(gomock.Any(), gomock.Any(), "kua").Times(1).Return(true, "kua-id", nil)
+	c.EXPECT().Get(gomock.Any(), gomock.Any(), "kua-id").Times(1).Return([]byte(`{"name": "kua", "actionType": "Load", "domain": "example.com", "meIdentifier": "APPLICATION_METHOD-NEW"}`), nil)
+
+	projects := []project.Project{
+		{
+			Id: "proj",
+			Configs: project.ConfigsPerTypePerEnvironments{
+				"env": project.ConfigsPerType{
+					theAPI.ID: []config.Config{unchanged},
+				},
+			},
+		},
+	}
+	clients := dynatrace.EnvironmentClients{
+		dynatrace.EnvironmentInfo{Name: "env"}: &client.ClientSet{ConfigClient: c},
+	}
+
+	err := deploy.Deploy(t.Context(), projects, clients, deploy.DeployConfigsOptions{SkipUnchanged: true})
+	assert.NoError(t, err)
+}
+
+func TestDeployConfigGraph_ResolvesJSONPathAgainstDeployedSettingsObject(t *testing.T) {
+	referenced := newPlanTestSetting("referenced", `{"name": "a"}`, false)
+	referencing := newPlanTestSetting("referencing", `{"rule": "{{ .rule }}"}`, false)
+	referencing.Parameters["rule"] = &jsonpath.JSONPathParameter{Config: referenced.Coordinate, Path: "$.rules[0].id"}
+
+	externalID, err := idutils.GenerateExternalIDForSettingsObject(referenced.Coordinate)
+	require.NoError(t, err)
+	// the server adds the rule ID, so it's only part of the remote object
+	remoteObject := dtclient.DownloadSettingsObject{ExternalId: externalID, ObjectId: "referenced-id", Scope: "environment", Value: []byte(`{"name": "a", "rules": [{"id": "rule-id"}]}`)}
+
+	c := client.NewMockSettingsClient(gomock.NewController(t))
+	c.EXPECT().Cache(gomock.Any(), gomock.Any()).AnyTimes()
+	c.EXPECT().List(gomock.Any(), "builtin:test", gomock.Any()).Times(1).DoAndReturn(
+		func(_ any, _ string, opts dtclient.ListSettingsOptions) ([]dtclient.DownloadSettingsObject, error) {
+			if opts.Filter(remoteObject) {
+				return []dtclient.DownloadSettingsObject{remoteObject}, nil
+			}
+			return nil, nil
+		})
+	c.EXPECT().Upsert(gomock.Any(), gomock.Any(), gomock.Any()).Times(2).DoAndReturn(
+		func(_ any, obj dtclient.SettingsObject, _ dtclient.UpsertSettingsOptions) (dtclient.DynatraceEntity, error) {
+			if obj.Coordinate == referencing.Coordinate {
+				assert.JSONEq(t, `{"rule": "rule-id"}`, string(obj.Content))
+			}
+			return dtclient.DynatraceEntity{Id: obj.Coordinate.ConfigId + "-id"}, nil
+		})
+
+	projects := []project.Project{{Id: "proj", Configs: project.ConfigsPerTypePerEnvironments{"env": {"builtin:test": {referenced, referencing}}}}}
+	clients := dynatrace.EnvironmentClients{
+		dynatrace.EnvironmentInfo{Name: "env"}: &client.ClientSet{SettingsClient: c},
+	}
+
+	err = deploy.Deploy(t.Context(), projects, clients, deploy.DeployConfigsOptions{})
+	assert.NoError(t, err)
+}
+
+func TestDeployConfigGraph_VerifiesDeployedObjects(t *testing.T) {
+	verified := newPlanTestSetting("verified", `{"name": "a", "enabled": true}`, false)
+	verified.Verification = config.Verification{
+		Expectations:   []config.Expectation{{Path: "$.enabled", Value: true}},
+		EqualsRendered: true,
+	}
+	normalised := newPlanTestSetting("normalised", `{"name": "b", "tiles": [1, 2], "bounds": 1}`, false)
+	normalised.Verification = config.Verification{EqualsRendered: true, Ignore: []string{"$.bounds"}}
+
+	remoteValues := map[string]string{}
+	for _, tc := range []struct {
+		c     config.Config
+		value string
+	}{
+		{c: verified, value: `{"name": "a", "enabled": true, "other": 1}`},
+		{c: normalised, value: `{"name": "b", "tiles": [], "bounds": 2}`},
+	} {
+		externalID, err := idutils.GenerateExternalIDForSettingsObject(tc.c.Coordinate)
+		require.NoError(t, err)
+		remoteValues[externalID] = tc.value
+	}
+
+	c := client.NewMockSettingsClient(gomock.NewController(t))
+	c.EXPECT().Cache(gomock.Any(), gomock.Any()).AnyTimes()
+	c.EXPECT().Upsert(gomock.Any(), gomock.Any(), gomock.Any()).Times(2).Return(dtclient.DynatraceEntity{Id: "id"}, nil)
+	c.EXPECT().List(gomock.Any(), "builtin:test", gomock.Any()).Times(2).DoAndReturn(
+		func(_ any, _ string, opts dtclient.ListSettingsOptions) ([]dtclient.DownloadSettingsObject, error) {
+			var result []dtclient.DownloadSettingsObject
+			for externalID, value := range remoteValues {
+				o := dtclient.DownloadSettingsObject{ExternalId: externalID, ObjectId: "id", Value: []byte(value)}
+				if opts.Filter(o) {
+					result = append(result, o)
+				}
+			}
+			return result, nil
+		})
+
+	projects := []project.Project{{Id: "proj", Configs: project.ConfigsPerTypePerEnvironments{"env": {"builtin:test": {verified, normalised}}}}}
+	clients := dynatrace.EnvironmentClients{
+		dynatrace.EnvironmentInfo{Name: "env"}: &client.ClientSet{SettingsClient: c},
+	}
+
+	fs := afero.NewMemMapFs()
+	reporter := report.NewDefaultReporter(fs, "report.jsonl")
+	ctx := report.NewContextWithReporter(t.Context(), reporter)
+
+	err := deploy.Deploy(ctx, projects, clients, deploy.DeployConfigsOptions{ContinueOnErr: true})
+	assert.Error(t, err)
+
+	reporter.Stop()
+	records, err := report.ReadReportFile(fs, "report.jsonl")
+	require.NoError(t, err)
+
+	states := map[coordinate.Coordinate]report.RecordState{}
+	for _, r := range records {
+		if r.Type == report.TypeDeploy {
+			states[*r.Config] = r.State
+			if r.State == report.StateVerificationFailed {
+				assert.Contains(t, r.Error, `$.tiles: expected [1,2], but got []`)
+				assert.NotContains(t, r.Error, "$.bounds")
+			}
+		}
+	}
+	assert.Equal(t, map[coordinate.Coordinate]report.RecordState{
+		verified.Coordinate:   report.StateSuccess,
+		normalised.Coordinate: report.StateVerificationFailed,
+	}, states)
+}
+
+func TestDeployConfigGraph_VerificationMasksSecrets(t *testing.T) {
+	conf, c := newPlanTestSettingWithSecret(t)
+	conf.Verification = config.Verification{EqualsRendered: true}
+	c.EXPECT().Cache(gomock.Any(), gomock.Any()).AnyTimes()
+	c.EXPECT().Upsert(gomock.Any(), gomock.Any(), gomock.Any()).Return(dtclient.DynatraceEntity{Id: "id"}, nil)
+
+	projects := []project.Project{{Id: "proj", Configs: project.ConfigsPerTypePerEnvironments{"env": {"builtin:test": {conf}}}}}
+	clients := dynatrace.EnvironmentClients{
+		dynatrace.EnvironmentInfo{Name: "env"}: &client.ClientSet{SettingsClient: c},
+	}
+
+	fs := afero.NewMemMapFs()
+	reporter := report.NewDefaultReporter(fs, "report.jsonl")
+	ctx := report.NewContextWithReporter(t.Context(), reporter)
+
+	err := deploy.Deploy(ctx, projects, clients, deploy.DeployConfigsOptions{})
+	require.Error(t, err)
+	assert.NotContains(t, err.Error(), "the-secret-token")
+	assert.NotContains(t, err.Error(), "the-old-token")
+
+	reporter.Stop()
+	records, err := report.ReadReportFile(fs, "report.jsonl")
+	require.NoError(t, err)
+
+	var verificationErrors []string
+	for _, r := range records {
+		if r.State == report.StateVerificationFailed {
+			verificationErrors = append(verificationErrors, r.Error)
+		}
+	}
+	require.Len(t, verificationErrors, 1)
+	assert.Contains(t, verificationErrors[0], `$.auth.token: expected "Bearer ****", but got "****"`)
+	assert.NotContains(t, verificationErrors[0], "the-secret-token")
+	assert.NotContains(t, verificationErrors[0], "the-old-token")
+}
+
+func TestDeployConfigGraph_RecordsDeploymentState(t *testing.T) {
+	conf := config.Config{
+		Template:    template.NewInMemoryTemplate("setting", `{"name": "a"}`),
+		Coordinate:  coordinate.Coordinate{Project: "proj", Type: "builtin:test", ConfigId: "setting"},
+		Type:        config.SettingsType{SchemaId: "builtin:test"},
+		Environment: "env",
+		Parameters:  config.Parameters{config.ScopeParameter: &value.ValueParameter{Value: "environment"}},
+	}
+	projects := []project.Project{
+		{
+			Id: "proj",
+			Configs: project.ConfigsPerTypePerEnvironments{
+				"env": project.ConfigsPerType{
+					"builtin:test": []config.Config{conf},
+				},
+			},
+		},
+	}
+
+	recorded := state.New("env")
+	recorded.Put(state.Entry{Coordinate: conf.Coordinate, ID: "recorded-id", Scope: "environment"})
+
+	c := client.NewMockSettingsClient(gomock.NewController(t))
+	c.EXPECT().Cache(gomock.Any(), gomock.Any()).AnyTimes()
+	c.EXPECT().Upsert(gomock.Any(), gomock.Any(), gomock.Any()).Times(1).DoAndReturn(
+		func(_ any, obj dtclient.SettingsObject, _ dtclient.UpsertSettingsOptions) (dtclient.DynatraceEntity, error) {
+			assert.Equal(t, "recorded-id", obj.OriginObjectId, "recorded object should be preferred")
+			return dtclient.DynatraceEntity{Id: "new-id"}, nil
+		})
+
+	clients := dynatrace.EnvironmentClients{
+		dynatrace.EnvironmentInfo{Name: "env"}: &client.ClientSet{SettingsClient: c},
+	}
+
+	err := deploy.Deploy(t.Context(), projects, clients, deploy.DeployConfigsOptions{States: map[string]*state.State{"env": recorded}})
+	require.NoError(t, err)
+
+	e, found := recorded.Get(conf.Coordinate)
+	require.True(t, found)
+	assert.Equal(t, "new-id", e.ID)
+	assert.Equal(t, "environment", e.Scope)
+	assert.Equal(t, state.Hash(`{"name": "a"}`), e.Hash)
+	assert.False(t, e.DeployedAt.IsZero())
+}
+
+func TestDeployConfigGraph_IgnoresDeploymentStateOfOtherScope(t *testing.T) {
+	conf := config.Config{
+		Template:    template.NewInMemoryTemplate("setting", `{"name": "a"}`),
+		Coordinate:  coordinate.Coordinate{Project: "proj", Type: "builtin:test", ConfigId: "setting"},
+		Type:        config.SettingsType{SchemaId: "builtin:test"},
+		Environment: "env",
+		Parameters:  config.Parameters{config.ScopeParameter: &value.ValueParameter{Value: "HOST-2"}},
+	}
+	projects := []project.Project{{Id: "proj", Configs: project.ConfigsPerTypePerEnvironments{"env": {"builtin:test": {conf}}}}}
+
+	recorded := state.New("env")
+	recorded.Put(state.Entry{Coordinate: conf.Coordinate, ID: "recorded-id", Scope: "HOST-1"})
+
+	c := client.NewMockSettingsClient(gomock.NewController(t))
+	c.EXPECT().Cache(gomock.Any(), gomock.Any()).AnyTimes()
+	c.EXPECT().Upsert(gomock.Any(), gomock.Any(), gomock.Any()).Times(1).DoAndReturn(
+		func(_ any, obj dtclient.SettingsObject, _ dtclient.UpsertSettingsOptions) (dtclient.DynatraceEntity, error) {
+			assert.Empty(t, obj.OriginObjectId, "object recorded in another scope must not be updated")
+			assert.Equal(t, "HOST-2", obj.Scope)
+			return dtclient.DynatraceEntity{Id: "new-id"}, nil
+		})
+
+	clients := dynatrace.EnvironmentClients{
+		dynatrace.EnvironmentInfo{Name: "env"}: &client.ClientSet{SettingsClient: c},
+	}
+
+	err := deploy.Deploy(t.Context(), projects, clients, deploy.DeployConfigsOptions{States: map[string]*state.State{"env": recorded}})
+	require.NoError(t, err)
+
+	e, found := recorded.Get(conf.Coordinate)
+	require.True(t, found)
+	assert.Equal(t, "new-id", e.ID)
+	assert.Equal(t, "HOST-2", e.Scope)
+}
+
+func TestDeployConfigGraph_DoesNotRecordNumericIDsOfManagementZones(t *testing.T) {
+	t.Setenv(featureflags.ManagementZoneSettingsNumericIDs.EnvName(), "true")
+
+	conf := config.Config{
+		Template:    template.NewInMemoryTemplate("mz", `{"name": "a"}`),
+		Coordinate:  coordinate.Coordinate{Project: "proj", Type: "builtin:management-zones", ConfigId: "mz"},
+		Type:        config.SettingsType{SchemaId: "builtin:management-zones"},
+		Environment: "env",
+		Parameters:  config.Parameters{config.ScopeParameter: &value.ValueParameter{Value: "environment"}},
+	}
+	projects := []project.Project{{Id: "proj", Configs: project.ConfigsPerTypePerEnvironments{"env": {"builtin:management-zones": {conf}}}}}
+
+	c := client.NewMockSettingsClient(gomock.NewController(t))
+	c.EXPECT().Cache(gomock.Any(), gomock.Any()).AnyTimes()
+	c.EXPECT().Upsert(gomock.Any(), gomock.Any(), gomock.Any()).Return(dtclient.DynatraceEntity{Id: "YTJhNmJmOGYtOThiYS00ZTgxLWJhZDYtM2ZiZGM2OTc5MTVi"}, nil)
+
+	clients := dynatrace.EnvironmentClients{
+		dynatrace.EnvironmentInfo{Name: "env"}: &client.ClientSet{SettingsClient: c},
+	}
+
+	recorded := state.New("env")
+	err := deploy.Deploy(t.Context(), projects, clients, deploy.DeployConfigsOptions{States: map[string]*state.State{"env": recorded}})
+	require.NoError(t, err)
+
+	_, found := recorded.Get(conf.Coordinate)
+	assert.False(t, found, "the numeric ID of the management zone can't be used to find the settings object again")
+}
+
+func TestDeployConfigGraph_ResumesFromCheckpoint(t *testing.T) {
+	deployed := config.Config{
+		Template:    template.NewInMemoryTemplate("deployed", `{"name": "a"}`),
+		Coordinate:  coordinate.Coordinate{Project: "proj", Type: "builtin:test", ConfigId: "deployed"},
+		Type:        config.SettingsType{SchemaId: "builtin:test"},
+		Environment: "env",
+		Parameters:  config.Parameters{config.ScopeParameter: &value.ValueParameter{Value: "environment"}},
+	}
+	remaining := config.Config{
+		Template:    template.NewInMemoryTemplate("remaining", `{"name": "b", "ref": "{{ .ref }}"}`),
+		Coordinate:  coordinate.Coordinate{Project: "proj", Type: "builtin:test", ConfigId: "remaining"},
+		Type:        config.SettingsType{SchemaId: "builtin:test"},
+		Environment: "env",
+		Parameters: config.Parameters{
+			config.ScopeParameter: &value.ValueParameter{Value: "environment"},
+			"ref":                 reference.New("proj", "builtin:test", "deployed", config.IdParameter),
+		},
+	}
+	projects := []project.Project{
+		{
+			Id: "proj",
+			Configs: project.ConfigsPerTypePerEnvironments{
+				"env": project.ConfigsPerType{
+					"builtin:test": []config.Config{deployed, remaining},
+				},
+			},
+		},
+	}
+
+	cp := checkpoint.New()
+	cp.Environment("env").Put(entities.ResolvedEntity{
+		Coordinate: deployed.Coordinate,
+		EntityName: "a",
+		Properties: parameter.Properties{config.IdParameter: "deployed-id"},
+	})
+
+	c := client.NewMockSettingsClient(gomock.NewController(t))
+	c.EXPECT().Cache(gomock.Any(), gomock.Any()).AnyTimes()
+	c.EXPECT().Upsert(gomock.Any(), gomock.Any(), gomock.Any()).Times(1).DoAndReturn(
+		func(_ any, obj dtclient.SettingsObject, _ dtclient.UpsertSettingsOptions) (dtclient.DynatraceEntity, error) {
+			assert.Equal(t, remaining.Coordinate, obj.Coordinate)
+			assert.JSONEq(t, `{"name": "b", "ref": "deployed-id"}`, string(obj.Content))
+			return dtclient.DynatraceEntity{Id: "remaining-id"}, nil
+		})
+
+	clients := dynatrace.EnvironmentClients{
+		dynatrace.EnvironmentInfo{Name: "env"}: &client.ClientSet{SettingsClient: c},
+	}
+
+	err := deploy.Deploy(t.Context(), projects, clients, deploy.DeployConfigsOptions{Checkpoint: cp})
+	require.NoError(t, err)
+
+	e, found := cp.Environment("env").Get(remaining.Coordinate)
+	require.True(t, found, "newly deployed config should be recorded in checkpoint")
+	assert.Equal(t, "remaining-id", e.Properties[config.IdParameter])
+}
+
+func TestDeployConfigGraph_ResumesFromCheckpointResolvingSecretsAgain(t *testing.T) {
+	deployed := config.Config{
+		Template:    template.NewInMemoryTemplate("deployed", `{"name": "a", "token": "{{ .token }}"}`),
+		Coordinate:  coordinate.Coordinate{Project: "proj", Type: "builtin:test", ConfigId: "deployed"},
+		Type:        config.SettingsType{SchemaId: "builtin:test"},
+		Environment: "env",
+		Parameters: config.Parameters{
+			config.ScopeParameter: &value.ValueParameter{Value: "environment"},
+			"token":               &parameter.DummyParameter{Value: secret.MaskedString("the-secret")},
+		},
+	}
+	remaining := config.Config{
+		Template:    template.NewInMemoryTemplate("remaining", `{"name": "b", "token": "{{ .token }}"}`),
+		Coordinate:  coordinate.Coordinate{Project: "proj", Type: "builtin:test", ConfigId: "remaining"},
+		Type:        config.SettingsType{SchemaId: "builtin:test"},
+		Environment: "env",
+		Parameters: config.Parameters{
+			config.ScopeParameter: &value.ValueParameter{Value: "environment"},
+			"token":               reference.New("proj", "builtin:test", "deployed", "token"),
+		},
+	}
+	projects := []project.Project{
+		{
+			Id: "proj",
+			Configs: project.ConfigsPerTypePerEnvironments{
+				"env": project.ConfigsPerType{
+					"builtin:test": []config.Config{deployed, remaining},
+				},
+			},
+		},
+	}
+
+	// the checkpoint of the previous deployment is written and loaded again, which drops the value of the secret
+	fs := afero.NewMemMapFs()
+	previous := checkpoint.New()
+	previous.Environment("env").Put(entities.ResolvedEntity{
+		Coordinate: deployed.Coordinate,
+		EntityName: "a",
+		Properties: parameter.Properties{config.IdParameter: "deployed-id", "token": secret.MaskedString("the-secret")},
+	})
+	require.NoError(t, checkpoint.Write(fs, "checkpoint.json", previous))
+	cp, err := checkpoint.Load(fs, "checkpoint.json")
+	require.NoError(t, err)
+
+	c := client.NewMockSettingsClient(gomock.NewController(t))
+	c.EXPECT().Cache(gomock.Any(), gomock.Any()).AnyTimes()
+	c.EXPECT().Upsert(gomock.Any(), gomock.Any(), gomock.Any()).Times(1).DoAndReturn(
+		func(_ any, obj dtclient.SettingsObject, _ dtclient.UpsertSettingsOptions) (dtclient.DynatraceEntity, error) {
+			assert.Equal(t, remaining.Coordinate, obj.Coordinate)
+			assert.JSONEq(t, `{"name": "b", "token": "the-secret"}`, string(obj.Content))
+			return dtclient.DynatraceEntity{Id: "remaining-id"}, nil
+		})
+
+	clients := dynatrace.EnvironmentClients{
+		dynatrace.EnvironmentInfo{Name: "env"}: &client.ClientSet{SettingsClient: c},
+	}
+
+	err = deploy.Deploy(t.Context(), projects, clients, deploy.DeployConfigsOptions{Checkpoint: cp})
+	require.NoError(t, err)
+}
+
+func TestDeployConfigGraph_ResumeFailsIfSecretOfCheckpointCantBeResolvedAgain(t *testing.T) {
+	deployed := config.Config{
+		Template:    template.NewInMemoryTemplate("deployed", `{"name": "a"}`),
+		Coordinate:  coordinate.Coordinate{Project: "proj", Type: "builtin:test", ConfigId: "deployed"},
+		Type:        config.SettingsType{SchemaId: "builtin:test"},
+		Environment: "env",
+		Parameters:  config.Parameters{config.ScopeParameter: &value.ValueParameter{Value: "environment"}},
+	}
+	remaining := config.Config{
+		Template:    template.NewInMemoryTemplate("remaining", `{"name": "b", "token": "{{ .token }}"}`),
+		Coordinate:  coordinate.Coordinate{Project: "proj", Type: "builtin:test", ConfigId: "remaining"},
+		Type:        config.SettingsType{SchemaId: "builtin:test"},
+		Environment: "env",
+		Parameters: config.Parameters{
+			config.ScopeParameter: &value.ValueParameter{Value: "environment"},
+			"token":               reference.New("proj", "builtin:test", "deployed", "token"),
+		},
+	}
+	projects := []project.Project{
+		{
+			Id: "proj",
+			Configs: project.ConfigsPerTypePerEnvironments{
+				"env": project.ConfigsPerType{
+					"builtin:test": []config.Config{deployed, remaining},
+				},
+			},
+		},
+	}
+
+	// the config no longer defines the secret it was deployed with
+	cp := checkpoint.New()
+	require.NoError(t, cp.UnmarshalJSON([]byte(`{"environments": {"env": [{"coordinate": {"project": "proj", "type": "builtin:test", "configId": "deployed"}, "properties": {"id": "deployed-id"}, "secretProperties": ["token"]}]}}`)))
+
+	c := client.NewMockSettingsClient(gomock.NewController(t))
+	c.EXPECT().Cache(gomock.Any(), gomock.Any()).AnyTimes()
+
+	clients := dynatrace.EnvironmentClients{
+		dynatrace.EnvironmentInfo{Name: "env"}: &client.ClientSet{SettingsClient: c},
+	}
+
+	err := deploy.Deploy(t.Context(), projects, clients, deploy.DeployConfigsOptions{Checkpoint: cp})
+	assert.Error(t, err)
+}
+
+func TestDeployConfigGraph_DeploymentPolicyDeadline(t *testing.T) {
+	tests := []struct {
+		name      string
+		opts      deploy.DeployConfigsOptions
+		configFn  func(c *config.Config)
+		wantError bool
+	}{
+		{
+			name: "without deadline",
+		},
+		{
+			name:      "default deadline",
+			opts:      deploy.DeployConfigsOptions{DefaultDeploymentPolicy: config.DeploymentPolicy{Deadline: time.Millisecond}},
+			wantError: true,
+		},
+		{
+			name:      "deadline of type",
+			opts:      deploy.DeployConfigsOptions{DeploymentPolicies: map[string]config.DeploymentPolicy{"builtin:test": {Deadline: time.Millisecond}}},
+			wantError: true,
+		},
+		{
+			name:      "deadline of config",
+			opts:      deploy.DeployConfigsOptions{DefaultDeploymentPolicy: config.DeploymentPolicy{Deadline: time.Hour}},
+			configFn:  func(c *config.Config) { c.DeploymentPolicy = config.DeploymentPolicy{Deadline: time.Millisecond} },
+			wantError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			projects := newRolloutTestProjects("env")
+			if tt.configFn != nil {
+				tt.configFn(&projects[0].Configs["env"]["builtin:test"][0])
+			}
+
+			c := client.NewMockSettingsClient(gomock.NewController(t))
+			c.EXPECT().Cache(gomock.Any(), gomock.Any()).AnyTimes()
+			c.EXPECT().Upsert(gomock.Any(), gomock.Any(), gomock.Any()).Times(1).DoAndReturn(
+				func(ctx context.Context, _ dtclient.SettingsObject, _ dtclient.UpsertSettingsOptions) (dtclient.DynatraceEntity, error) {
+					select {
+					case <-ctx.Done():
+						return dtclient.DynatraceEntity{}, ctx.Err()
+					case <-time.After(50 * time.Millisecond):
+						return dtclient.DynatraceEntity{Id: "id"}, nil
+					}
+				})
+			clients := dynatrace.EnvironmentClients{
+				dynatrace.EnvironmentInfo{Name: "env"}: &client.ClientSet{SettingsClient: c},
+			}
+
+			err := deploy.Deploy(t.Context(), projects, clients, tt.opts)
+			if tt.wantError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestDeployConfigGraph_LimitsConcurrentDeployments(t *testing.T) {
+	tests := []struct {
+		name           string
+		opts           deploy.DeployConfigsOptions
+		maxConcurrency int
+		maxInFlight    int
+	}{
+		{
+			name:        "concurrent deployments of environment",
+			opts:        deploy.DeployConfigsOptions{ConcurrentDeployments: map[string]int{"env": 2}},
+			maxInFlight: 2,
+		},
+		{
+			name:        "max concurrency per type",
+			opts:        deploy.DeployConfigsOptions{MaxConcurrencyPerType: map[string]int{"builtin:test": 1}},
+			maxInFlight: 1,
+		},
+		{
+			name:           "max concurrency of settings schema defined by configs",
+			maxConcurrency: 1,
+			maxInFlight:    1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			configs := make([]config.Config, 0, 5)
+			for i := range 5 {
+				c := newPlanTestSetting(fmt.Sprintf("setting-%d", i), `{}`, false)
+				c.Environment = "env"
+				if i == 0 {
+					c.DeploymentPolicy.MaxConcurrency = tt.maxConcurrency
+				}
+				configs = append(configs, c)
+			}
+			projects := []project.Project{{Id: "proj", Configs: project.ConfigsPerTypePerEnvironments{"env": {"builtin:test": configs}}}}
+
+			var (
+				mu                    sync.Mutex
+				inFlight, maxInFlight int
+			)
+			c := client.NewMockSettingsClient(gomock.NewController(t))
+			c.EXPECT().Cache(gomock.Any(), gomock.Any()).AnyTimes()
+			c.EXPECT().Upsert(gomock.Any(), gomock.Any(), gomock.Any()).Times(5).DoAndReturn(
+				func(_ context.Context, _ dtclient.SettingsObject, _ dtclient.UpsertSettingsOptions) (dtclient.DynatraceEntity, error) {
+					mu.Lock()
+					inFlight++
+					maxInFlight = max(maxInFlight, inFlight)
+					mu.Unlock()
+
+					time.Sleep(10 * time.Millisecond)
+
+					mu.Lock()
+					inFlight--
+					mu.Unlock()
+					return dtclient.DynatraceEntity{Id: "id"}, nil
+				})
+			clients := dynatrace.EnvironmentClients{
+				dynatrace.EnvironmentInfo{Name: "env"}: &client.ClientSet{SettingsClient: c},
+			}
+
+			err := deploy.Deploy(t.Context(), projects, clients, tt.opts)
+			require.NoError(t, err)
+			assert.LessOrEqual(t, maxInFlight, tt.maxInFlight)
+		})
+	}
+}
+
+func TestDeploy_LocksEnvironments(t *testing.T) {
+	c := newPlanTestSetting("setting", `{}`, false)
+	c.Environment = "env"
+	projects := []project.Project{{Id: "proj", Configs: project.ConfigsPerTypePerEnvironments{"env": {"builtin:test": {c}}}}}
+
+	t.Run("deploys and releases lock", func(t *testing.T) {
+		fs := afero.NewMemMapFs()
+		settingsClient := client.NewMockSettingsClient(gomock.NewController(t))
+		settingsClient.EXPECT().Cache(gomock.Any(), gomock.Any()).AnyTimes()
+		settingsClient.EXPECT().Upsert(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, _ dtclient.SettingsObject, _ dtclient.UpsertSettingsOptions) (dtclient.DynatraceEntity, error) {
+				exists, err := afero.Exists(fs, lock.FilePath("locks", "env"))
+				require.NoError(t, err)
+				assert.True(t, exists, "environment must be locked during deployment")
+				return dtclient.DynatraceEntity{Id: "id"}, nil
+			})
+		clients := dynatrace.EnvironmentClients{
+			dynatrace.EnvironmentInfo{Name: "env"}: &client.ClientSet{SettingsClient: settingsClient},
+		}
+
+		err := deploy.Deploy(t.Context(), projects, clients, deploy.DeployConfigsOptions{Locker: lock.NewLocker(fs, "locks", time.Minute, false)})
+		require.NoError(t, err)
+
+		exists, err := afero.Exists(fs, lock.FilePath("locks", "env"))
+		require.NoError(t, err)
+		assert.False(t, exists, "lock must be released after deployment")
+	})
+
+	t.Run("fails without deploying if environment is locked", func(t *testing.T) {
+		fs := afero.NewMemMapFs()
+		otherLock, err := lock.NewLocker(fs, "locks", time.Minute, false).Acquire(t.Context(), "env")
+		require.NoError(t, err)
+		defer otherLock.Release()
+
+		settingsClient := client.NewMockSettingsClient(gomock.NewController(t))
+		settingsClient.EXPECT().Cache(gomock.Any(), gomock.Any()).AnyTimes()
+		clients := dynatrace.EnvironmentClients{
+			dynatrace.EnvironmentInfo{Name: "env"}: &client.ClientSet{SettingsClient: settingsClient},
+		}
+
+		err = deploy.Deploy(t.Context(), projects, clients, deploy.DeployConfigsOptions{Locker: lock.NewLocker(fs, "locks", time.Minute, false)})
+		assert.ErrorAs(t, err, &lock.LockedError{})
+	})
+}
@@ -0,0 +1,81 @@
+/*
+ * @license
+ * Copyright 2025 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package deploy
+
+import (
+	"context"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/cmd/monaco/dynatrace"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/json"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/coordinate"
+	project "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/project/v2"
+)
+
+// DriftStatus describes whether the remote object of a config still matches the config
+type DriftStatus string
+
+const (
+	// DriftStatusInSync states that the remote object is equal to the rendered config
+	DriftStatusInSync DriftStatus = "in-sync"
+	// DriftStatusDrifted states that the remote object differs from the rendered config
+	DriftStatusDrifted DriftStatus = "drifted"
+	// DriftStatusMissing states that no remote object exists for the config
+	DriftStatusMissing DriftStatus = "missing"
+)
+
+// Drift is the drift status of a single config in a single environment
+type Drift struct {
+	Environment string                `json:"environment"`
+	Coordinate  coordinate.Coordinate `json:"coordinate"`
+	Status      DriftStatus           `json:"status"`
+	// RemoteID is the ID of the remote object. It is empty if the object is missing.
+	RemoteID string `json:"remoteId,omitempty"`
+	// Differences lists all values of the rendered config that differ from the remote object
+	Differences []json.Difference `json:"differences,omitempty"`
+}
+
+// DetectDrift resolves and renders all configs of the given projects for each environment and compares them with the
+// current state of the respective remote objects, in order to find objects that were changed or deleted without
+// deploying the configs. Skipped configs are not part of the result.
+// The result is ordered like the changes returned by Plan, and errors for single configs are handled the same way.
+func DetectDrift(ctx context.Context, projects []project.Project, environmentClients dynatrace.EnvironmentClients) ([]Drift, error) {
+	changes, err := Plan(ctx, projects, environmentClients)
+
+	var drifts []Drift
+	for _, c := range changes {
+		var status DriftStatus
+		switch c.Action {
+		case PlanActionCreate:
+			status = DriftStatusMissing
+		case PlanActionUpdate:
+			status = DriftStatusDrifted
+		case PlanActionUnchanged:
+			status = DriftStatusInSync
+		default:
+			continue
+		}
+
+		drifts = append(drifts, Drift{
+			Environment: c.Environment,
+			Coordinate:  c.Coordinate,
+			Status:      status,
+			RemoteID:    c.RemoteID,
+			Differences: c.Differences,
+		})
+	}
+	return drifts, err
+}
@@ -0,0 +1,106 @@
+//go:build unit
+
+/*
+ * @license
+ * Copyright 2025 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package deploy_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/cmd/monaco/dynatrace"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/idutils"
+	jsonutils "github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/json"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/client"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/client/dtclient"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy"
+	project "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/project/v2"
+)
+
+func TestDetectDrift(t *testing.T) {
+	inSync := newPlanTestSetting("in-sync", `{"name": "a"}`, false)
+	drifted := newPlanTestSetting("drifted", `{"name": "desired"}`, false)
+	missing := newPlanTestSetting("missing", `{"name": "m"}`, false)
+	skipped := newPlanTestSetting("skipped", `{}`, true)
+
+	inSyncExternalID, err := idutils.GenerateExternalIDForSettingsObject(inSync.Coordinate)
+	require.NoError(t, err)
+	driftedExternalID, err := idutils.GenerateExternalIDForSettingsObject(drifted.Coordinate)
+	require.NoError(t, err)
+	remoteObjects := []dtclient.DownloadSettingsObject{
+		{ObjectId: "in-sync-id", ExternalId: inSyncExternalID, Value: []byte(`{"name": "a"}`)},
+		{ObjectId: "drifted-id", ExternalId: driftedExternalID, Value: []byte(`{"name": "changed"}`)},
+	}
+
+	c := client.NewMockSettingsClient(gomock.NewController(t))
+	c.EXPECT().List(gomock.Any(), "builtin:test", gomock.Any()).AnyTimes().DoAndReturn(
+		func(_ any, _ string, opts dtclient.ListSettingsOptions) ([]dtclient.DownloadSettingsObject, error) {
+			var result []dtclient.DownloadSettingsObject
+			for _, o := range remoteObjects {
+				if opts.Filter(o) {
+					result = append(result, o)
+				}
+			}
+			return result, nil
+		})
+
+	projects := []project.Project{
+		{
+			Id: "proj",
+			Configs: project.ConfigsPerTypePerEnvironments{
+				"env": project.ConfigsPerType{
+					"builtin:test": []config.Config{inSync, drifted, missing, skipped},
+				},
+			},
+		},
+	}
+
+	clients := dynatrace.EnvironmentClients{
+		dynatrace.EnvironmentInfo{Name: "env"}: &client.ClientSet{SettingsClient: c},
+	}
+
+	drifts, err := deploy.DetectDrift(t.Context(), projects, clients)
+	require.NoError(t, err)
+
+	assert.ElementsMatch(t, []deploy.Drift{
+		{Environment: "env", Coordinate: inSync.Coordinate, Status: deploy.DriftStatusInSync, RemoteID: "in-sync-id"},
+		{Environment: "env", Coordinate: drifted.Coordinate, Status: deploy.DriftStatusDrifted, RemoteID: "drifted-id", Differences: []jsonutils.Difference{{Path: "$.name", Desired: "desired", Actual: "changed"}}},
+		{Environment: "env", Coordinate: missing.Coordinate, Status: deploy.DriftStatusMissing},
+	}, drifts)
+}
+
+func TestDetectDrift_MasksSecrets(t *testing.T) {
+	conf, c := newPlanTestSettingWithSecret(t)
+	projects := []project.Project{{Id: "proj", Configs: project.ConfigsPerTypePerEnvironments{"env": {"builtin:test": {conf}}}}}
+	clients := dynatrace.EnvironmentClients{
+		dynatrace.EnvironmentInfo{Name: "env"}: &client.ClientSet{SettingsClient: c},
+	}
+
+	drifts, err := deploy.DetectDrift(t.Context(), projects, clients)
+	require.NoError(t, err)
+
+	b, err := json.Marshal(drifts)
+	require.NoError(t, err)
+	assert.Contains(t, string(b), `"Bearer ****"`)
+	assert.NotContains(t, string(b), "the-secret-token")
+	assert.NotContains(t, string(b), "the-old-token")
+}
@@ -0,0 +1,172 @@
+/*
+ * @license
+ * Copyright 2025 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package deploy
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/log"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/log/field"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/maps"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/coordinate"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/graph"
+)
+
+type hookEvent string
+
+const (
+	hookEventPreDeploy  hookEvent = "preDeploy"
+	hookEventPostDeploy hookEvent = "postDeploy"
+	hookEventOnError    hookEvent = "onError"
+)
+
+// hookInput is passed to hook commands as JSON on stdin, and its scalar values as environment variables
+type hookInput struct {
+	Event       hookEvent              `json:"event"`
+	Environment string                 `json:"environment"`
+	Group       string                 `json:"group,omitempty"`
+	Project     string                 `json:"project,omitempty"`
+	Coordinate  *coordinate.Coordinate `json:"coordinate,omitempty"`
+	// Properties are the resolved parameter values of the config
+	Properties parameter.Properties `json:"properties,omitempty"`
+	// EntityID is the ID of the deployed object. It is only set after a successful deployment.
+	EntityID string `json:"entityId,omitempty"`
+	// Error is the error of a failed deployment
+	Error string `json:"error,omitempty"`
+}
+
+func (in hookInput) environ() []string {
+	env := []string{
+		"MONACO_HOOK_EVENT=" + string(in.Event),
+		"MONACO_ENVIRONMENT=" + in.Environment,
+		"MONACO_ENVIRONMENT_GROUP=" + in.Group,
+	}
+	if in.Project != "" {
+		env = append(env, "MONACO_PROJECT="+in.Project)
+	}
+	if in.Coordinate != nil {
+		env = append(env,
+			"MONACO_COORDINATE="+in.Coordinate.String(),
+			"MONACO_CONFIG_TYPE="+in.Coordinate.Type,
+			"MONACO_CONFIG_ID="+in.Coordinate.ConfigId,
+		)
+	}
+	if in.EntityID != "" {
+		env = append(env, "MONACO_ENTITY_ID="+in.EntityID)
+	}
+	if in.Error != "" {
+		env = append(env, "MONACO_ERROR="+in.Error)
+	}
+	return env
+}
+
+// commandOf returns the command of the given hooks that is run for the event
+func commandOf(h config.Hooks, event hookEvent) string {
+	switch event {
+	case hookEventPreDeploy:
+		return h.PreDeploy
+	case hookEventPostDeploy:
+		return h.PostDeploy
+	case hookEventOnError:
+		return h.OnError
+	default:
+		return ""
+	}
+}
+
+// runHook runs the command of the given hooks for the event of the input. The input is passed to the command as JSON
+// on stdin and as MONACO_* environment variables. Nothing is run if no command is defined for the event.
+func runHook(ctx context.Context, hooks config.Hooks, in hookInput) error {
+	command := commandOf(hooks, in.Event)
+	if command == "" {
+		return nil
+	}
+
+	in.Properties = maps.ToStringKeys(map[string]any(in.Properties)).(map[string]any)
+	stdin, err := json.Marshal(in)
+	if err != nil {
+		return fmt.Errorf("failed to create input of %s hook %q: %w", in.Event, command, err)
+	}
+
+	log.WithCtxFields(ctx).Debug("Running %s hook %q...", in.Event, command)
+	cmd := shellCommand(ctx, command)
+	cmd.Stdin = bytes.NewReader(stdin)
+	cmd.Env = append(os.Environ(), in.environ()...)
+
+	out, err := cmd.CombinedOutput()
+	log.WithCtxFields(ctx).Debug("Output of %s hook %q: %s", in.Event, command, out)
+	if err != nil {
+		return fmt.Errorf("%s hook %q failed: %w", in.Event, command, err)
+	}
+	return nil
+}
+
+// runConfigHooks runs the hooks of the given config. Hooks are not run in dry-run mode.
+func runConfigHooks(ctx context.Context, c *config.Config, opts DeployConfigsOptions, in hookInput) error {
+	if opts.DryRun {
+		return nil
+	}
+
+	in.Environment = c.Environment
+	in.Group = c.Group
+	in.Project = c.Coordinate.Project
+	in.Coordinate = &c.Coordinate
+	return runHook(ctx, c.Hooks, in)
+}
+
+// runProjectHooks runs the hooks of each of the given projects for the event of the input, stopping at the first
+// failing hook
+func runProjectHooks(ctx context.Context, projects []string, opts DeployConfigsOptions, in hookInput) error {
+	for _, p := range projects {
+		in.Project = p
+		if err := runHook(ctx, opts.ProjectHooks[p], in); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// projectsOf returns the sorted IDs of all projects that have configs in the given components
+func projectsOf(components []graph.SortedComponent) []string {
+	var result []string
+	for _, component := range components {
+		nodes := component.Graph.Nodes()
+		for nodes.Next() {
+			p := nodes.Node().(graph.ConfigNode).Config.Coordinate.Project
+			if !slices.Contains(result, p) {
+				result = append(result, p)
+			}
+		}
+	}
+	slices.Sort(result)
+	return result
+}
+
+// runOnErrorHooks runs the onError hooks of the given config. As the deployment already failed, errors of the hooks are
+// only logged.
+func runOnErrorHooks(ctx context.Context, c *config.Config, opts DeployConfigsOptions, deployErr error) {
+	if err := runConfigHooks(ctx, c, opts, hookInput{Event: hookEventOnError, Error: deployErr.Error()}); err != nil {
+		log.WithCtxFields(ctx).WithFields(field.Error(err)).Error("Failed to run hook: %v", err)
+	}
+}
@@ -0,0 +1,209 @@
+//go:build unit
+
+/*
+ * @license
+ * Copyright 2025 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package deploy_test
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/cmd/monaco/dynatrace"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/client"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/client/dtclient"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy"
+	project "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/project/v2"
+)
+
+// recordingHooks returns hooks that append a line with the given name, their event and the MONACO_* variable of the
+// given name to the log file
+func recordingHooks(logFile, name, variable string) config.Hooks {
+	line := func(event string) string {
+		return `echo "` + name + ` ` + event + ` $` + variable + `" >> ` + logFile
+	}
+	return config.Hooks{PreDeploy: line("pre"), PostDeploy: line("post"), OnError: line("error")}
+}
+
+func readLines(t *testing.T, file string) []string {
+	content, err := os.ReadFile(file)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	require.NoError(t, err)
+
+	lines := strings.Split(strings.TrimSpace(string(content)), "\n")
+	for i := range lines {
+		lines[i] = strings.TrimSpace(lines[i])
+	}
+	return lines
+}
+
+// hookTestSetup deploys a single config to the environment 'dev', with hooks defined for the environment, project and
+// config that are recorded in logFile
+type hookTestSetup struct {
+	logFile  string
+	projects []project.Project
+	clients  dynatrace.EnvironmentClients
+	opts     deploy.DeployConfigsOptions
+	calls    *[]string
+}
+
+func newHookTestSetup(t *testing.T, deployErr error) hookTestSetup {
+	logFile := filepath.Join(t.TempDir(), "hooks.log")
+	projects := newRolloutTestProjects("dev")
+	projects[0].Configs["dev"]["builtin:test"][0].Hooks = recordingHooks(logFile, "config", "MONACO_ENTITY_ID")
+
+	var calls []string
+	return hookTestSetup{
+		logFile:  logFile,
+		projects: projects,
+		clients: dynatrace.EnvironmentClients{
+			dynatrace.EnvironmentInfo{Name: "dev"}: newRolloutTestClient(t, "dev", &calls, deployErr),
+		},
+		opts: deploy.DeployConfigsOptions{
+			EnvironmentHooks: map[string]config.Hooks{"dev": recordingHooks(logFile, "environment", "MONACO_ENVIRONMENT")},
+			ProjectHooks:     map[string]config.Hooks{"proj": recordingHooks(logFile, "project", "MONACO_PROJECT")},
+		},
+		calls: &calls,
+	}
+}
+
+func TestDeploy_Hooks(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("hook commands of this test require a POSIX shell")
+	}
+
+	t.Run("hooks are run around successful deployments", func(t *testing.T) {
+		s := newHookTestSetup(t, nil)
+
+		err := deploy.Deploy(t.Context(), s.projects, s.clients, s.opts)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"deploy dev"}, *s.calls)
+		assert.Equal(t, []string{
+			"environment pre dev",
+			"project pre proj",
+			"config pre",
+			"config post dev-id",
+			"project post proj",
+			"environment post dev",
+		}, readLines(t, s.logFile))
+	})
+
+	t.Run("project hooks are run once for all configs of the project", func(t *testing.T) {
+		s := newHookTestSetup(t, nil)
+		other := newPlanTestSetting("other", `{}`, false)
+		other.Environment = "dev"
+		s.projects[0].Configs["dev"]["builtin:test"] = append(s.projects[0].Configs["dev"]["builtin:test"], other)
+
+		err := deploy.Deploy(t.Context(), s.projects, s.clients, s.opts)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"deploy dev", "deploy dev"}, *s.calls)
+
+		lines := readLines(t, s.logFile)
+		assert.Equal(t, []string{"environment pre dev", "project pre proj"}, lines[:2])
+		assert.Equal(t, []string{"project post proj", "environment post dev"}, lines[len(lines)-2:])
+		assert.Len(t, lines, 6)
+	})
+
+	t.Run("onError hooks are run after failed deployments", func(t *testing.T) {
+		s := newHookTestSetup(t, errors.New("failed"))
+
+		err := deploy.Deploy(t.Context(), s.projects, s.clients, s.opts)
+		assert.Error(t, err)
+		assert.Equal(t, []string{"deploy dev"}, *s.calls)
+		assert.Equal(t, []string{
+			"environment pre dev",
+			"project pre proj",
+			"config pre",
+			"config error",
+			"project error proj",
+			"environment error dev",
+		}, readLines(t, s.logFile))
+	})
+
+	t.Run("configs are not deployed if a preDeploy hook fails", func(t *testing.T) {
+		s := newHookTestSetup(t, nil)
+		s.projects[0].Configs["dev"]["builtin:test"][0].Hooks = config.Hooks{PreDeploy: "exit 1"}
+
+		err := deploy.Deploy(t.Context(), s.projects, s.clients, s.opts)
+		assert.Error(t, err)
+		assert.Empty(t, *s.calls)
+		assert.Equal(t, []string{
+			"environment pre dev",
+			"project pre proj",
+			"project error proj",
+			"environment error dev",
+		}, readLines(t, s.logFile))
+	})
+
+	t.Run("environments are not deployed if their preDeploy hook fails", func(t *testing.T) {
+		s := newHookTestSetup(t, nil)
+		s.opts.EnvironmentHooks["dev"] = config.Hooks{PreDeploy: "exit 1"}
+
+		err := deploy.Deploy(t.Context(), s.projects, s.clients, s.opts)
+		assert.Error(t, err)
+		assert.Empty(t, *s.calls)
+		assert.Nil(t, readLines(t, s.logFile))
+	})
+
+	t.Run("hooks are not run in dry-run", func(t *testing.T) {
+		s := newHookTestSetup(t, nil)
+		s.opts.DryRun = true
+		clients := dynatrace.EnvironmentClients{
+			dynatrace.EnvironmentInfo{Name: "dev"}: &client.ClientSet{SettingsClient: &dtclient.DummySettingsClient{}},
+		}
+
+		err := deploy.Deploy(t.Context(), s.projects, clients, s.opts)
+		require.NoError(t, err)
+		assert.Nil(t, readLines(t, s.logFile))
+	})
+
+	t.Run("hook input is passed as JSON on stdin", func(t *testing.T) {
+		inputFile := filepath.Join(t.TempDir(), "input.json")
+		projects := newRolloutTestProjects("dev")
+		projects[0].Configs["dev"]["builtin:test"][0].Hooks = config.Hooks{PostDeploy: "cat > " + inputFile}
+
+		var calls []string
+		clients := dynatrace.EnvironmentClients{
+			dynatrace.EnvironmentInfo{Name: "dev"}: newRolloutTestClient(t, "dev", &calls, nil),
+		}
+
+		err := deploy.Deploy(t.Context(), projects, clients, deploy.DeployConfigsOptions{})
+		require.NoError(t, err)
+
+		content, err := os.ReadFile(inputFile)
+		require.NoError(t, err)
+
+		var input map[string]any
+		require.NoError(t, json.Unmarshal(content, &input))
+		assert.Equal(t, "postDeploy", input["event"])
+		assert.Equal(t, "dev", input["environment"])
+		assert.Equal(t, "proj", input["project"])
+		assert.Equal(t, map[string]any{"project": "proj", "type": "builtin:test", "configId": "setting"}, input["coordinate"])
+		assert.Equal(t, "dev-id", input["entityId"])
+		assert.Equal(t, "environment", input["properties"].(map[string]any)[config.ScopeParameter])
+	})
+}
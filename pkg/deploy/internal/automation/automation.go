@@ -83,6 +83,7 @@ func Deploy(ctx context.Context, client Client, properties parameter.Properties,
 		Coordinate: c.Coordinate,
 		Properties: properties,
 		Skip:       false,
+		Response:   resp.Data,
 	}
 	return resolved, nil
 
@@ -48,7 +48,7 @@ func Deploy(ctx context.Context, client Client, properties parameter.Properties,
 
 	// create new context to carry logger
 	ctx = logr.NewContext(ctx, log.WithCtxFields(ctx).GetLogr())
-	_, err := client.Upsert(ctx, bucketName, []byte(renderedConfig))
+	resp, err := client.Upsert(ctx, bucketName, []byte(renderedConfig))
 	if err != nil {
 		var apiErr api.APIError
 		if errors.As(err, &apiErr) {
@@ -64,5 +64,6 @@ func Deploy(ctx context.Context, client Client, properties parameter.Properties,
 		EntityName: bucketName,
 		Coordinate: c.Coordinate,
 		Properties: properties,
+		Response:   resp.Data,
 	}, nil
 }
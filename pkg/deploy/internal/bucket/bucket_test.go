@@ -84,6 +84,7 @@ func TestDeploy(t *testing.T) {
 				Properties: parameter.Properties{
 					config.IdParameter: "proj_my-bucket",
 				},
+				Response: []byte("{}"),
 			},
 			false,
 		},
@@ -110,6 +111,7 @@ func TestDeploy(t *testing.T) {
 				Properties: parameter.Properties{
 					config.IdParameter: "PreExistingBucket",
 				},
+				Response: []byte("{}"),
 			},
 			false,
 		},
@@ -18,6 +18,7 @@ package classic
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"strings"
 
@@ -33,6 +34,7 @@ import (
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy/errors"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy/internal/extract"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy/state"
 )
 
 func Deploy(ctx context.Context, configClient client.ConfigClient, apis api.APIs, properties parameter.Properties, renderedConfig string, conf *config.Config) (entities.ResolvedEntity, error) {
@@ -71,7 +73,9 @@ func Deploy(ctx context.Context, configClient client.ConfigClient, apis api.APIs
 	}
 
 	var dtEntity dtclient.DynatraceEntity
-	if apiToDeploy.NonUniqueName {
+	if id, found := recordedID(ctx, configClient, apiToDeploy, conf, properties); found {
+		dtEntity, err = configClient.UpdateByID(ctx, apiToDeploy, id, configName, []byte(renderedConfig))
+	} else if apiToDeploy.NonUniqueName {
 		dtEntity, err = upsertNonUniqueNameConfig(ctx, configClient, apiToDeploy, conf, configName, renderedConfig)
 	} else {
 		dtEntity, err = configClient.UpsertByName(ctx, apiToDeploy, configName, []byte(renderedConfig))
@@ -84,14 +88,42 @@ func Deploy(ctx context.Context, configClient client.ConfigClient, apis api.APIs
 	properties[config.IdParameter] = dtEntity.Id
 	properties[config.NameParameter] = dtEntity.Name
 
+	// the API client only returns the ID and name of the object, so these make up the response
+	response, err := json.Marshal(dtEntity)
+	if err != nil {
+		return entities.ResolvedEntity{}, errors.NewConfigDeployErr(conf, err.Error()).WithError(err)
+	}
+
 	return entities.ResolvedEntity{
 		EntityName: dtEntity.Name,
 		Coordinate: conf.Coordinate,
 		Properties: properties,
 		Skip:       false,
+		Response:   response,
 	}, nil
 }
 
+// recordedID returns the ID recorded for the config in the deployment state, if the recorded object still exists in the
+// config's current scope. APIs without unique names, single configurations, and extensions are identified independently
+// of their name, and thus never use the deployment state.
+func recordedID(ctx context.Context, configClient client.ConfigClient, a api.API, conf *config.Config, properties parameter.Properties) (string, bool) {
+	if a.NonUniqueName || a.SingleConfiguration || a.ID == api.Extension {
+		return "", false
+	}
+
+	scope, _ := properties[config.ScopeParameter].(string)
+	e, found := state.GetStateFromContext(ctx).GetInScope(conf.Coordinate, scope)
+	if !found {
+		return "", false
+	}
+
+	if _, err := configClient.Get(ctx, a, e.ID); err != nil {
+		log.WithCtxFields(ctx).Debug("Object %q recorded in deployment state is not available, looking it up by name: %v", e.ID, err)
+		return "", false
+	}
+	return e.ID, true
+}
+
 func upsertNonUniqueNameConfig(ctx context.Context, client client.ConfigClient, apiToDeploy api.API, conf *config.Config, configName string, renderedConfig string) (dtclient.DynatraceEntity, error) {
 	configID := conf.Coordinate.ConfigId
 	projectId := conf.Coordinate.Project
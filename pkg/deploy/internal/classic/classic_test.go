@@ -19,17 +19,22 @@
 package classic
 
 import (
+	"net/http"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
 
+	coreapi "github.com/dynatrace/dynatrace-configuration-as-code-core/api"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/api"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/client"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/client/dtclient"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/coordinate"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/entities"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy/internal/testutils"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy/state"
 )
 
 var dashboardApi = api.API{ID: "dashboard", URLPath: "dashboard", DeprecatedBy: "dashboard-v2"}
@@ -213,3 +218,53 @@ func TestDeployConfigShouldFailOnReferenceOnSkipConfig(t *testing.T) {
 	_, errors := Deploy(t.Context(), client, testApiMap, nil, "", &conf)
 	assert.NotEmpty(t, errors)
 }
+
+func TestDeployConfigPrefersObjectRecordedInState(t *testing.T) {
+	conf := config.Config{
+		Type:       config.ClassicApiType{Api: "dashboard"},
+		Template:   testutils.GenerateDummyTemplate(t),
+		Coordinate: coordinate.Coordinate{Project: "project1", Type: "dashboard", ConfigId: "dashboard-1"},
+	}
+	properties := parameter.Properties{config.NameParameter: "new name"}
+
+	t.Run("updates recorded object by ID", func(t *testing.T) {
+		s := state.New("env")
+		s.Put(state.Entry{Coordinate: conf.Coordinate, ID: "recorded-id"})
+		ctx := state.NewContextWithState(t.Context(), s)
+
+		c := client.NewMockConfigClient(gomock.NewController(t))
+		c.EXPECT().Get(gomock.Any(), gomock.Any(), "recorded-id").Return([]byte(`{}`), nil)
+		c.EXPECT().UpdateByID(gomock.Any(), gomock.Any(), "recorded-id", "new name", gomock.Any()).Return(dtclient.DynatraceEntity{Id: "recorded-id", Name: "new name"}, nil)
+
+		entity, err := Deploy(ctx, c, testApiMap, properties, "{}", &conf)
+		assert.NoError(t, err)
+		assert.Equal(t, "recorded-id", entity.Properties[config.IdParameter])
+	})
+
+	t.Run("falls back to name lookup if recorded object does not exist", func(t *testing.T) {
+		s := state.New("env")
+		s.Put(state.Entry{Coordinate: conf.Coordinate, ID: "recorded-id"})
+		ctx := state.NewContextWithState(t.Context(), s)
+
+		c := client.NewMockConfigClient(gomock.NewController(t))
+		c.EXPECT().Get(gomock.Any(), gomock.Any(), "recorded-id").Return(nil, coreapi.APIError{StatusCode: http.StatusNotFound})
+		c.EXPECT().UpsertByName(gomock.Any(), gomock.Any(), "new name", gomock.Any()).Return(dtclient.DynatraceEntity{Id: "other-id", Name: "new name"}, nil)
+
+		entity, err := Deploy(ctx, c, testApiMap, properties, "{}", &conf)
+		assert.NoError(t, err)
+		assert.Equal(t, "other-id", entity.Properties[config.IdParameter])
+	})
+
+	t.Run("ignores object recorded in other scope", func(t *testing.T) {
+		s := state.New("env")
+		s.Put(state.Entry{Coordinate: conf.Coordinate, ID: "recorded-id", Scope: "other-scope"})
+		ctx := state.NewContextWithState(t.Context(), s)
+
+		c := client.NewMockConfigClient(gomock.NewController(t))
+		c.EXPECT().UpsertByName(gomock.Any(), gomock.Any(), "new name", gomock.Any()).Return(dtclient.DynatraceEntity{Id: "other-id", Name: "new name"}, nil)
+
+		entity, err := Deploy(ctx, c, testApiMap, properties, "{}", &conf)
+		assert.NoError(t, err)
+		assert.Equal(t, "other-id", entity.Properties[config.IdParameter])
+	})
+}
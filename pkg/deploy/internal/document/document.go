@@ -34,6 +34,7 @@ import (
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/entities"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter"
 	deployErrors "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy/errors"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy/state"
 )
 
 //go:generate mockgen -source=document.go -destination=document_mock.go -package=document documentClient
@@ -58,19 +59,26 @@ func Deploy(ctx context.Context, client Client, properties parameter.Properties,
 		return entities.ResolvedEntity{}, errors.New("missing name parameter")
 	}
 
+	// prefer the document ID recorded in the deployment state, if the config was not downloaded from an environment.
+	// documents have no scope, so only entries without one belong to them.
+	originObjectID := c.OriginObjectId
+	if e, found := state.GetStateFromContext(ctx).GetInScope(c.Coordinate, ""); found && originObjectID == "" {
+		originObjectID = e.ID
+	}
+
 	// strategy 1: if an origin id is available, try to update that document
-	if c.OriginObjectId != "" {
-		updateResponse, err := client.Update(ctx, c.OriginObjectId, documentName, isPrivate, []byte(renderedConfig), documentType)
+	if originObjectID != "" {
+		updateResponse, err := client.Update(ctx, originObjectID, documentName, isPrivate, []byte(renderedConfig), documentType)
 		if err == nil {
 			md, err := documents.UnmarshallMetadata(updateResponse.Data)
 			if err != nil {
 				return entities.ResolvedEntity{}, deployErrors.NewConfigDeployErr(c, "error reading received data").WithError(err)
 			}
-			return createResolvedEntity(documentName, md.ID, c.Coordinate, properties), nil
+			return createResolvedEntity(documentName, md.ID, c.Coordinate, properties, updateResponse.Data), nil
 		}
 
 		if !isAPIErrorStatusNotFound(err) {
-			return entities.ResolvedEntity{}, deployErrors.NewConfigDeployErr(c, fmt.Sprintf("failed to update document '%s'", c.OriginObjectId)).WithError(err)
+			return entities.ResolvedEntity{}, deployErrors.NewConfigDeployErr(c, fmt.Sprintf("failed to update document '%s'", originObjectID)).WithError(err)
 		}
 	}
 
@@ -92,7 +100,7 @@ func Deploy(ctx context.Context, client Client, properties parameter.Properties,
 		if err != nil {
 			return entities.ResolvedEntity{}, deployErrors.NewConfigDeployErr(c, "error reading received data").WithError(err)
 		}
-		return createResolvedEntity(documentName, md.ID, c.Coordinate, properties), nil
+		return createResolvedEntity(documentName, md.ID, c.Coordinate, properties, updateResponse.Data), nil
 	}
 
 	// strategy 3: try to create a new document
@@ -105,7 +113,7 @@ func Deploy(ctx context.Context, client Client, properties parameter.Properties,
 		return entities.ResolvedEntity{}, deployErrors.NewConfigDeployErr(c, "error reading received data").WithError(err)
 	}
 
-	return createResolvedEntity(documentName, md.ID, c.Coordinate, properties), nil
+	return createResolvedEntity(documentName, md.ID, c.Coordinate, properties, createResponse.Data), nil
 }
 
 func isAPIErrorStatusNotFound(err error) bool {
@@ -135,13 +143,14 @@ func tryGetDocumentIDByExternalID(ctx context.Context, client Client, externalId
 	return listResponse.Responses[0].ID, nil
 }
 
-func createResolvedEntity(documentName string, id string, coordinate coordinate.Coordinate, properties parameter.Properties) entities.ResolvedEntity {
+func createResolvedEntity(documentName string, id string, coordinate coordinate.Coordinate, properties parameter.Properties, response []byte) entities.ResolvedEntity {
 	properties[config.IdParameter] = id
 
 	return entities.ResolvedEntity{
 		EntityName: documentName,
 		Coordinate: coordinate,
 		Properties: properties,
+		Response:   response,
 	}
 }
 
@@ -48,20 +48,21 @@ func Deploy(ctx context.Context, client Client, properties parameter.Properties,
 		return entities.ResolvedEntity{}, fmt.Errorf("expected openpipeline config type but found %v", t)
 	}
 
-	_, err := client.Update(ctx, t.Kind, []byte(renderedConfig))
+	resp, err := client.Update(ctx, t.Kind, []byte(renderedConfig))
 	if err != nil {
 		return entities.ResolvedEntity{}, deployErrors.NewConfigDeployErr(c, fmt.Sprintf("failed to update openpipeline object of kind '%s'", t.Kind)).WithError(err)
 	}
 
-	return createResolvedEntity(t.Kind, c.Coordinate, properties), nil
+	return createResolvedEntity(t.Kind, c.Coordinate, properties, resp.Data), nil
 }
 
-func createResolvedEntity(id string, coordinate coordinate.Coordinate, properties parameter.Properties) entities.ResolvedEntity {
+func createResolvedEntity(id string, coordinate coordinate.Coordinate, properties parameter.Properties, response []byte) entities.ResolvedEntity {
 	properties[config.IdParameter] = id
 
 	return entities.ResolvedEntity{
 		EntityName: id,
 		Coordinate: coordinate,
 		Properties: properties,
+		Response:   response,
 	}
 }
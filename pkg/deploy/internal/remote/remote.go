@@ -0,0 +1,358 @@
+/*
+ * @license
+ * Copyright 2025 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package remote allows to look up the object a config would be deployed to, using the same identification strategies
+// (origin object IDs, external IDs, generated IDs and names) as the deployers in pkg/deploy/internal.
+package remote
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	coreapi "github.com/dynatrace/dynatrace-configuration-as-code-core/api"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/automationutils"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/idutils"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/api"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/client"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/client/dtclient"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy/internal/extract"
+)
+
+// Object is the remote representation of a config
+type Object = config.RemoteObject
+
+// init registers how the remote objects of configs of the built-in config types are looked up
+func init() {
+	for _, h := range []config.TypeHandler{
+		{ID: config.ClassicApiTypeID, Lookup: func(ctx context.Context, clients *client.ClientSet, properties parameter.Properties, c *config.Config) (Object, bool, error) {
+			return getClassic(ctx, clients.ConfigClient, c.Type.(config.ClassicApiType), properties, c)
+		}},
+		{ID: config.SettingsTypeID, Lookup: func(ctx context.Context, clients *client.ClientSet, _ parameter.Properties, c *config.Config) (Object, bool, error) {
+			return getSetting(ctx, clients.SettingsClient, c.Type.(config.SettingsType), c)
+		}},
+		{ID: config.AutomationTypeID, Lookup: func(ctx context.Context, clients *client.ClientSet, _ parameter.Properties, c *config.Config) (Object, bool, error) {
+			return getAutomation(ctx, clients.AutClient, c.Type.(config.AutomationType), c)
+		}},
+		{ID: config.BucketTypeID, Lookup: func(ctx context.Context, clients *client.ClientSet, _ parameter.Properties, c *config.Config) (Object, bool, error) {
+			return getBucket(ctx, clients.BucketClient, c)
+		}},
+		{ID: config.DocumentTypeID, Lookup: func(ctx context.Context, clients *client.ClientSet, _ parameter.Properties, c *config.Config) (Object, bool, error) {
+			return getDocument(ctx, clients.DocumentClient, c)
+		}},
+		{ID: config.OpenPipelineTypeID, Lookup: func(ctx context.Context, clients *client.ClientSet, _ parameter.Properties, c *config.Config) (Object, bool, error) {
+			return getOpenPipeline(ctx, clients.OpenPipelineClient, c.Type.(config.OpenPipelineType))
+		}},
+		{ID: config.SegmentID, Lookup: func(ctx context.Context, clients *client.ClientSet, _ parameter.Properties, c *config.Config) (Object, bool, error) {
+			return getSegment(ctx, clients.SegmentClient, c)
+		}},
+		{ID: config.ServiceLevelObjectiveID, Lookup: func(ctx context.Context, clients *client.ClientSet, _ parameter.Properties, c *config.Config) (Object, bool, error) {
+			return getServiceLevelObjective(ctx, clients.ServiceLevelObjectiveClient, c)
+		}},
+	} {
+		config.Types.Register(h)
+	}
+}
+
+// Get looks up the remote object the given config would be deployed to, using the Lookup behaviour of its type. The
+// resolved properties of the config are required to identify classic API configs (by name or scope) and settings (by
+// scope).
+// If no matching object exists, found is false and no error is returned.
+func Get(ctx context.Context, clientset *client.ClientSet, properties parameter.Properties, c *config.Config) (obj Object, found bool, err error) {
+	h, found := config.Types.Get(c.Type.ID())
+	if !found || h.Lookup == nil {
+		return Object{}, false, fmt.Errorf("unknown config-type (ID: %q)", c.Type.ID())
+	}
+	return h.Lookup(ctx, clientset, properties, c)
+}
+
+// ClassicAPI returns the API a classic config is deployed to. For APIs with a parent, the scope of the config is
+// applied as parent object ID.
+func ClassicAPI(t config.ClassicApiType, properties parameter.Properties) (api.API, error) {
+	a, found := api.NewAPIs()[t.Api]
+	if !found {
+		return api.API{}, fmt.Errorf("unknown api `%s`. this is most likely a bug", t.Api)
+	}
+
+	if a.HasParent() {
+		scope, err := extract.Scope(properties)
+		if err != nil {
+			return api.API{}, fmt.Errorf("failed to extract scope for config %q", t.ID())
+		}
+		a = a.ApplyParentObjectID(scope)
+	}
+	return a, nil
+}
+
+func getClassic(ctx context.Context, configClient client.ConfigClient, t config.ClassicApiType, properties parameter.Properties, c *config.Config) (Object, bool, error) {
+	a, err := ClassicAPI(t, properties)
+	if err != nil {
+		return Object{}, false, err
+	}
+
+	var id string
+	switch {
+	case a.SingleConfiguration:
+		if a.HasParent() {
+			id = a.AppliedParentObjectID
+		}
+
+	case a.NonUniqueName:
+		id = nonUniqueNameID(a, c)
+		values, err := configClient.List(ctx, a)
+		if err != nil {
+			return Object{}, false, err
+		}
+		if !containsID(values, id) {
+			return Object{}, false, nil
+		}
+
+	default:
+		name, err := extract.ConfigName(c, properties)
+		if err != nil {
+			return Object{}, false, err
+		}
+		exists, existingID, err := configClient.ExistsWithName(ctx, a, name)
+		if err != nil {
+			return Object{}, false, err
+		}
+		if !exists {
+			return Object{}, false, nil
+		}
+		id = existingID
+	}
+
+	payload, err := configClient.Get(ctx, a, id)
+	if err != nil {
+		if isAPIErrorStatusNotFound(err) {
+			return Object{}, false, nil
+		}
+		return Object{}, false, err
+	}
+
+	if a.TweakResponseFunc != nil {
+		var m map[string]any
+		if err := json.Unmarshal(payload, &m); err != nil {
+			return Object{}, false, err
+		}
+		a.TweakResponseFunc(m)
+		if payload, err = json.Marshal(m); err != nil {
+			return Object{}, false, err
+		}
+	}
+
+	return Object{ID: id, Payload: payload}, true, nil
+}
+
+// nonUniqueNameID returns the ID a config of an API with non-unique names is deployed with.
+// This mirrors the ID generation done by the classic deployer.
+func nonUniqueNameID(a api.API, c *config.Config) string {
+	id := c.Coordinate.ConfigId
+	if !idutils.IsUUID(id) && !idutils.IsMeId(id) {
+		id = idutils.GenerateUUIDFromConfigId(c.Coordinate.Project, c.Coordinate.ConfigId)
+	}
+
+	if a.ID == api.UserActionAndSessionPropertiesMobile {
+		if c.OriginObjectId != "" {
+			return c.OriginObjectId
+		}
+		return strings.ToLower(strings.ReplaceAll(id, "-", ""))
+	}
+	return id
+}
+
+func containsID(values []dtclient.Value, id string) bool {
+	for _, v := range values {
+		if v.Id == id {
+			return true
+		}
+	}
+	return false
+}
+
+func getSetting(ctx context.Context, settingsClient client.SettingsClient, t config.SettingsType, c *config.Config) (Object, bool, error) {
+	externalID, err := idutils.GenerateExternalIDForSettingsObject(c.Coordinate)
+	if err != nil {
+		return Object{}, false, err
+	}
+
+	objects, err := settingsClient.List(ctx, t.SchemaId, dtclient.ListSettingsOptions{
+		Filter: func(o dtclient.DownloadSettingsObject) bool {
+			return o.ExternalId == externalID || (c.OriginObjectId != "" && o.ObjectId == c.OriginObjectId)
+		},
+	})
+	if err != nil {
+		return Object{}, false, err
+	}
+
+	if len(objects) == 0 {
+		return Object{}, false, nil
+	}
+
+	// prefer the object matching the external ID, as this is the one the settings client would update
+	for _, o := range objects {
+		if o.ExternalId == externalID {
+			return Object{ID: o.ObjectId, Payload: o.Value, Scope: o.Scope}, true, nil
+		}
+	}
+	return Object{ID: objects[0].ObjectId, Payload: objects[0].Value, Scope: objects[0].Scope}, true, nil
+}
+
+func getAutomation(ctx context.Context, autClient client.AutomationClient, t config.AutomationType, c *config.Config) (Object, bool, error) {
+	resourceType, err := automationutils.ClientResourceTypeFromConfigType(t.Resource)
+	if err != nil {
+		return Object{}, false, err
+	}
+
+	id := c.OriginObjectId
+	if id == "" {
+		id = idutils.GenerateUUIDFromCoordinate(c.Coordinate)
+	}
+
+	resp, err := autClient.Get(ctx, resourceType, id)
+	if err != nil {
+		if isAPIErrorStatusNotFound(err) {
+			return Object{}, false, nil
+		}
+		return Object{}, false, err
+	}
+	return Object{ID: id, Payload: resp.Data}, true, nil
+}
+
+func getBucket(ctx context.Context, bucketClient client.BucketClient, c *config.Config) (Object, bool, error) {
+	bucketName := c.OriginObjectId
+	if bucketName == "" {
+		bucketName = idutils.GenerateBucketName(c.Coordinate)
+	}
+
+	resp, err := bucketClient.Get(ctx, bucketName)
+	if err != nil {
+		if isAPIErrorStatusNotFound(err) {
+			return Object{}, false, nil
+		}
+		return Object{}, false, err
+	}
+	return Object{ID: bucketName, Payload: resp.Data}, true, nil
+}
+
+func getDocument(ctx context.Context, documentClient client.DocumentClient, c *config.Config) (Object, bool, error) {
+	if c.OriginObjectId != "" {
+		resp, err := documentClient.Get(ctx, c.OriginObjectId)
+		if err == nil {
+			return Object{ID: resp.ID, Payload: resp.Data}, true, nil
+		}
+		if !isAPIErrorStatusNotFound(err) {
+			return Object{}, false, err
+		}
+	}
+
+	externalID := idutils.GenerateExternalID(c.Coordinate)
+	listResponse, err := documentClient.List(ctx, fmt.Sprintf("externalId=='%s'", externalID))
+	if err != nil {
+		return Object{}, false, err
+	}
+	if len(listResponse.Responses) > 1 {
+		return Object{}, false, fmt.Errorf("multiple documents found with externalId='%s'", externalID)
+	}
+	if len(listResponse.Responses) == 0 {
+		return Object{}, false, nil
+	}
+
+	resp, err := documentClient.Get(ctx, listResponse.Responses[0].ID)
+	if err != nil {
+		return Object{}, false, err
+	}
+	return Object{ID: resp.ID, Payload: resp.Data}, true, nil
+}
+
+func getOpenPipeline(ctx context.Context, openPipelineClient client.OpenPipelineClient, t config.OpenPipelineType) (Object, bool, error) {
+	responses, err := openPipelineClient.GetAll(ctx)
+	if err != nil {
+		return Object{}, false, err
+	}
+
+	for _, r := range responses {
+		var o struct {
+			ID string `json:"id"`
+		}
+		if err := json.Unmarshal(r.Data, &o); err != nil {
+			return Object{}, false, err
+		}
+		if o.ID == t.Kind {
+			return Object{ID: o.ID, Payload: r.Data}, true, nil
+		}
+	}
+	return Object{}, false, nil
+}
+
+func getSegment(ctx context.Context, segmentClient client.SegmentClient, c *config.Config) (Object, bool, error) {
+	externalID := idutils.GenerateExternalID(c.Coordinate)
+
+	responses, err := segmentClient.GetAll(ctx)
+	if err != nil {
+		return Object{}, false, err
+	}
+
+	for _, r := range responses {
+		var o struct {
+			UID        string `json:"uid"`
+			ExternalID string `json:"externalId"`
+		}
+		if err := json.Unmarshal(r.Data, &o); err != nil {
+			return Object{}, false, err
+		}
+		if o.ExternalID == externalID || (c.OriginObjectId != "" && o.UID == c.OriginObjectId) {
+			return Object{ID: o.UID, Payload: r.Data}, true, nil
+		}
+	}
+	return Object{}, false, nil
+}
+
+func getServiceLevelObjective(ctx context.Context, sloClient client.ServiceLevelObjectiveClient, c *config.Config) (Object, bool, error) {
+	externalID := idutils.GenerateExternalID(c.Coordinate)
+
+	listResponse, err := sloClient.List(ctx)
+	if err != nil {
+		return Object{}, false, err
+	}
+
+	for _, raw := range listResponse.All() {
+		var o struct {
+			ID         string `json:"id"`
+			ExternalID string `json:"externalId"`
+		}
+		if err := json.Unmarshal(raw, &o); err != nil {
+			return Object{}, false, err
+		}
+		if o.ExternalID == externalID || (c.OriginObjectId != "" && o.ID == c.OriginObjectId) {
+			return Object{ID: o.ID, Payload: raw}, true, nil
+		}
+	}
+	return Object{}, false, nil
+}
+
+func isAPIErrorStatusNotFound(err error) bool {
+	var apiErr coreapi.APIError
+	if !errors.As(err, &apiErr) {
+		return false
+	}
+	return apiErr.StatusCode == http.StatusNotFound
+}
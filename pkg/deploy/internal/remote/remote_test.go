@@ -0,0 +1,137 @@
+//go:build unit
+
+/*
+ * @license
+ * Copyright 2025 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package remote_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	coreapi "github.com/dynatrace/dynatrace-configuration-as-code-core/api"
+	"github.com/dynatrace/dynatrace-configuration-as-code-core/clients/automation"
+	"github.com/dynatrace/dynatrace-configuration-as-code-core/clients/buckets"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/idutils"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/client"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/client/dtclient"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/coordinate"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy/internal/remote"
+)
+
+func TestGet_Classic(t *testing.T) {
+	c := &config.Config{
+		Coordinate: coordinate.Coordinate{Project: "p", Type: "auto-tag", ConfigId: "c"},
+		Type:       config.ClassicApiType{Api: "auto-tag"},
+	}
+	properties := parameter.Properties{config.NameParameter: "my-profile"}
+
+	t.Run("returns object found by name", func(t *testing.T) {
+		configClient := client.NewMockConfigClient(gomock.NewController(t))
+		configClient.EXPECT().ExistsWithName(gomock.Any(), gomock.Any(), "my-profile").Return(true, "1234", nil)
+		configClient.EXPECT().Get(gomock.Any(), gomock.Any(), "1234").Return([]byte(`{"name":"my-profile"}`), nil)
+
+		obj, found, err := remote.Get(t.Context(), &client.ClientSet{ConfigClient: configClient}, properties, c)
+		require.NoError(t, err)
+		assert.True(t, found)
+		assert.Equal(t, remote.Object{ID: "1234", Payload: []byte(`{"name":"my-profile"}`)}, obj)
+	})
+
+	t.Run("returns not found if no object with name exists", func(t *testing.T) {
+		configClient := client.NewMockConfigClient(gomock.NewController(t))
+		configClient.EXPECT().ExistsWithName(gomock.Any(), gomock.Any(), "my-profile").Return(false, "", nil)
+
+		_, found, err := remote.Get(t.Context(), &client.ClientSet{ConfigClient: configClient}, properties, c)
+		require.NoError(t, err)
+		assert.False(t, found)
+	})
+}
+
+func TestGet_Settings(t *testing.T) {
+	c := &config.Config{
+		Coordinate: coordinate.Coordinate{Project: "p", Type: "builtin:alerting.profile", ConfigId: "c"},
+		Type:       config.SettingsType{SchemaId: "builtin:alerting.profile"},
+	}
+	externalID, err := idutils.GenerateExternalIDForSettingsObject(c.Coordinate)
+	require.NoError(t, err)
+
+	settingsClient := client.NewMockSettingsClient(gomock.NewController(t))
+	settingsClient.EXPECT().List(gomock.Any(), "builtin:alerting.profile", gomock.Any()).DoAndReturn(
+		func(_ any, _ string, opts dtclient.ListSettingsOptions) ([]dtclient.DownloadSettingsObject, error) {
+			all := []dtclient.DownloadSettingsObject{
+				{ExternalId: "other", ObjectId: "other-id", Value: []byte(`{}`)},
+				{ExternalId: externalID, ObjectId: "object-id", Value: []byte(`{"name":"a"}`)},
+			}
+			var result []dtclient.DownloadSettingsObject
+			for _, o := range all {
+				if opts.Filter(o) {
+					result = append(result, o)
+				}
+			}
+			return result, nil
+		})
+
+	obj, found, err := remote.Get(t.Context(), &client.ClientSet{SettingsClient: settingsClient}, parameter.Properties{}, c)
+	require.NoError(t, err)
+	assert.True(t, found)
+	assert.Equal(t, remote.Object{ID: "object-id", Payload: []byte(`{"name":"a"}`)}, obj)
+}
+
+func TestGet_Automation(t *testing.T) {
+	c := &config.Config{
+		Coordinate: coordinate.Coordinate{Project: "p", Type: "workflow", ConfigId: "c"},
+		Type:       config.AutomationType{Resource: config.Workflow},
+	}
+
+	t.Run("returns object found by generated ID", func(t *testing.T) {
+		autClient := client.NewMockAutomationClient(gomock.NewController(t))
+		autClient.EXPECT().Get(gomock.Any(), gomock.Any(), idutils.GenerateUUIDFromCoordinate(c.Coordinate)).Return(automation.Response{StatusCode: http.StatusOK, Data: []byte(`{}`)}, nil)
+
+		obj, found, err := remote.Get(t.Context(), &client.ClientSet{AutClient: autClient}, parameter.Properties{}, c)
+		require.NoError(t, err)
+		assert.True(t, found)
+		assert.Equal(t, idutils.GenerateUUIDFromCoordinate(c.Coordinate), obj.ID)
+	})
+
+	t.Run("returns not found on HTTP 404", func(t *testing.T) {
+		autClient := client.NewMockAutomationClient(gomock.NewController(t))
+		autClient.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).Return(automation.Response{}, coreapi.APIError{StatusCode: http.StatusNotFound})
+
+		_, found, err := remote.Get(t.Context(), &client.ClientSet{AutClient: autClient}, parameter.Properties{}, c)
+		require.NoError(t, err)
+		assert.False(t, found)
+	})
+}
+
+func TestGet_BucketReturnsErrors(t *testing.T) {
+	c := &config.Config{
+		Coordinate: coordinate.Coordinate{Project: "p", Type: "bucket", ConfigId: "c"},
+		Type:       config.BucketType{},
+	}
+
+	bucketClient := client.NewMockBucketClient(gomock.NewController(t))
+	bucketClient.EXPECT().Get(gomock.Any(), "p_c").Return(buckets.Response{}, coreapi.APIError{StatusCode: http.StatusInternalServerError})
+
+	_, found, err := remote.Get(t.Context(), &client.ClientSet{BucketClient: bucketClient}, parameter.Properties{}, c)
+	assert.Error(t, err)
+	assert.False(t, found)
+}
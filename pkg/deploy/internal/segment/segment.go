@@ -58,9 +58,9 @@ func Deploy(ctx context.Context, client deploySegmentClient, properties paramete
 
 	//Strategy 1 when OriginObjectId is set we update the object
 	if c.OriginObjectId != "" {
-		_, err := client.Update(ctx, c.OriginObjectId, requestPayload)
+		updateResponse, err := client.Update(ctx, c.OriginObjectId, requestPayload)
 		if err == nil {
-			return createResolveEntity(c.OriginObjectId, properties, c), nil
+			return createResolveEntity(c.OriginObjectId, properties, c, updateResponse.Data), nil
 		}
 
 		if !isAPIErrorStatusNotFound(err) {
@@ -75,11 +75,11 @@ func Deploy(ctx context.Context, client deploySegmentClient, properties paramete
 	}
 
 	if match {
-		_, err := client.Update(ctx, matchData.UID, requestPayload)
+		updateResponse, err := client.Update(ctx, matchData.UID, requestPayload)
 		if err != nil {
 			return entities.ResolvedEntity{}, deployErrors.NewConfigDeployErr(c, fmt.Sprintf("failed to update segment with externalId: %s", externalId)).WithError(err)
 		}
-		return createResolveEntity(matchData.UID, properties, c), nil
+		return createResolveEntity(matchData.UID, properties, c, updateResponse.Data), nil
 	}
 
 	//Strategy 3 is to create a new segment object
@@ -93,7 +93,7 @@ func Deploy(ctx context.Context, client deploySegmentClient, properties paramete
 		return entities.ResolvedEntity{}, deployErrors.NewConfigDeployErr(c, fmt.Sprintf("failed to unmarshal segment with externalId: %s", externalId)).WithError(err)
 	}
 
-	return createResolveEntity(responseData.UID, properties, c), nil
+	return createResolveEntity(responseData.UID, properties, c, createResponse.Data), nil
 }
 
 func addExternalId(externalId string, renderedConfig string) ([]byte, error) {
@@ -126,11 +126,12 @@ func findMatchOnRemote(ctx context.Context, client deploySegmentClient, external
 	return jsonResponse{}, false, nil
 }
 
-func createResolveEntity(id string, properties parameter.Properties, c *config.Config) entities.ResolvedEntity {
+func createResolveEntity(id string, properties parameter.Properties, c *config.Config, response []byte) entities.ResolvedEntity {
 	properties[config.IdParameter] = id
 	return entities.ResolvedEntity{
 		Coordinate: c.Coordinate,
 		Properties: properties,
+		Response:   response,
 	}
 }
 
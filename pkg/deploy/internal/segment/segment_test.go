@@ -162,6 +162,14 @@ func TestDeploy(t *testing.T) {
 					"id": "JMhNaJ0Zbf9",
 				},
 				Skip: false,
+				Response: marshal(map[string]any{
+					"uid":         "JMhNaJ0Zbf9",
+					"name":        "no-match",
+					"description": "post - update from monaco - change - 2",
+					"isPublic":    false,
+					"owner":       "79a4c92e-379b-4cd7-96a3-78a601b6a69b",
+					"externalId":  "monaco-e2320031-d6c6-3c83-9706-b3e82b834129",
+				}, t),
 			},
 			expectErr: false,
 		},
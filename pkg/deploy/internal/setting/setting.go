@@ -18,6 +18,7 @@ package setting
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"time"
 
@@ -32,6 +33,7 @@ import (
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy/errors"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy/internal/extract"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy/state"
 )
 
 func Deploy(ctx context.Context, settingsClient client.SettingsClient, properties parameter.Properties, renderedConfig string, c *config.Config) (entities.ResolvedEntity, error) {
@@ -47,13 +49,19 @@ func Deploy(ctx context.Context, settingsClient client.SettingsClient, propertie
 		return entities.ResolvedEntity{}, err
 	}
 
+	// prefer the object ID recorded in the deployment state, if the config was not downloaded from an environment
+	originObjectID := c.OriginObjectId
+	if e, found := state.GetStateFromContext(ctx).GetInScope(c.Coordinate, scope); found && originObjectID == "" {
+		originObjectID = e.ID
+	}
+
 	settingsObj := dtclient.SettingsObject{
 		Coordinate:     c.Coordinate,
 		SchemaId:       t.SchemaId,
 		SchemaVersion:  t.SchemaVersion,
 		Scope:          scope,
 		Content:        []byte(renderedConfig),
-		OriginObjectId: c.OriginObjectId,
+		OriginObjectId: originObjectID,
 	}
 
 	insertOptions := dtclient.UpsertSettingsOptions{
@@ -81,18 +89,25 @@ func Deploy(ctx context.Context, settingsClient client.SettingsClient, propertie
 		log.WithCtxFields(ctx).Debug("failed to extract name for Settings 2.0 object %q - ID will be used", dtEntity.Id)
 	}
 
-	properties[config.IdParameter], err = getEntityID(c, dtEntity)
+	properties[config.IdParameter], err = EntityID(c, dtEntity.Id)
 	if err != nil {
 		return entities.ResolvedEntity{}, errors.NewConfigDeployErr(c, err.Error()).WithError(err)
 	}
 
 	properties[config.NameParameter] = name
 
+	// the settings client only returns the ID of the object, so it makes up the response
+	response, err := json.Marshal(dtEntity)
+	if err != nil {
+		return entities.ResolvedEntity{}, errors.NewConfigDeployErr(c, err.Error()).WithError(err)
+	}
+
 	return entities.ResolvedEntity{
 		EntityName: name,
 		Coordinate: c.Coordinate,
 		Properties: properties,
 		Skip:       false,
+		Response:   response,
 	}, nil
 
 }
@@ -107,14 +122,22 @@ func extractInsertAfter(properties parameter.Properties) string {
 	return insertAfter
 }
 
-func getEntityID(c *config.Config, e dtclient.DynatraceEntity) (string, error) {
-	if c.Coordinate.Type == "builtin:management-zones" && featureflags.ManagementZoneSettingsNumericIDs.Enabled() {
-		numID, err := idutils.GetNumericIDForObjectID(e.Id)
+// EntityID returns the ID other configs use to reference the settings object with the given object ID.
+// For management zones this is the numeric ID if the respective feature flag is enabled, otherwise the object ID itself.
+func EntityID(c *config.Config, objectID string) (string, error) {
+	if UsesNumericID(c) {
+		numID, err := idutils.GetNumericIDForObjectID(objectID)
 		if err != nil {
-			return "", fmt.Errorf("failed to extract numeric ID for Management Zone Setting with object ID %q: %w", e.Id, err)
+			return "", fmt.Errorf("failed to extract numeric ID for Management Zone Setting with object ID %q: %w", objectID, err)
 		}
 		return fmt.Sprintf("%d", numID), nil
 	}
 
-	return e.Id, nil
+	return objectID, nil
+}
+
+// UsesNumericID returns whether other configs reference the settings object of the given config by a numeric ID
+// instead of its object ID, see EntityID.
+func UsesNumericID(c *config.Config) bool {
+	return c.Coordinate.Type == "builtin:management-zones" && featureflags.ManagementZoneSettingsNumericIDs.Enabled()
 }
@@ -106,6 +106,7 @@ func TestDeploySetting_ManagementZone_MZoneIDGetsEncoded(t *testing.T) {
 		Coordinate: coordinate.Coordinate{Project: "p", Type: "builtin:management-zones", ConfigId: "abcde"},
 		Properties: map[string]any{"scope": "environment", "id": "-4292415658385853785", "name": "[UNKNOWN NAME]vu9U3hXa3q0AAAABABhidWlsdGluOm1hbmFnZW1lbnQtem9uZXMABnRlbmFudAAGdGVuYW50ACRjNDZlNDZiMy02ZDk2LTMyYTctOGI1Yi1mNjExNzcyZDAxNjW-71TeFdrerQ"},
 		Skip:       false,
+		Response:   []byte(`{"id":"vu9U3hXa3q0AAAABABhidWlsdGluOm1hbmFnZW1lbnQtem9uZXMABnRlbmFudAAGdGVuYW50ACRjNDZlNDZiMy02ZDk2LTMyYTctOGI1Yi1mNjExNzcyZDAxNjW-71TeFdrerQ","name":"mzname","description":""}`),
 	}, resolvedEntity)
 	assert.NoError(t, err)
 }
@@ -131,6 +132,7 @@ func TestDeploySetting_ManagementZone_NameGetsExtracted_ifPresent(t *testing.T)
 		Coordinate: coordinate.Coordinate{Project: "p", Type: "builtin:some-setting", ConfigId: "abcde"},
 		Properties: map[string]any{"scope": "environment", "id": "abcdefghijk", "name": "the-name"},
 		Skip:       false,
+		Response:   []byte(`{"id":"abcdefghijk","name":"mzname","description":""}`),
 	}, resolvedEntity)
 	assert.NoError(t, err)
 }
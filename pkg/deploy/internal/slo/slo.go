@@ -58,9 +58,9 @@ func Deploy(ctx context.Context, client deployServiceLevelObjectiveClient, prope
 
 	//Strategy 1 when OriginObjectId is set we update the object
 	if c.OriginObjectId != "" {
-		_, err = client.Update(ctx, c.OriginObjectId, requestPayload)
+		updateResponse, err := client.Update(ctx, c.OriginObjectId, requestPayload)
 		if err == nil {
-			return createResolveEntity(c.OriginObjectId, properties, c), nil
+			return createResolveEntity(c.OriginObjectId, properties, c, updateResponse.Data), nil
 		}
 
 		if !isAPIErrorStatusNotFound(err) {
@@ -75,11 +75,11 @@ func Deploy(ctx context.Context, client deployServiceLevelObjectiveClient, prope
 	}
 
 	if match {
-		_, err := client.Update(ctx, matchID, requestPayload)
+		updateResponse, err := client.Update(ctx, matchID, requestPayload)
 		if err != nil {
 			return entities.ResolvedEntity{}, deployErrors.NewConfigDeployErr(c, fmt.Sprintf("failed to update slo with externalID: %s", externalID)).WithError(err)
 		}
-		return createResolveEntity(matchID, properties, c), nil
+		return createResolveEntity(matchID, properties, c, updateResponse.Data), nil
 	}
 
 	//Strategy 3 is to create a new slo
@@ -93,7 +93,7 @@ func Deploy(ctx context.Context, client deployServiceLevelObjectiveClient, prope
 		return entities.ResolvedEntity{}, deployErrors.NewConfigDeployErr(c, fmt.Sprintf("failed to unmarshal slo with externalID: %s", externalID)).WithError(err)
 	}
 
-	return createResolveEntity(response.ID, properties, c), nil
+	return createResolveEntity(response.ID, properties, c, createResponse.Data), nil
 }
 
 func addExternalId(externalId string, renderedConfig string) ([]byte, error) {
@@ -116,11 +116,12 @@ func responseFromHttpData(rawResponse api.Response) (sloResponse, error) {
 	return response, nil
 }
 
-func createResolveEntity(id string, properties parameter.Properties, c *config.Config) entities.ResolvedEntity {
+func createResolveEntity(id string, properties parameter.Properties, c *config.Config, response []byte) entities.ResolvedEntity {
 	properties[config.IdParameter] = id
 	return entities.ResolvedEntity{
 		Coordinate: c.Coordinate,
 		Properties: properties,
+		Response:   response,
 	}
 }
 
@@ -193,7 +193,8 @@ func TestDeploySuccess(t *testing.T) {
 				Properties: map[string]interface{}{
 					"id": "some-id",
 				},
-				Skip: false,
+				Skip:     false,
+				Response: []byte(`{"name": "some-name", "customSli": {"indicator": "some-query"}, "criteria": [{"warning": 95}], "tags": ["latency:500ms"], "id": "some-id", "version": "some-version", "externalId": "external-id"}`),
 			},
 			expectedRequestPayload: []byte("{\"externalId\":\"monaco-614c832a-b2c4-30c0-8e5b-f017366a4b1a\"}"),
 		},
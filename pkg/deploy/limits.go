@@ -0,0 +1,135 @@
+/*
+ * @license
+ * Copyright 2025 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package deploy
+
+import (
+	"context"
+	"sync"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code-core/api/rest"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/cmd/monaco/dynatrace"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/api"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/graph"
+)
+
+// concurrentDeploymentsPerEnvironment returns the maximum number of concurrent deployments for each of the given
+// environments. Environments without a limit of their own get the given default limit. Limits less than 1 mean that
+// deployments are not limited.
+func concurrentDeploymentsPerEnvironment(environmentClients dynatrace.EnvironmentClients, limits map[string]int, defaultLimit int) map[string]int {
+	result := make(map[string]int, len(environmentClients))
+	for env := range environmentClients {
+		if limit := limits[env.Name]; limit > 0 {
+			result[env.Name] = limit
+			continue
+		}
+		result[env.Name] = defaultLimit
+	}
+	return result
+}
+
+type ctxKeyDeploymentLimiter struct{}
+
+// newContextWithDeploymentLimiter attaches the limiter restricting the concurrent deployments to an environment
+func newContextWithDeploymentLimiter(ctx context.Context, l *rest.ConcurrentRequestLimiter) context.Context {
+	return context.WithValue(ctx, ctxKeyDeploymentLimiter{}, l)
+}
+
+func getDeploymentLimiterFromContext(ctx context.Context) *rest.ConcurrentRequestLimiter {
+	if l, ok := ctx.Value(ctxKeyDeploymentLimiter{}).(*rest.ConcurrentRequestLimiter); ok {
+		return l
+	}
+	return nil
+}
+
+// maxConcurrencyPerType returns the maximum number of concurrently deployed configs by config type. It consists of
+// the limits of all classic config APIs and the lowest limits the given configs define for their Settings 2.0 schema,
+// overridden by the given limits.
+func maxConcurrencyPerType(apis api.APIs, components []graph.SortedComponent, limits map[string]int) map[string]int {
+	result := make(map[string]int, len(limits))
+	for id, a := range apis {
+		if a.MaxConcurrency > 0 {
+			result[id] = a.MaxConcurrency
+		}
+	}
+	for _, component := range components {
+		nodes := component.Graph.Nodes()
+		for nodes.Next() {
+			c := nodes.Node().(graph.ConfigNode).Config
+			limit := c.DeploymentPolicy.MaxConcurrency
+			if limit <= 0 {
+				continue
+			}
+			if current, found := result[c.Coordinate.Type]; !found || limit < current {
+				result[c.Coordinate.Type] = limit
+			}
+		}
+	}
+	for t, limit := range limits {
+		if limit > 0 {
+			result[t] = limit
+		}
+	}
+	return result
+}
+
+// typeLimiters restrict the concurrent deployments of configs of the same type. The limiter of a type is created
+// when it is needed for the first time.
+type typeLimiters struct {
+	mu       sync.Mutex
+	limits   map[string]int
+	limiters map[string]*rest.ConcurrentRequestLimiter
+}
+
+func newTypeLimiters(limits map[string]int) *typeLimiters {
+	return &typeLimiters{limits: limits, limiters: make(map[string]*rest.ConcurrentRequestLimiter)}
+}
+
+// get returns the limiter of the given config type, or nil if deployments of the type are not limited
+func (l *typeLimiters) get(configType string) *rest.ConcurrentRequestLimiter {
+	if l == nil {
+		return nil
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if limiter, found := l.limiters[configType]; found {
+		return limiter
+	}
+
+	limit := l.limits[configType]
+	if limit <= 0 {
+		return nil
+	}
+	limiter := rest.NewConcurrentRequestLimiter(limit)
+	l.limiters[configType] = limiter
+	return limiter
+}
+
+type ctxKeyTypeLimiters struct{}
+
+// newContextWithTypeLimiters attaches the limiters restricting the concurrent deployments of each config type
+func newContextWithTypeLimiters(ctx context.Context, l *typeLimiters) context.Context {
+	return context.WithValue(ctx, ctxKeyTypeLimiters{}, l)
+}
+
+func getTypeLimitersFromContext(ctx context.Context) *typeLimiters {
+	if l, ok := ctx.Value(ctxKeyTypeLimiters{}).(*typeLimiters); ok {
+		return l
+	}
+	return nil
+}
@@ -0,0 +1,311 @@
+/*
+ * @license
+ * Copyright 2025 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package lock implements deployment locks, which prevent concurrent deployments to the same environment. A lock is a
+// file in a directory that is shared by all deployments, e.g. on a network drive. Locks expire after a time to live
+// (TTL), unless they are renewed by the heartbeat of the deployment holding them. This way, locks of deployments that
+// were killed don't block further deployments forever.
+package lock
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/spf13/afero"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/log"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/log/field"
+)
+
+// DefaultTTL is the time to live of locks, unless configured otherwise
+const DefaultTTL = 10 * time.Minute
+
+// Info describes a lock and the deployment holding it
+type Info struct {
+	Environment string `json:"environment"`
+	// ID identifies the deployment holding the lock
+	ID string `json:"id"`
+	// Owner describes the deployment holding the lock for humans, i.e. host and process ID
+	Owner      string    `json:"owner"`
+	AcquiredAt time.Time `json:"acquiredAt"`
+	// ExpiresAt is the time the lock expires at, unless it is renewed before
+	ExpiresAt time.Time `json:"expiresAt"`
+}
+
+// LockedError is returned if an environment is locked by another deployment
+type LockedError struct {
+	Info Info
+}
+
+func (e LockedError) Error() string {
+	return fmt.Sprintf("environment %q is locked by another deployment (%s) since %s. The lock expires at %s, unless it is renewed",
+		e.Info.Environment, e.Info.Owner, e.Info.AcquiredAt.Format(time.RFC3339), e.Info.ExpiresAt.Format(time.RFC3339))
+}
+
+// Locker acquires the locks of environments in a lock directory
+type Locker struct {
+	fs          afero.Fs
+	dir         string
+	ttl         time.Duration
+	forceUnlock bool
+	owner       string
+	now         func() time.Time
+}
+
+// NewLocker returns a Locker storing locks in the given directory. Locks expire after the given TTL, unless they are
+// renewed, which happens every third of the TTL. If forceUnlock is set, existing locks of other deployments are
+// removed instead of failing.
+func NewLocker(fs afero.Fs, dir string, ttl time.Duration, forceUnlock bool) *Locker {
+	if ttl <= 0 {
+		ttl = DefaultTTL
+	}
+
+	host, err := os.Hostname()
+	if err != nil {
+		host = "unknown host"
+	}
+
+	return &Locker{
+		fs:          fs,
+		dir:         dir,
+		ttl:         ttl,
+		forceUnlock: forceUnlock,
+		owner:       fmt.Sprintf("%s, pid %d", host, os.Getpid()),
+		now:         time.Now,
+	}
+}
+
+// FilePath returns the path of the lock file of the given environment in the given directory
+func FilePath(dir string, environment string) string {
+	return filepath.Join(dir, environment+".lock")
+}
+
+// Acquire locks the given environment. If the environment is locked by another deployment and the lock did not expire,
+// a LockedError is returned. The lock is renewed in the background until it is released. If it is taken over by
+// another deployment nevertheless, the context of the lock is cancelled.
+func (l *Locker) Acquire(ctx context.Context, environment string) (*Lock, error) {
+	if err := l.fs.MkdirAll(l.dir, 0777); err != nil {
+		return nil, fmt.Errorf("failed to create lock directory %q: %w", l.dir, err)
+	}
+
+	now := l.now()
+	info := Info{
+		Environment: environment,
+		ID:          uuid.NewString(),
+		Owner:       l.owner,
+		AcquiredAt:  now,
+		ExpiresAt:   now.Add(l.ttl),
+	}
+
+	err := l.create(info)
+	if errors.Is(err, os.ErrExist) {
+		existing, readErr := l.read(environment)
+		if readErr != nil {
+			return nil, readErr
+		}
+
+		switch {
+		case l.forceUnlock:
+			log.WithFields(field.Environment(environment, "")).Warn("Forcefully taking over lock of environment %q held by %s", environment, existing.Owner)
+		case existing.ExpiresAt.Before(now):
+			log.WithFields(field.Environment(environment, "")).Warn("Taking over expired lock of environment %q held by %s", environment, existing.Owner)
+		default:
+			return nil, LockedError{Info: existing}
+		}
+
+		err = l.replace(existing.ID, info)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	lockCtx, cancel := context.WithCancelCause(ctx)
+	lock := &Lock{locker: l, info: info, ctx: lockCtx, cancel: cancel, stop: make(chan struct{}), done: make(chan struct{})}
+	go lock.heartbeat(ctx)
+	return lock, nil
+}
+
+// create atomically creates the lock file of the given lock. It fails with os.ErrExist if the file already exists.
+func (l *Locker) create(info Info) error {
+	b, err := json.MarshalIndent(info, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	f, err := l.fs.OpenFile(FilePath(l.dir, info.Environment), os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(b); err != nil {
+		_ = f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// replace atomically replaces the lock file of the given lock, if it still holds the lock with the expected ID. To not
+// replace the lock of another deployment that took over the lock in the meantime, the lock file is read again right
+// before it is replaced, and read back afterward. If the lock is held by another deployment, a LockedError is returned.
+func (l *Locker) replace(expectedID string, info Info) error {
+	b, err := json.MarshalIndent(info, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	path := FilePath(l.dir, info.Environment)
+	tmpPath := path + "." + info.ID + ".tmp"
+	if err := afero.WriteFile(l.fs, tmpPath, b, 0644); err != nil {
+		return fmt.Errorf("failed to write lock file %q: %w", tmpPath, err)
+	}
+	defer func() { _ = l.fs.Remove(tmpPath) }() // the file only remains if it was not renamed
+
+	if current, err := l.read(info.Environment); err != nil {
+		return err
+	} else if current.ID != expectedID {
+		return LockedError{Info: current}
+	}
+
+	if err := l.fs.Rename(tmpPath, path); err != nil {
+		return fmt.Errorf("failed to replace lock file %q: %w", path, err)
+	}
+
+	// another deployment might have replaced the lock file at the same time
+	if current, err := l.read(info.Environment); err != nil {
+		return err
+	} else if current.ID != info.ID {
+		return LockedError{Info: current}
+	}
+	return nil
+}
+
+func (l *Locker) read(environment string) (Info, error) {
+	path := FilePath(l.dir, environment)
+	b, err := afero.ReadFile(l.fs, path)
+	if err != nil {
+		return Info{}, fmt.Errorf("failed to read lock file %q: %w", path, err)
+	}
+
+	var info Info
+	if err := json.Unmarshal(b, &info); err != nil {
+		return Info{}, fmt.Errorf("failed to parse lock file %q: %w", path, err)
+	}
+	return info, nil
+}
+
+// ErrLockLost is the cause of the cancellation of the context of a lock that was taken over by another deployment
+var ErrLockLost = errors.New("lock was taken over by another deployment")
+
+// Lock is the lock of an environment held by the current deployment
+type Lock struct {
+	locker *Locker
+	mu     sync.Mutex
+	info   Info
+	ctx    context.Context
+	cancel context.CancelCauseFunc
+	stop   chan struct{}
+	done   chan struct{}
+	once   sync.Once
+}
+
+// Context returns a context derived from the context the lock was acquired with. It is cancelled with ErrLockLost as
+// cause if the lock is taken over by another deployment, so that the deployment doesn't continue without the lock.
+// It is also cancelled when the lock is released.
+func (l *Lock) Context() context.Context {
+	return l.ctx
+}
+
+// Info returns the current information of the lock
+func (l *Lock) Info() Info {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.info
+}
+
+func (l *Lock) heartbeat(ctx context.Context) {
+	defer close(l.done)
+
+	ticker := time.NewTicker(l.locker.ttl / 3)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-l.stop:
+			return
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			err := l.renew()
+			var lockedErr LockedError
+			if errors.As(err, &lockedErr) {
+				log.WithFields(field.Environment(l.info.Environment, "")).Error("Lock of environment %q was taken over by another deployment (%s), cancelling the deployment", l.info.Environment, lockedErr.Info.Owner)
+				l.cancel(fmt.Errorf("%w (%s)", ErrLockLost, lockedErr.Info.Owner))
+				return
+			}
+			if err != nil {
+				log.WithFields(field.Environment(l.info.Environment, ""), field.Error(err)).Error("Failed to renew lock of environment %q: %v", l.info.Environment, err)
+			}
+		}
+	}
+}
+
+// renew extends the expiry of the lock by the TTL, if the lock is still held. If the lock was taken over by another
+// deployment, a LockedError is returned.
+func (l *Lock) renew() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	renewed := l.info
+	renewed.ExpiresAt = l.locker.now().Add(l.locker.ttl)
+	if err := l.locker.replace(l.info.ID, renewed); err != nil {
+		return err
+	}
+	l.info = renewed
+	return nil
+}
+
+// Release stops renewing the lock and removes it, unless it was taken over by another deployment in the meantime.
+// Releasing a lock more than once has no effect.
+func (l *Lock) Release() error {
+	var err error
+	l.once.Do(func() {
+		close(l.stop)
+		<-l.done
+		defer l.cancel(nil)
+
+		l.mu.Lock()
+		defer l.mu.Unlock()
+
+		existing, readErr := l.locker.read(l.info.Environment)
+		if readErr != nil {
+			err = readErr
+			return
+		}
+		if existing.ID != l.info.ID {
+			log.WithFields(field.Environment(l.info.Environment, "")).Warn("Lock of environment %q was taken over by another deployment (%s) and is not released", l.info.Environment, existing.Owner)
+			return
+		}
+		if removeErr := l.locker.fs.Remove(FilePath(l.locker.dir, l.info.Environment)); removeErr != nil {
+			err = fmt.Errorf("failed to release lock of environment %q: %w", l.info.Environment, removeErr)
+		}
+	})
+	return err
+}
@@ -0,0 +1,206 @@
+//go:build unit
+
+/*
+ * @license
+ * Copyright 2025 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package lock_test
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy/lock"
+)
+
+func writeLock(t *testing.T, fs afero.Fs, info lock.Info) {
+	b, err := json.Marshal(info)
+	require.NoError(t, err)
+	require.NoError(t, afero.WriteFile(fs, lock.FilePath("locks", info.Environment), b, 0644))
+}
+
+func readLock(t *testing.T, fs afero.Fs, env string) lock.Info {
+	b, err := afero.ReadFile(fs, lock.FilePath("locks", env))
+	require.NoError(t, err)
+	var info lock.Info
+	require.NoError(t, json.Unmarshal(b, &info))
+	return info
+}
+
+func TestLocker_AcquireAndRelease(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	locker := lock.NewLocker(fs, "locks", time.Minute, false)
+
+	l, err := locker.Acquire(t.Context(), "dev")
+	require.NoError(t, err)
+
+	info := readLock(t, fs, "dev")
+	assert.Equal(t, l.Info().ID, info.ID)
+	assert.Equal(t, "dev", info.Environment)
+	assert.NotEmpty(t, info.Owner)
+	assert.True(t, info.ExpiresAt.After(info.AcquiredAt))
+
+	_, err = locker.Acquire(t.Context(), "dev")
+	assert.ErrorAs(t, err, &lock.LockedError{})
+
+	require.NoError(t, l.Release())
+	exists, err := afero.Exists(fs, lock.FilePath("locks", "dev"))
+	require.NoError(t, err)
+	assert.False(t, exists)
+
+	require.NoError(t, l.Release(), "releasing a lock twice has no effect")
+
+	l, err = locker.Acquire(t.Context(), "dev")
+	require.NoError(t, err)
+	require.NoError(t, l.Release())
+}
+
+func TestLocker_AcquireFailsIfLockedByOtherDeployment(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	existing := lock.Info{Environment: "dev", ID: "other", Owner: "other host", AcquiredAt: time.Now(), ExpiresAt: time.Now().Add(time.Hour)}
+	writeLock(t, fs, existing)
+
+	_, err := lock.NewLocker(fs, "locks", time.Minute, false).Acquire(t.Context(), "dev")
+
+	var lockedErr lock.LockedError
+	require.ErrorAs(t, err, &lockedErr)
+	assert.Equal(t, "other", lockedErr.Info.ID)
+	assert.Equal(t, "other", readLock(t, fs, "dev").ID, "existing lock must not be modified")
+}
+
+func TestLocker_AcquireRemovesExpiredLock(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	writeLock(t, fs, lock.Info{Environment: "dev", ID: "other", AcquiredAt: time.Now().Add(-time.Hour), ExpiresAt: time.Now().Add(-time.Minute)})
+
+	l, err := lock.NewLocker(fs, "locks", time.Minute, false).Acquire(t.Context(), "dev")
+	require.NoError(t, err)
+	defer l.Release()
+
+	assert.Equal(t, l.Info().ID, readLock(t, fs, "dev").ID)
+}
+
+func TestLocker_ForceUnlockRemovesLockOfOtherDeployment(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	writeLock(t, fs, lock.Info{Environment: "dev", ID: "other", AcquiredAt: time.Now(), ExpiresAt: time.Now().Add(time.Hour)})
+
+	l, err := lock.NewLocker(fs, "locks", time.Minute, true).Acquire(t.Context(), "dev")
+	require.NoError(t, err)
+	defer l.Release()
+
+	assert.Equal(t, l.Info().ID, readLock(t, fs, "dev").ID)
+}
+
+func TestLock_HeartbeatRenewsLock(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	l, err := lock.NewLocker(fs, "locks", 150*time.Millisecond, false).Acquire(t.Context(), "dev")
+	require.NoError(t, err)
+	defer l.Release()
+
+	initial := readLock(t, fs, "dev").ExpiresAt
+	assert.Eventually(t, func() bool {
+		return readLock(t, fs, "dev").ExpiresAt.After(initial)
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestLock_ReleaseKeepsLockTakenOverByOtherDeployment(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	l, err := lock.NewLocker(fs, "locks", time.Minute, false).Acquire(t.Context(), "dev")
+	require.NoError(t, err)
+
+	writeLock(t, fs, lock.Info{Environment: "dev", ID: "other", AcquiredAt: time.Now(), ExpiresAt: time.Now().Add(time.Hour)})
+
+	require.NoError(t, l.Release())
+	assert.Equal(t, "other", readLock(t, fs, "dev").ID)
+}
+
+// hookFs calls beforeTmpFile before a temporary lock file is created, and afterRename after a file was renamed
+type hookFs struct {
+	afero.Fs
+	beforeTmpFile func()
+	afterRename   func()
+}
+
+func (f hookFs) OpenFile(name string, flag int, perm os.FileMode) (afero.File, error) {
+	if strings.HasSuffix(name, ".tmp") && f.beforeTmpFile != nil {
+		f.beforeTmpFile()
+	}
+	return f.Fs.OpenFile(name, flag, perm)
+}
+
+func (f hookFs) Rename(oldname, newname string) error {
+	err := f.Fs.Rename(oldname, newname)
+	if f.afterRename != nil {
+		f.afterRename()
+	}
+	return err
+}
+
+func TestLocker_AcquireDoesNotReplaceLockTakenOverInTheMeantime(t *testing.T) {
+	memFs := afero.NewMemMapFs()
+	writeLock(t, memFs, lock.Info{Environment: "dev", ID: "expired", AcquiredAt: time.Now().Add(-time.Hour), ExpiresAt: time.Now().Add(-time.Minute)})
+
+	// another deployment takes over the expired lock after it was read, but before it is replaced
+	fs := hookFs{Fs: memFs, beforeTmpFile: func() {
+		writeLock(t, memFs, lock.Info{Environment: "dev", ID: "other", AcquiredAt: time.Now(), ExpiresAt: time.Now().Add(time.Hour)})
+	}}
+
+	_, err := lock.NewLocker(fs, "locks", time.Minute, false).Acquire(t.Context(), "dev")
+
+	var lockedErr lock.LockedError
+	require.ErrorAs(t, err, &lockedErr)
+	assert.Equal(t, "other", lockedErr.Info.ID)
+	assert.Equal(t, "other", readLock(t, memFs, "dev").ID, "lock of other deployment must not be replaced")
+}
+
+func TestLocker_AcquireFailsIfLockIsReplacedConcurrently(t *testing.T) {
+	memFs := afero.NewMemMapFs()
+	writeLock(t, memFs, lock.Info{Environment: "dev", ID: "expired", AcquiredAt: time.Now().Add(-time.Hour), ExpiresAt: time.Now().Add(-time.Minute)})
+
+	// another deployment replaces the expired lock at the same time
+	fs := hookFs{Fs: memFs, afterRename: func() {
+		writeLock(t, memFs, lock.Info{Environment: "dev", ID: "other", AcquiredAt: time.Now(), ExpiresAt: time.Now().Add(time.Hour)})
+	}}
+
+	_, err := lock.NewLocker(fs, "locks", time.Minute, false).Acquire(t.Context(), "dev")
+
+	var lockedErr lock.LockedError
+	require.ErrorAs(t, err, &lockedErr)
+	assert.Equal(t, "other", lockedErr.Info.ID)
+}
+
+func TestLock_HeartbeatCancelsContextIfLockIsTakenOver(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	l, err := lock.NewLocker(fs, "locks", 150*time.Millisecond, false).Acquire(t.Context(), "dev")
+	require.NoError(t, err)
+	defer l.Release()
+
+	writeLock(t, fs, lock.Info{Environment: "dev", ID: "other", AcquiredAt: time.Now(), ExpiresAt: time.Now().Add(time.Hour)})
+
+	select {
+	case <-l.Context().Done():
+		assert.ErrorIs(t, context.Cause(l.Context()), lock.ErrLockLost)
+	case <-time.After(time.Second):
+		t.Fatal("context of lock was not cancelled")
+	}
+	assert.Equal(t, "other", readLock(t, fs, "dev").ID, "lock of other deployment must not be replaced")
+}
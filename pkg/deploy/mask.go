@@ -0,0 +1,103 @@
+/*
+ * @license
+ * Copyright 2025 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package deploy
+
+import (
+	"encoding/json"
+
+	jsonutils "github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/json"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/secret"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/template"
+)
+
+// maskedValue replaces values of differences that must not be shown, like the actual values of secrets
+const maskedValue = "****"
+
+// diffWithRemote compares the rendered config with the given remote object. The values of all differences that are
+// rendered from secrets are masked, so that the differences can be shown to users.
+func diffWithRemote(c *config.Config, properties parameter.Properties, renderedConfig string, remote []byte) ([]jsonutils.Difference, error) {
+	diffs, err := jsonutils.Diff([]byte(renderedConfig), remote)
+	if err != nil || len(diffs) == 0 || !secret.ContainsMasked(map[string]any(properties)) {
+		return diffs, err
+	}
+	return maskSecretDifferences(c, properties, renderedConfig, diffs), nil
+}
+
+// maskSecretDifferences masks the values of all differences that are rendered from secrets. The paths of these values
+// are the ones that differ between the rendered config and the config rendered with masked secrets. The desired values
+// are taken from the masked config, and the actual values are replaced as well, as they are likely to be secret, too.
+// If the config can't be rendered with masked secrets, all values are masked.
+func maskSecretDifferences(c *config.Config, properties parameter.Properties, renderedConfig string, diffs []jsonutils.Difference) []jsonutils.Difference {
+	secretPaths, maskedDoc, ok := findSecretPaths(c, properties, renderedConfig)
+
+	result := make([]jsonutils.Difference, len(diffs))
+	for i, d := range diffs {
+		result[i] = d
+
+		p, err := jsonutils.ParsePath(d.Path)
+		if ok && err == nil && !isAffectedBySecret(p, secretPaths) {
+			continue
+		}
+
+		result[i].Desired = maskedValue
+		if v, found := p.Get(maskedDoc); ok && err == nil && found {
+			result[i].Desired = v
+		}
+		if d.Actual != nil {
+			result[i].Actual = maskedValue
+		}
+	}
+	return result
+}
+
+// findSecretPaths returns the paths of all values of the rendered config that are rendered from secrets, together with
+// the config rendered with masked secrets. If the paths can't be found, ok is false.
+func findSecretPaths(c *config.Config, properties parameter.Properties, renderedConfig string) (paths []jsonutils.Path, maskedDoc any, ok bool) {
+	maskedConfig, err := template.RenderMasked(c.Template, properties)
+	if err != nil {
+		return nil, nil, false
+	}
+
+	secretDiffs, err := jsonutils.Diff([]byte(renderedConfig), []byte(maskedConfig))
+	if err != nil {
+		return nil, nil, false
+	}
+	if err := json.Unmarshal([]byte(maskedConfig), &maskedDoc); err != nil {
+		return nil, nil, false
+	}
+
+	for _, d := range secretDiffs {
+		p, err := jsonutils.ParsePath(d.Path)
+		if err != nil {
+			return nil, nil, false
+		}
+		paths = append(paths, p)
+	}
+	return paths, maskedDoc, true
+}
+
+// isAffectedBySecret returns whether the value of the given path is, or contains, a value of one of the secret paths
+func isAffectedBySecret(p jsonutils.Path, secretPaths []jsonutils.Path) bool {
+	for _, s := range secretPaths {
+		if p.Contains(s) || s.Contains(p) {
+			return true
+		}
+	}
+	return false
+}
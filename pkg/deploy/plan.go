@@ -0,0 +1,185 @@
+/*
+ * @license
+ * Copyright 2025 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package deploy
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/cmd/monaco/dynatrace"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/json"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/log"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/log/field"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/multierror"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/client"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/coordinate"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/entities"
+	deployErrors "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy/errors"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy/internal/remote"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/graph"
+	project "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/project/v2"
+)
+
+// PlanAction describes what a deployment would do with a single config
+type PlanAction string
+
+const (
+	// PlanActionCreate states that no remote object exists yet and a new one would be created
+	PlanActionCreate PlanAction = "create"
+	// PlanActionUpdate states that the remote object exists and differs from the rendered config
+	PlanActionUpdate PlanAction = "update"
+	// PlanActionUnchanged states that the remote object exists and is equal to the rendered config
+	PlanActionUnchanged PlanAction = "unchanged"
+	// PlanActionSkip states that the config would not be deployed, either because it is skipped or because it depends
+	// on a skipped config
+	PlanActionSkip PlanAction = "skip"
+)
+
+// PlannedChange is the planned deployment action for a single config in a single environment
+type PlannedChange struct {
+	Environment string                `json:"environment"`
+	Coordinate  coordinate.Coordinate `json:"coordinate"`
+	Action      PlanAction            `json:"action"`
+	// RemoteID is the ID of the existing remote object. It is empty if the object would be created.
+	RemoteID string `json:"remoteId,omitempty"`
+	// Differences lists all values of the rendered config that differ from the remote object
+	Differences []json.Difference `json:"differences,omitempty"`
+}
+
+// Plan resolves and renders all configs of the given projects for each environment and compares them with the current
+// state of the respective remote objects. Nothing is written to any environment.
+// The returned changes are ordered by environment name and, within an environment, by the order they would be deployed in.
+// Errors for single configs are collected as deployErrors.EnvironmentDeploymentErrors, and planning continues for all
+// configs not depending on a failed one.
+func Plan(ctx context.Context, projects []project.Project, environmentClients dynatrace.EnvironmentClients) ([]PlannedChange, error) {
+	g := graph.New(projects, environmentClients.Names())
+
+	envs := sortedEnvironments(environmentClients)
+
+	var changes []PlannedChange
+	planErrs := make(deployErrors.EnvironmentDeploymentErrors)
+	for _, env := range envs {
+		ctx := newContextWithEnvironment(ctx, env)
+		log.WithCtxFields(ctx).Info("Planning deployment to environment %q...", env.Name)
+
+		sortedConfigs, err := g.SortConfigs(env.Name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to sort configs for environment %q: %w", env.Name, err)
+		}
+
+		envChanges, errs := planEnvironment(ctx, env.Name, sortedConfigs, environmentClients[env])
+		changes = append(changes, envChanges...)
+		for _, err := range errs {
+			planErrs = planErrs.Append(env.Name, err)
+		}
+	}
+
+	if len(planErrs) != 0 {
+		return changes, planErrs
+	}
+	return changes, nil
+}
+
+func planEnvironment(ctx context.Context, environment string, sortedConfigs []config.Config, clientset *client.ClientSet) ([]PlannedChange, []error) {
+	var changes []PlannedChange
+	var errs []error
+
+	resolvedEntities := entities.New()
+	notDeployed := map[coordinate.Coordinate]struct{}{}
+
+	for i := range sortedConfigs {
+		c := &sortedConfigs[i]
+		ctx := context.WithValue(ctx, log.CtxKeyCoord{}, c.Coordinate)
+
+		if c.Skip || dependsOnAny(c, notDeployed) {
+			notDeployed[c.Coordinate] = struct{}{}
+			changes = append(changes, PlannedChange{Environment: environment, Coordinate: c.Coordinate, Action: PlanActionSkip})
+			continue
+		}
+
+		change, resolvedEntity, err := planConfig(ctx, c, clientset, resolvedEntities)
+		if err != nil {
+			log.WithCtxFields(ctx).WithFields(field.Error(err)).Error("Failed to plan deployment: %v", err)
+			notDeployed[c.Coordinate] = struct{}{}
+			errs = append(errs, err)
+			continue
+		}
+
+		change.Environment = environment
+		changes = append(changes, change)
+		resolvedEntities.Put(resolvedEntity)
+	}
+
+	return changes, errs
+}
+
+func planConfig(ctx context.Context, c *config.Config, clientset *client.ClientSet, resolvedEntities *entities.EntityMap) (PlannedChange, entities.ResolvedEntity, error) {
+	properties, errs := c.ResolveParameterValues(resolvedEntities)
+	if len(errs) > 0 {
+		return PlannedChange{}, entities.ResolvedEntity{}, multierror.New(errs...)
+	}
+
+	renderedConfig, err := c.Render(properties)
+	if err != nil {
+		return PlannedChange{}, entities.ResolvedEntity{}, err
+	}
+
+	obj, found, err := remote.Get(ctx, clientset, properties, c)
+	if err != nil {
+		return PlannedChange{}, entities.ResolvedEntity{}, fmt.Errorf("failed to fetch remote object: %w", err)
+	}
+
+	change := PlannedChange{Coordinate: c.Coordinate}
+	resolvedEntity := entities.ResolvedEntity{
+		EntityName: c.Coordinate.ConfigId,
+		Coordinate: c.Coordinate,
+		Properties: properties,
+	}
+	if !found {
+		change.Action = PlanActionCreate
+		// the ID of objects that don't exist yet is not known - a placeholder makes references to it resolvable
+		properties[config.IdParameter] = fmt.Sprintf("<id of %s>", c.Coordinate)
+		resolvedEntity.DryRun = true
+	} else {
+		diffs, err := diffWithRemote(c, properties, renderedConfig, obj.Payload)
+		if err != nil {
+			return PlannedChange{}, entities.ResolvedEntity{}, fmt.Errorf("failed to compare with remote object: %w", err)
+		}
+
+		change.Action = PlanActionUnchanged
+		if len(diffs) > 0 {
+			change.Action = PlanActionUpdate
+		}
+		change.RemoteID = obj.ID
+		change.Differences = diffs
+		properties[config.IdParameter] = obj.ID
+		// the remote object stands in for the response to its deployment
+		resolvedEntity.Response = obj.Payload
+	}
+
+	return change, resolvedEntity, nil
+}
+
+func dependsOnAny(c *config.Config, coordinates map[coordinate.Coordinate]struct{}) bool {
+	for _, ref := range c.References() {
+		if _, found := coordinates[ref]; found {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,189 @@
+//go:build unit
+
+/*
+ * @license
+ * Copyright 2025 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package deploy_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/cmd/monaco/dynatrace"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/idutils"
+	jsonutils "github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/json"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/secret"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/client"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/client/dtclient"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/coordinate"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter/value"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/template"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy"
+	project "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/project/v2"
+)
+
+func newPlanTestSetting(configID string, content string, skip bool, refs ...coordinate.Coordinate) config.Config {
+	params := config.Parameters{
+		config.ScopeParameter: &value.ValueParameter{Value: "environment"},
+	}
+	for _, r := range refs {
+		params["ref-"+r.ConfigId] = &parameter.DummyParameter{
+			References: []parameter.ParameterReference{{Config: r, Property: config.IdParameter}},
+		}
+	}
+
+	return config.Config{
+		Template:    template.NewInMemoryTemplate(configID, content),
+		Coordinate:  coordinate.Coordinate{Project: "proj", Type: "builtin:test", ConfigId: configID},
+		Type:        config.SettingsType{SchemaId: "builtin:test"},
+		Environment: "env",
+		Parameters:  params,
+		Skip:        skip,
+	}
+}
+
+func TestPlan(t *testing.T) {
+	unchanged := newPlanTestSetting("unchanged", `{"name": "a"}`, false)
+	updated := newPlanTestSetting("updated", `{"name": "new"}`, false)
+	created := newPlanTestSetting("created", `{"name": "c"}`, false, unchanged.Coordinate)
+	skipped := newPlanTestSetting("skipped", `{}`, true)
+	dependsOnSkipped := newPlanTestSetting("depends-on-skipped", `{}`, false, skipped.Coordinate)
+
+	remoteObjects := map[string]dtclient.DownloadSettingsObject{}
+	for id, obj := range map[coordinate.Coordinate]dtclient.DownloadSettingsObject{
+		unchanged.Coordinate: {ObjectId: "unchanged-id", Value: []byte(`{"name": "a", "serverManaged": true}`)},
+		updated.Coordinate:   {ObjectId: "updated-id", Value: []byte(`{"name": "old"}`)},
+	} {
+		externalID, err := idutils.GenerateExternalIDForSettingsObject(id)
+		require.NoError(t, err)
+		obj.ExternalId = externalID
+		remoteObjects[externalID] = obj
+	}
+
+	c := client.NewMockSettingsClient(gomock.NewController(t))
+	c.EXPECT().List(gomock.Any(), "builtin:test", gomock.Any()).AnyTimes().DoAndReturn(
+		func(_ any, _ string, opts dtclient.ListSettingsOptions) ([]dtclient.DownloadSettingsObject, error) {
+			var result []dtclient.DownloadSettingsObject
+			for _, o := range remoteObjects {
+				if opts.Filter(o) {
+					result = append(result, o)
+				}
+			}
+			return result, nil
+		})
+
+	projects := []project.Project{
+		{
+			Id: "proj",
+			Configs: project.ConfigsPerTypePerEnvironments{
+				"env": project.ConfigsPerType{
+					"builtin:test": []config.Config{unchanged, updated, created, skipped, dependsOnSkipped},
+				},
+			},
+		},
+	}
+
+	clients := dynatrace.EnvironmentClients{
+		dynatrace.EnvironmentInfo{Name: "env"}: &client.ClientSet{SettingsClient: c},
+	}
+
+	changes, err := deploy.Plan(t.Context(), projects, clients)
+	require.NoError(t, err)
+
+	assert.ElementsMatch(t, []deploy.PlannedChange{
+		{Environment: "env", Coordinate: unchanged.Coordinate, Action: deploy.PlanActionUnchanged, RemoteID: "unchanged-id"},
+		{Environment: "env", Coordinate: updated.Coordinate, Action: deploy.PlanActionUpdate, RemoteID: "updated-id", Differences: []jsonutils.Difference{{Path: "$.name", Desired: "new", Actual: "old"}}},
+		{Environment: "env", Coordinate: created.Coordinate, Action: deploy.PlanActionCreate},
+		{Environment: "env", Coordinate: skipped.Coordinate, Action: deploy.PlanActionSkip},
+		{Environment: "env", Coordinate: dependsOnSkipped.Coordinate, Action: deploy.PlanActionSkip},
+	}, changes)
+}
+
+// newPlanTestSettingWithSecret returns a settings config whose token is rendered from a secret, and a settings client
+// returning a remote object for it with a different name and token
+func newPlanTestSettingWithSecret(t *testing.T) (config.Config, *client.MockSettingsClient) {
+	conf := newPlanTestSetting("with-secret", `{"name": "a", "auth": {"token": "Bearer {{ .token }}"}}`, false)
+	conf.Parameters["token"] = &parameter.DummyParameter{Value: secret.MaskedString("the-secret-token")}
+
+	externalID, err := idutils.GenerateExternalIDForSettingsObject(conf.Coordinate)
+	require.NoError(t, err)
+	remoteObject := dtclient.DownloadSettingsObject{ObjectId: "id", ExternalId: externalID, Value: []byte(`{"name": "b", "auth": {"token": "Bearer the-old-token"}}`)}
+
+	c := client.NewMockSettingsClient(gomock.NewController(t))
+	c.EXPECT().List(gomock.Any(), "builtin:test", gomock.Any()).AnyTimes().DoAndReturn(
+		func(_ any, _ string, opts dtclient.ListSettingsOptions) ([]dtclient.DownloadSettingsObject, error) {
+			if opts.Filter(remoteObject) {
+				return []dtclient.DownloadSettingsObject{remoteObject}, nil
+			}
+			return nil, nil
+		})
+	return conf, c
+}
+
+func TestPlan_MasksSecrets(t *testing.T) {
+	conf, c := newPlanTestSettingWithSecret(t)
+	projects := []project.Project{{Id: "proj", Configs: project.ConfigsPerTypePerEnvironments{"env": {"builtin:test": {conf}}}}}
+	clients := dynatrace.EnvironmentClients{
+		dynatrace.EnvironmentInfo{Name: "env"}: &client.ClientSet{SettingsClient: c},
+	}
+
+	changes, err := deploy.Plan(t.Context(), projects, clients)
+	require.NoError(t, err)
+
+	require.Len(t, changes, 1)
+	assert.Equal(t, []jsonutils.Difference{
+		{Path: "$.auth.token", Desired: "Bearer ****", Actual: "****"},
+		{Path: "$.name", Desired: "a", Actual: "b"},
+	}, changes[0].Differences)
+
+	b, err := json.Marshal(changes)
+	require.NoError(t, err)
+	assert.NotContains(t, string(b), "the-secret-token")
+	assert.NotContains(t, string(b), "the-old-token")
+}
+
+func TestPlan_CollectsErrors(t *testing.T) {
+	faulty := newPlanTestSetting("faulty", `{`, false)
+	dependent := newPlanTestSetting("dependent", `{}`, false, faulty.Coordinate)
+
+	projects := []project.Project{
+		{
+			Id: "proj",
+			Configs: project.ConfigsPerTypePerEnvironments{
+				"env": project.ConfigsPerType{
+					"builtin:test": []config.Config{faulty, dependent},
+				},
+			},
+		},
+	}
+
+	c := client.NewMockSettingsClient(gomock.NewController(t))
+	c.EXPECT().List(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil).AnyTimes()
+
+	clients := dynatrace.EnvironmentClients{
+		dynatrace.EnvironmentInfo{Name: "env"}: &client.ClientSet{SettingsClient: c},
+	}
+
+	changes, err := deploy.Plan(t.Context(), projects, clients)
+	assert.Error(t, err)
+	assert.Equal(t, []deploy.PlannedChange{{Environment: "env", Coordinate: dependent.Coordinate, Action: deploy.PlanActionSkip}}, changes)
+}
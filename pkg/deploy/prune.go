@@ -0,0 +1,282 @@
+/*
+ * @license
+ * Copyright 2025 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package deploy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/cmd/monaco/dynatrace"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/idutils"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/log"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/log/field"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/api"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/client"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/client/dtclient"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/coordinate"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/delete"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/delete/pointer"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy/internal/remote"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy/state"
+	project "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/project/v2"
+)
+
+// PruneOptions defines additional options used by Prune
+type PruneOptions struct {
+	// DryRun only logs the remote objects that would be deleted
+	DryRun bool
+	// States holds the deployment state of each environment by environment name, see DeployConfigsOptions.States.
+	// Entries of deleted objects are removed from the states.
+	States map[string]*state.State
+}
+
+// Prune deletes all remote objects that monaco deployed for the given projects earlier, but whose configs were removed
+// from the projects since. Objects of other projects are never deleted.
+//
+// Such objects are found in the deployment state of each environment, if one is given, by the external ID of Settings
+// objects of all schemas of the environment, and by the IDs of documents and automations of configs that were removed.
+func Prune(ctx context.Context, projects []project.Project, environmentClients dynatrace.EnvironmentClients, opts PruneOptions) error {
+	envs := sortedEnvironments(environmentClients)
+
+	var errs []error
+	for _, env := range envs {
+		ctx := newContextWithEnvironment(ctx, env)
+		if err := pruneEnvironment(ctx, projects, env.Name, environmentClients[env], opts); err != nil {
+			errs = append(errs, fmt.Errorf("failed to prune environment %q: %w", env.Name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func pruneEnvironment(ctx context.Context, projects []project.Project, environment string, clientset *client.ClientSet, opts PruneOptions) error {
+	s := opts.States[environment]
+
+	entriesToDelete, err := findRemovedConfigs(ctx, projects, environment, clientset, s)
+	if err != nil {
+		return err
+	}
+
+	if len(entriesToDelete) == 0 {
+		log.WithCtxFields(ctx).Info("No removed configurations to prune from environment %q", environment)
+		return nil
+	}
+
+	var pointers []pointer.DeletePointer
+	for _, dps := range entriesToDelete {
+		pointers = append(pointers, dps...)
+	}
+	slices.SortFunc(pointers, func(a, b pointer.DeletePointer) int { return strings.Compare(a.String(), b.String()) })
+
+	if opts.DryRun {
+		log.WithCtxFields(ctx).Info("Would prune %d removed configuration(s) from environment %q:", len(pointers), environment)
+	} else {
+		log.WithCtxFields(ctx).Info("Pruning %d removed configuration(s) from environment %q:", len(pointers), environment)
+	}
+	for _, dp := range pointers {
+		log.WithCtxFields(ctx).WithFields(field.Coordinate(dp.AsCoordinate())).Info("  - %s (%s)", dp, dp.OriginObjectId)
+	}
+
+	if opts.DryRun {
+		return nil
+	}
+
+	if err := delete.Configs(ctx, *clientset, entriesToDelete); err != nil {
+		return err
+	}
+
+	for _, dp := range pointers {
+		s.Remove(dp.AsCoordinate())
+	}
+	return nil
+}
+
+// findRemovedConfigs returns delete pointers to all remote objects of the given projects in the given environment
+// whose configs don't exist anymore.
+//
+// Settings objects are found by their external ID in all schemas of the environment, as the external ID contains the
+// coordinate of their config. The IDs of documents and automations are generated from the coordinate of their config
+// instead, so they are looked up for all removed configs known from the state or from the other environments of the
+// projects.
+func findRemovedConfigs(ctx context.Context, projects []project.Project, environment string, clientset *client.ClientSet, s *state.State) (delete.DeleteEntries, error) {
+	projectIDs := map[string]struct{}{}
+	loaded := map[coordinate.Coordinate]struct{}{}
+	var schemas []string
+	for _, p := range projects {
+		projectIDs[p.Id] = struct{}{}
+		for c := range p.Configs[environment].AllConfigs {
+			loaded[c.Coordinate] = struct{}{}
+			if t, ok := c.Type.(config.SettingsType); ok && !slices.Contains(schemas, t.SchemaId) {
+				schemas = append(schemas, t.SchemaId)
+			}
+		}
+	}
+
+	isRemoved := func(c coordinate.Coordinate) bool {
+		if _, found := projectIDs[c.Project]; !found {
+			return false
+		}
+		_, found := loaded[c]
+		return !found
+	}
+
+	entries := delete.DeleteEntries{}
+	found := map[coordinate.Coordinate]struct{}{}
+	add := func(dp pointer.DeletePointer) {
+		entries[dp.Type] = append(entries[dp.Type], dp)
+		found[dp.AsCoordinate()] = struct{}{}
+	}
+
+	// remote objects identified by generated IDs, by coordinate, with the object ID recorded in the state if any
+	generatedIDCandidates := map[coordinate.Coordinate]string{}
+	for _, p := range projects {
+		for env, configs := range p.Configs {
+			if env == environment {
+				continue
+			}
+			for c := range configs.AllConfigs {
+				if _, ok := typeWithGeneratedID(c.Coordinate.Type); ok && isRemoved(c.Coordinate) {
+					generatedIDCandidates[c.Coordinate] = ""
+				}
+			}
+		}
+	}
+
+	for _, e := range s.Entries() {
+		if !isRemoved(e.Coordinate) || !canBeDeleted(e.Coordinate.Type) {
+			continue
+		}
+		if _, ok := typeWithGeneratedID(e.Coordinate.Type); ok {
+			generatedIDCandidates[e.Coordinate] = e.ID
+			continue
+		}
+		add(pointer.DeletePointer{
+			Project:        e.Coordinate.Project,
+			Type:           e.Coordinate.Type,
+			Identifier:     e.Coordinate.ConfigId,
+			Scope:          e.Scope,
+			OriginObjectId: e.ID,
+		})
+	}
+
+	candidates := slices.SortedFunc(maps.Keys(generatedIDCandidates), func(a, b coordinate.Coordinate) int { return strings.Compare(a.String(), b.String()) })
+	for _, c := range candidates {
+		obj, exists, err := getObjectWithGeneratedID(ctx, clientset, c, generatedIDCandidates[c])
+		if err != nil {
+			return nil, fmt.Errorf("failed to look up remote object of %s: %w", c, err)
+		}
+		if !exists {
+			log.WithCtxFields(ctx).WithFields(field.Coordinate(c)).Debug("No remote object of removed config %s exists", c)
+			continue
+		}
+		add(pointer.DeletePointer{
+			Project:        c.Project,
+			Type:           c.Type,
+			Identifier:     c.ConfigId,
+			OriginObjectId: obj.ID,
+		})
+	}
+
+	if clientset.SettingsClient == nil {
+		return entries, nil
+	}
+
+	schemaList, err := clientset.SettingsClient.ListSchemas(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list settings schemas: %w", err)
+	}
+	for _, schema := range schemaList {
+		if !slices.Contains(schemas, schema.SchemaId) {
+			schemas = append(schemas, schema.SchemaId)
+		}
+	}
+
+	slices.Sort(schemas)
+	for _, schema := range schemas {
+		objects, err := clientset.SettingsClient.List(ctx, schema, dtclient.ListSettingsOptions{
+			DiscardValue: true,
+			Filter:       func(o dtclient.DownloadSettingsObject) bool { return strings.HasPrefix(o.ExternalId, "monaco:") },
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list settings objects of schema %q: %w", schema, err)
+		}
+
+		for _, o := range objects {
+			c, err := idutils.ParseExternalIDForSettingsObject(o.ExternalId)
+			if err != nil {
+				log.WithCtxFields(ctx).WithFields(field.Type(schema)).Debug("Ignoring settings object %q: %v", o.ObjectId, err)
+				continue
+			}
+			if _, dup := found[c]; dup || c.Type != schema || !isRemoved(c) {
+				continue
+			}
+			add(pointer.DeletePointer{
+				Project:        c.Project,
+				Type:           c.Type,
+				Identifier:     c.ConfigId,
+				OriginObjectId: o.ObjectId,
+			})
+		}
+	}
+
+	return entries, nil
+}
+
+// typeWithGeneratedID returns the config type of the given coordinate type, if the IDs of its remote objects are
+// generated from the coordinates of their configs (documents and automations)
+func typeWithGeneratedID(configType string) (config.Type, bool) {
+	switch configType {
+	case string(config.DocumentTypeID):
+		return config.DocumentType{}, true
+	case string(config.Workflow), string(config.BusinessCalendar), string(config.SchedulingRule):
+		return config.AutomationType{Resource: config.AutomationResource(configType)}, true
+	default:
+		return nil, false
+	}
+}
+
+// getObjectWithGeneratedID looks up the remote object of the removed config with the given coordinate by the object ID
+// recorded in the state, if any, or otherwise by the ID generated from its coordinate
+func getObjectWithGeneratedID(ctx context.Context, clientset *client.ClientSet, c coordinate.Coordinate, recordedID string) (remote.Object, bool, error) {
+	t, _ := typeWithGeneratedID(c.Type)
+	switch t.(type) {
+	case config.DocumentType:
+		if clientset.DocumentClient == nil {
+			return remote.Object{}, false, nil
+		}
+	case config.AutomationType:
+		if clientset.AutClient == nil {
+			return remote.Object{}, false, nil
+		}
+	}
+	return remote.Get(ctx, clientset, nil, &config.Config{Coordinate: c, Type: t, OriginObjectId: recordedID})
+}
+
+// canBeDeleted returns whether remote objects of the given config type can be deleted
+func canBeDeleted(configType string) bool {
+	if configType == string(config.OpenPipelineTypeID) {
+		return false
+	}
+	if a, ok := api.NewAPIs()[configType]; ok {
+		return !a.SingleConfiguration && configType != api.DashboardShareSettings
+	}
+	return true
+}
@@ -0,0 +1,216 @@
+//go:build unit
+
+/*
+ * @license
+ * Copyright 2025 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package deploy_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	libAPI "github.com/dynatrace/dynatrace-configuration-as-code-core/api"
+	"github.com/dynatrace/dynatrace-configuration-as-code-core/clients/documents"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/cmd/monaco/dynatrace"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/idutils"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/client"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/client/dtclient"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/coordinate"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/template"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy/state"
+	project "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/project/v2"
+)
+
+// newPruneTestSettingsClient returns a settings client listing the given objects and expecting the deletion of the
+// objects with the given IDs.
+func newPruneTestSettingsClient(t *testing.T, objects []dtclient.DownloadSettingsObject, deletedIDs ...string) *client.MockSettingsClient {
+	c := client.NewMockSettingsClient(gomock.NewController(t))
+	c.EXPECT().ListSchemas(gomock.Any()).AnyTimes().DoAndReturn(
+		func(_ any) (dtclient.SchemaList, error) {
+			var schemas dtclient.SchemaList
+			for _, o := range objects {
+				schemas = append(schemas, struct {
+					SchemaId string `json:"schemaId"`
+					Ordered  bool   `json:"ordered"`
+				}{SchemaId: o.SchemaId})
+			}
+			return schemas, nil
+		})
+	c.EXPECT().List(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes().DoAndReturn(
+		func(_ any, schema string, opts dtclient.ListSettingsOptions) ([]dtclient.DownloadSettingsObject, error) {
+			var result []dtclient.DownloadSettingsObject
+			for _, o := range objects {
+				if o.SchemaId == schema && (opts.Filter == nil || opts.Filter(o)) {
+					result = append(result, o)
+				}
+			}
+			return result, nil
+		})
+	for _, id := range deletedIDs {
+		c.EXPECT().Delete(gomock.Any(), id).Times(1).Return(nil)
+	}
+	return c
+}
+
+func newPruneTestSettingsObject(t *testing.T, c coordinate.Coordinate, objectID string) dtclient.DownloadSettingsObject {
+	externalID, err := idutils.GenerateExternalIDForSettingsObject(c)
+	require.NoError(t, err)
+	return dtclient.DownloadSettingsObject{
+		ExternalId:      externalID,
+		SchemaId:        c.Type,
+		ObjectId:        objectID,
+		ResourceContext: &dtclient.SettingsResourceContext{Operations: []string{dtclient.DeleteOperation}},
+	}
+}
+
+func TestPrune(t *testing.T) {
+	kept := coordinate.Coordinate{Project: "proj", Type: "builtin:test", ConfigId: "kept"}
+	removedInState := coordinate.Coordinate{Project: "proj", Type: "builtin:test", ConfigId: "removed-in-state"}
+	removedOnEnvironment := coordinate.Coordinate{Project: "proj", Type: "builtin:test", ConfigId: "removed-on-environment"}
+	otherProject := coordinate.Coordinate{Project: "other", Type: "builtin:test", ConfigId: "removed"}
+
+	projects := []project.Project{
+		{
+			Id: "proj",
+			Configs: project.ConfigsPerTypePerEnvironments{
+				"env": project.ConfigsPerType{
+					"builtin:test": []config.Config{newPlanTestSetting("kept", `{}`, false)},
+				},
+			},
+		},
+	}
+
+	objects := []dtclient.DownloadSettingsObject{
+		newPruneTestSettingsObject(t, kept, "kept-id"),
+		newPruneTestSettingsObject(t, removedInState, "removed-in-state-id"),
+		newPruneTestSettingsObject(t, removedOnEnvironment, "removed-on-environment-id"),
+		newPruneTestSettingsObject(t, otherProject, "other-id"),
+		{ExternalId: "", SchemaId: "builtin:test", ObjectId: "not-by-monaco"},
+	}
+
+	newState := func() *state.State {
+		s := state.New("env")
+		s.Put(state.Entry{Coordinate: kept, ID: "kept-id"})
+		s.Put(state.Entry{Coordinate: removedInState, ID: "removed-in-state-id"})
+		s.Put(state.Entry{Coordinate: otherProject, ID: "other-id"})
+		return s
+	}
+
+	t.Run("deletes removed configs", func(t *testing.T) {
+		s := newState()
+		c := newPruneTestSettingsClient(t, objects, "removed-in-state-id", "removed-on-environment-id")
+		clients := dynatrace.EnvironmentClients{
+			dynatrace.EnvironmentInfo{Name: "env"}: &client.ClientSet{SettingsClient: c},
+		}
+
+		err := deploy.Prune(t.Context(), projects, clients, deploy.PruneOptions{States: map[string]*state.State{"env": s}})
+		require.NoError(t, err)
+
+		_, found := s.Get(removedInState)
+		assert.False(t, found, "pruned config should be removed from state")
+		_, found = s.Get(kept)
+		assert.True(t, found)
+		_, found = s.Get(otherProject)
+		assert.True(t, found, "configs of other projects must not be pruned")
+	})
+
+	t.Run("dry-run does not delete anything", func(t *testing.T) {
+		s := newState()
+		c := newPruneTestSettingsClient(t, objects)
+		clients := dynatrace.EnvironmentClients{
+			dynatrace.EnvironmentInfo{Name: "env"}: &client.ClientSet{SettingsClient: c},
+		}
+
+		err := deploy.Prune(t.Context(), projects, clients, deploy.PruneOptions{DryRun: true, States: map[string]*state.State{"env": s}})
+		require.NoError(t, err)
+		assert.Len(t, s.Entries(), 3)
+	})
+
+	t.Run("without state only settings objects are found by external ID", func(t *testing.T) {
+		c := newPruneTestSettingsClient(t, objects, "removed-in-state-id", "removed-on-environment-id")
+		clients := dynatrace.EnvironmentClients{
+			dynatrace.EnvironmentInfo{Name: "env"}: &client.ClientSet{SettingsClient: c},
+		}
+
+		err := deploy.Prune(t.Context(), projects, clients, deploy.PruneOptions{})
+		require.NoError(t, err)
+	})
+}
+
+func TestPrune_DeletesObjectsOfSchemasWithoutConfigs(t *testing.T) {
+	removed := coordinate.Coordinate{Project: "proj", Type: "builtin:removed", ConfigId: "last"}
+	projects := []project.Project{
+		{
+			Id: "proj",
+			Configs: project.ConfigsPerTypePerEnvironments{
+				"env": project.ConfigsPerType{
+					"builtin:test": []config.Config{newPlanTestSetting("kept", `{}`, false)},
+				},
+			},
+		},
+	}
+	objects := []dtclient.DownloadSettingsObject{
+		newPruneTestSettingsObject(t, coordinate.Coordinate{Project: "proj", Type: "builtin:test", ConfigId: "kept"}, "kept-id"),
+		newPruneTestSettingsObject(t, removed, "removed-id"),
+	}
+
+	c := newPruneTestSettingsClient(t, objects, "removed-id")
+	clients := dynatrace.EnvironmentClients{
+		dynatrace.EnvironmentInfo{Name: "env"}: &client.ClientSet{SettingsClient: c},
+	}
+
+	err := deploy.Prune(t.Context(), projects, clients, deploy.PruneOptions{})
+	require.NoError(t, err)
+}
+
+func TestPrune_DeletesDocumentsByExternalID(t *testing.T) {
+	removed := config.Config{
+		Template:    template.NewInMemoryTemplate("doc", `{}`),
+		Coordinate:  coordinate.Coordinate{Project: "proj", Type: string(config.DocumentTypeID), ConfigId: "doc"},
+		Type:        config.DocumentType{Kind: config.DashboardKind},
+		Environment: "other-env",
+	}
+	// the document is only removed from env, but still deployed to other-env
+	projects := []project.Project{
+		{
+			Id: "proj",
+			Configs: project.ConfigsPerTypePerEnvironments{
+				"env":       project.ConfigsPerType{},
+				"other-env": project.ConfigsPerType{string(config.DocumentTypeID): []config.Config{removed}},
+			},
+		},
+	}
+
+	documentClient := client.NewMockDocumentClient(gomock.NewController(t))
+	documentClient.EXPECT().List(gomock.Any(), fmt.Sprintf("externalId=='%s'", idutils.GenerateExternalID(removed.Coordinate))).Times(1).
+		Return(documents.ListResponse{Responses: []documents.Response{{Metadata: documents.Metadata{ID: "doc-id"}}}}, nil)
+	documentClient.EXPECT().Get(gomock.Any(), "doc-id").Times(1).Return(documents.Response{Metadata: documents.Metadata{ID: "doc-id"}}, nil)
+	documentClient.EXPECT().Delete(gomock.Any(), "doc-id").Times(1).Return(libAPI.Response{}, nil)
+
+	clients := dynatrace.EnvironmentClients{
+		dynatrace.EnvironmentInfo{Name: "env"}: &client.ClientSet{DocumentClient: documentClient},
+	}
+
+	err := deploy.Prune(t.Context(), projects, clients, deploy.PruneOptions{})
+	require.NoError(t, err)
+}
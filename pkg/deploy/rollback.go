@@ -0,0 +1,234 @@
+/*
+ * @license
+ * Copyright 2025 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package deploy
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"sync"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/log"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/log/field"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/client"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/coordinate"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/entities"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/delete"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/delete/pointer"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy/internal/extract"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy/internal/remote"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy/internal/setting"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy/state"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/graph"
+)
+
+// snapshot is the state of the remote object of a config before the config was deployed
+type snapshot struct {
+	config *config.Config
+	// properties are the resolved properties the config was deployed with
+	properties parameter.Properties
+	// existed states whether the remote object existed before the deployment
+	existed bool
+	// object is the remote object before the deployment, if it existed
+	object remote.Object
+	// createdID is the ID of the remote object created by the deployment, if it did not exist and the deployment succeeded
+	createdID string
+}
+
+// journal records snapshots of all remote objects written during the deployment of an environment, so that the
+// environment can be rolled back if the deployment fails
+type journal struct {
+	mu        sync.Mutex
+	snapshots map[coordinate.Coordinate]*snapshot
+}
+
+func newJournal() *journal {
+	return &journal{snapshots: map[coordinate.Coordinate]*snapshot{}}
+}
+
+type ctxKeyJournal struct{}
+
+func newContextWithJournal(ctx context.Context, j *journal) context.Context {
+	return context.WithValue(ctx, ctxKeyJournal{}, j)
+}
+
+func getJournalFromContext(ctx context.Context) *journal {
+	if j, ok := ctx.Value(ctxKeyJournal{}).(*journal); ok {
+		return j
+	}
+	return nil
+}
+
+// takeSnapshot fetches the remote object of the given config and records it in the journal attached to the context.
+// Nothing is done if no journal is attached.
+func takeSnapshot(ctx context.Context, c *config.Config, clientset *client.ClientSet, properties parameter.Properties) error {
+	j := getJournalFromContext(ctx)
+	if j == nil {
+		return nil
+	}
+
+	obj, found, err := remote.Get(ctx, clientset, properties, c)
+	if err != nil {
+		return err
+	}
+
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.snapshots[c.Coordinate] = &snapshot{
+		config:     c,
+		properties: maps.Clone(properties),
+		existed:    found,
+		object:     obj,
+	}
+	return nil
+}
+
+// recordCreated records the ID of a newly created remote object in the journal attached to the context, if any
+func recordCreated(ctx context.Context, resolvedEntity entities.ResolvedEntity) {
+	j := getJournalFromContext(ctx)
+	if j == nil {
+		return
+	}
+
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	s, found := j.snapshots[resolvedEntity.Coordinate]
+	if !found || s.existed {
+		return
+	}
+	if id, ok := resolvedEntity.Properties[config.IdParameter].(string); ok && !setting.UsesNumericID(s.config) {
+		s.createdID = id
+	}
+}
+
+// rollback restores the snapshots of all remote objects that were updated, and deletes all remote objects that were
+// created during the deployment of an environment. Configs are rolled back in reverse topological order, so that no
+// object is deleted while others still depend on it.
+func rollback(ctx context.Context, components []graph.SortedComponent, clientset *client.ClientSet, j *journal) error {
+	log.WithCtxFields(ctx).Info("Rolling back %d deployed configuration(s)...", len(j.snapshots))
+
+	var errs []error
+	for i := len(components) - 1; i >= 0; i-- {
+		nodes := components[i].SortedNodes
+		for k := len(nodes) - 1; k >= 0; k-- {
+			c := nodes[k].(graph.ConfigNode).Config
+			s, found := j.snapshots[c.Coordinate]
+			if !found {
+				continue
+			}
+
+			ctx := context.WithValue(ctx, log.CtxKeyCoord{}, c.Coordinate)
+			if err := rollbackConfig(ctx, clientset, s); err != nil {
+				log.WithCtxFields(ctx).WithFields(field.Error(err)).Error("Failed to roll back config: %v", err)
+				errs = append(errs, fmt.Errorf("failed to roll back config %q: %w", c.Coordinate, err))
+			}
+		}
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("rollback failed for %d configuration(s): %w", len(errs), errors.Join(errs...))
+	}
+	log.WithCtxFields(ctx).Info("Rollback finished without errors")
+	return nil
+}
+
+func rollbackConfig(ctx context.Context, clientset *client.ClientSet, s *snapshot) error {
+	if s.existed {
+		log.WithCtxFields(ctx).Debug("Restoring remote object %q", s.object.ID)
+		return restore(ctx, clientset, s)
+	}
+
+	if !canBeDeleted(s.config.Coordinate.Type) {
+		log.WithCtxFields(ctx).Warn("Created remote object of type %q can't be deleted", s.config.Coordinate.Type)
+		return nil
+	}
+
+	log.WithCtxFields(ctx).Debug("Deleting created remote object")
+	dp := pointer.DeletePointer{
+		Project:        s.config.Coordinate.Project,
+		Type:           s.config.Coordinate.Type,
+		Identifier:     s.config.Coordinate.ConfigId,
+		OriginObjectId: s.createdID,
+	}
+	if _, ok := s.config.Type.(config.ClassicApiType); ok {
+		// classic objects are identified by name if their ID is unknown
+		if name, err := extract.ConfigName(s.config, s.properties); err == nil {
+			dp.Identifier = name
+		}
+		dp.Scope, _ = s.properties[config.ScopeParameter].(string)
+	}
+
+	if err := delete.Configs(ctx, *clientset, delete.DeleteEntries{dp.Type: {dp}}); err != nil {
+		return err
+	}
+	state.GetStateFromContext(ctx).Remove(s.config.Coordinate)
+	return nil
+}
+
+// restore writes the snapshot back to the remote object it was taken from, identified by its ID, so that neither the
+// name nor the external ID of the object are used to find it again.
+func restore(ctx context.Context, clientset *client.ClientSet, s *snapshot) error {
+	payload, err := stripServerManagedFields(s.config.Type, s.object.Payload)
+	if err != nil {
+		return fmt.Errorf("failed to prepare snapshot of remote object %q: %w", s.object.ID, err)
+	}
+
+	if t, ok := s.config.Type.(config.ClassicApiType); ok {
+		a, err := remote.ClassicAPI(t, s.properties)
+		if err != nil {
+			return err
+		}
+		name, err := extract.ConfigName(s.config, s.properties)
+		if err != nil {
+			return err
+		}
+		_, err = clientset.ConfigClient.UpdateByID(ctx, a, s.object.ID, name, payload)
+		return err
+	}
+
+	// all other deployers update the object with the origin object ID in place
+	c := *s.config
+	c.OriginObjectId = s.object.ID
+	_, err = writeConfig(ctx, &c, clientset, maps.Clone(s.properties), string(payload))
+	return err
+}
+
+// serverManagedFields are the fields the GET responses of the APIs add to the payload of an object. They are either
+// rejected or ignored when sent back, and are managed by the clients where needed (e.g. optimistic locking versions).
+var serverManagedFields = []string{"id", "uid", "metadata", "version", "modificationInfo"}
+
+// stripServerManagedFields removes the serverManagedFields from the top level of the payload of a remote object.
+// Settings objects only contain their value and OpenPipeline objects are identified by their ID, so their payloads
+// are returned unchanged.
+func stripServerManagedFields(t config.Type, payload []byte) ([]byte, error) {
+	switch t.(type) {
+	case config.SettingsType, config.OpenPipelineType:
+		return payload, nil
+	}
+
+	var m map[string]any
+	if err := json.Unmarshal(payload, &m); err != nil {
+		return nil, err
+	}
+	maps.DeleteFunc(m, func(k string, _ any) bool { return slices.Contains(serverManagedFields, k) })
+	return json.Marshal(m)
+}
@@ -0,0 +1,165 @@
+//go:build unit
+
+/*
+ * @license
+ * Copyright 2025 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package deploy_test
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/cmd/monaco/dynatrace"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/idutils"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/api"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/client"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/client/dtclient"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/coordinate"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter/value"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/template"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy"
+	project "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/project/v2"
+)
+
+func TestDeploy_RollbackOnFailure(t *testing.T) {
+	updated := newPlanTestSetting("updated", `{"name": "new"}`, false)
+	created := newPlanTestSetting("created", `{"name": "created"}`, false)
+	failing := newPlanTestSetting("failing", `{"name": "failing"}`, false)
+
+	projects := []project.Project{
+		{
+			Id: "proj",
+			Configs: project.ConfigsPerTypePerEnvironments{
+				"env": project.ConfigsPerType{
+					"builtin:test": []config.Config{updated, created, failing},
+				},
+			},
+		},
+	}
+
+	externalID := func(c config.Config) string {
+		id, err := idutils.GenerateExternalIDForSettingsObject(c.Coordinate)
+		require.NoError(t, err)
+		return id
+	}
+	deletable := &dtclient.SettingsResourceContext{Operations: []string{dtclient.DeleteOperation}}
+
+	var mu sync.Mutex
+	remoteObjects := []dtclient.DownloadSettingsObject{
+		{ExternalId: externalID(updated), ObjectId: "updated-id", Value: []byte(`{"name": "old"}`), ResourceContext: deletable},
+	}
+
+	c := client.NewMockSettingsClient(gomock.NewController(t))
+	c.EXPECT().Cache(gomock.Any(), gomock.Any()).AnyTimes()
+	c.EXPECT().List(gomock.Any(), "builtin:test", gomock.Any()).AnyTimes().DoAndReturn(
+		func(_ any, _ string, opts dtclient.ListSettingsOptions) ([]dtclient.DownloadSettingsObject, error) {
+			mu.Lock()
+			defer mu.Unlock()
+			var result []dtclient.DownloadSettingsObject
+			for _, o := range remoteObjects {
+				if opts.Filter(o) {
+					result = append(result, o)
+				}
+			}
+			return result, nil
+		})
+
+	// deployment
+	c.EXPECT().Upsert(gomock.Any(), gomock.Any(), gomock.Any()).Times(3).DoAndReturn(
+		func(_ any, obj dtclient.SettingsObject, _ dtclient.UpsertSettingsOptions) (dtclient.DynatraceEntity, error) {
+			switch obj.Coordinate {
+			case updated.Coordinate:
+				return dtclient.DynatraceEntity{Id: "updated-id"}, nil
+			case created.Coordinate:
+				mu.Lock()
+				defer mu.Unlock()
+				remoteObjects = append(remoteObjects, dtclient.DownloadSettingsObject{ExternalId: externalID(created), ObjectId: "created-id", ResourceContext: deletable})
+				return dtclient.DynatraceEntity{Id: "created-id"}, nil
+			default:
+				return dtclient.DynatraceEntity{}, fmt.Errorf("upsert failed")
+			}
+		})
+
+	// rollback
+	c.EXPECT().Upsert(gomock.Any(), gomock.Any(), gomock.Any()).Times(1).DoAndReturn(
+		func(_ any, obj dtclient.SettingsObject, _ dtclient.UpsertSettingsOptions) (dtclient.DynatraceEntity, error) {
+			assert.Equal(t, updated.Coordinate, obj.Coordinate)
+			assert.Equal(t, "updated-id", obj.OriginObjectId)
+			assert.JSONEq(t, `{"name": "old"}`, string(obj.Content))
+			return dtclient.DynatraceEntity{Id: "updated-id"}, nil
+		})
+	c.EXPECT().Delete(gomock.Any(), "created-id").Times(1).Return(nil)
+
+	clients := dynatrace.EnvironmentClients{
+		dynatrace.EnvironmentInfo{Name: "env"}: &client.ClientSet{SettingsClient: c},
+	}
+
+	err := deploy.Deploy(t.Context(), projects, clients, deploy.DeployConfigsOptions{RollbackOnFailure: true, ContinueOnErr: true})
+	assert.Error(t, err)
+}
+
+func TestDeploy_RollbackRestoresClassicConfigByIDWithoutServerManagedFields(t *testing.T) {
+	theAPI := api.NewAPIs()["management-zone"]
+	updated := config.Config{
+		Template:    template.NewInMemoryTemplate("mz", `{"name": "{{ .name }}", "rules": ["new"]}`),
+		Coordinate:  coordinate.Coordinate{Project: "proj", Type: theAPI.ID, ConfigId: "mz"},
+		Type:        config.ClassicApiType{Api: theAPI.ID},
+		Environment: "env",
+		Parameters:  config.Parameters{config.NameParameter: &value.ValueParameter{Value: "mz"}},
+	}
+	failing := newPlanTestSetting("failing", `{"name": "failing"}`, false)
+
+	projects := []project.Project{
+		{
+			Id: "proj",
+			Configs: project.ConfigsPerTypePerEnvironments{
+				"env": project.ConfigsPerType{
+					theAPI.ID:      []config.Config{updated},
+					"builtin:test": []config.Config{failing},
+				},
+			},
+		},
+	}
+
+	cc := client.NewMockConfigClient(gomock.NewController(t))
+	cc.EXPECT().Cache(gomock.Any(), gomock.Any()).AnyTimes()
+	cc.EXPECT().ExistsWithName(gomock.Any(), gomock.Any(), "mz").AnyTimes().Return(true, "mz-id", nil)
+	cc.EXPECT().Get(gomock.Any(), gomock.Any(), "mz-id").Times(1).Return([]byte(`{"id": "mz-id", "metadata": {"configurationVersions": [7]}, "name": "mz", "rules": ["old"]}`), nil)
+	cc.EXPECT().UpsertByName(gomock.Any(), gomock.Any(), "mz", gomock.Any()).Times(1).Return(dtclient.DynatraceEntity{Id: "mz-id", Name: "mz"}, nil)
+	cc.EXPECT().UpdateByID(gomock.Any(), gomock.Any(), "mz-id", "mz", gomock.Any()).Times(1).DoAndReturn(
+		func(_ any, _ api.API, _ string, _ string, payload []byte) (dtclient.DynatraceEntity, error) {
+			assert.JSONEq(t, `{"name": "mz", "rules": ["old"]}`, string(payload))
+			return dtclient.DynatraceEntity{Id: "mz-id", Name: "mz"}, nil
+		})
+
+	sc := client.NewMockSettingsClient(gomock.NewController(t))
+	sc.EXPECT().Cache(gomock.Any(), gomock.Any()).AnyTimes()
+	sc.EXPECT().List(gomock.Any(), "builtin:test", gomock.Any()).AnyTimes().Return(nil, nil)
+	sc.EXPECT().Upsert(gomock.Any(), gomock.Any(), gomock.Any()).Times(1).Return(dtclient.DynatraceEntity{}, fmt.Errorf("upsert failed"))
+
+	clients := dynatrace.EnvironmentClients{
+		dynatrace.EnvironmentInfo{Name: "env"}: &client.ClientSet{ConfigClient: cc, SettingsClient: sc},
+	}
+
+	err := deploy.Deploy(t.Context(), projects, clients, deploy.DeployConfigsOptions{RollbackOnFailure: true, ContinueOnErr: true})
+	assert.Error(t, err)
+}
@@ -0,0 +1,162 @@
+/*
+ * @license
+ * Copyright 2025 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package deploy
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os/exec"
+	"runtime"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/cmd/monaco/dynatrace"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/log"
+)
+
+// Stage is a set of environments that are deployed together in a staged rollout
+type Stage struct {
+	// Name of the stage, used for logging
+	Name string
+	// Environments are the names of all environments of the stage
+	Environments []string
+	// Gates must all pass before the environments of the stage are deployed
+	Gates []Gate
+}
+
+// Gate is waited for before the environments of a Stage are deployed
+type Gate interface {
+	// Wait blocks until the gate passed. An error is returned if it did not pass.
+	Wait(ctx context.Context, stage string) error
+}
+
+// DelayGate passes after the given delay
+type DelayGate struct {
+	Delay time.Duration
+}
+
+func (g DelayGate) Wait(ctx context.Context, stage string) error {
+	log.WithCtxFields(ctx).Info("Waiting %v before deploying stage %q...", g.Delay, stage)
+	select {
+	case <-time.After(g.Delay):
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// CommandGate passes if the given shell command exits with code 0
+type CommandGate struct {
+	Command string
+}
+
+func (g CommandGate) Wait(ctx context.Context, stage string) error {
+	log.WithCtxFields(ctx).Info("Running gate command %q before deploying stage %q...", g.Command, stage)
+
+	out, err := shellCommand(ctx, g.Command).CombinedOutput()
+	log.WithCtxFields(ctx).Debug("Output of gate command %q: %s", g.Command, out)
+	if err != nil {
+		return fmt.Errorf("gate command %q failed: %w", g.Command, err)
+	}
+	return nil
+}
+
+// shellCommand returns a command running the given command line in the shell of the operating system
+func shellCommand(ctx context.Context, command string) *exec.Cmd {
+	if runtime.GOOS == "windows" {
+		return exec.CommandContext(ctx, "cmd", "/C", command)
+	}
+	return exec.CommandContext(ctx, "sh", "-c", command)
+}
+
+// ConfirmationGate passes if the user confirms the deployment of the stage
+type ConfirmationGate struct {
+	// In is read for the user's answer
+	In io.Reader
+	// Out is written the question to
+	Out io.Writer
+}
+
+func (g ConfirmationGate) Wait(_ context.Context, stage string) error {
+	if _, err := fmt.Fprintf(g.Out, "Continue with the deployment of stage %q? [y/N]: ", stage); err != nil {
+		return err
+	}
+
+	answer, err := bufio.NewReader(g.In).ReadString('\n')
+	if err != nil && !errors.Is(err, io.EOF) {
+		return fmt.Errorf("failed to read confirmation: %w", err)
+	}
+
+	switch strings.ToLower(strings.TrimSpace(answer)) {
+	case "y", "yes":
+		return nil
+	default:
+		return fmt.Errorf("deployment of stage %q was not confirmed", stage)
+	}
+}
+
+// rolloutStage is a Stage with the information of all of its environments
+type rolloutStage struct {
+	Stage
+	environments []dynatrace.EnvironmentInfo
+}
+
+// getRolloutStages returns the environments of the given clients ordered by the given stages. If no stages are given,
+// all environments are deployed in a single stage. Within a stage, environments are ordered by name. Stages without
+// any of the environments (e.g. as they were filtered out) are dropped, so that their gates are not waited for.
+func getRolloutStages(environmentClients dynatrace.EnvironmentClients, stages []Stage) ([]rolloutStage, error) {
+	envs := sortedEnvironments(environmentClients)
+	if len(stages) == 0 {
+		return []rolloutStage{{environments: envs}}, nil
+	}
+
+	result := make([]rolloutStage, 0, len(stages))
+	assigned := map[string]struct{}{}
+	for _, s := range stages {
+		rs := rolloutStage{Stage: s}
+		for _, env := range envs {
+			if slices.Contains(s.Environments, env.Name) {
+				rs.environments = append(rs.environments, env)
+				assigned[env.Name] = struct{}{}
+			}
+		}
+		if len(rs.environments) > 0 {
+			result = append(result, rs)
+		}
+	}
+
+	for _, env := range envs {
+		if _, found := assigned[env.Name]; !found {
+			return nil, fmt.Errorf("environment %q is not part of any rollout stage", env.Name)
+		}
+	}
+	return result, nil
+}
+
+// sortedEnvironments returns the environments of the given clients ordered by name
+func sortedEnvironments(environmentClients dynatrace.EnvironmentClients) []dynatrace.EnvironmentInfo {
+	envs := make([]dynatrace.EnvironmentInfo, 0, len(environmentClients))
+	for env := range environmentClients {
+		envs = append(envs, env)
+	}
+	slices.SortFunc(envs, func(a, b dynatrace.EnvironmentInfo) int { return strings.Compare(a.Name, b.Name) })
+	return envs
+}
@@ -0,0 +1,266 @@
+//go:build unit
+
+/*
+ * @license
+ * Copyright 2025 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package deploy_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/cmd/monaco/dynatrace"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/client"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/client/dtclient"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy"
+	deployErrors "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy/errors"
+	project "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/project/v2"
+)
+
+type recordingGate struct {
+	calls *[]string
+	err   error
+}
+
+func (g recordingGate) Wait(_ context.Context, stage string) error {
+	*g.calls = append(*g.calls, "gate "+stage)
+	return g.err
+}
+
+func newRolloutTestProjects(envs ...string) []project.Project {
+	configs := project.ConfigsPerTypePerEnvironments{}
+	for _, env := range envs {
+		c := newPlanTestSetting("setting", `{}`, false)
+		c.Environment = env
+		configs[env] = project.ConfigsPerType{"builtin:test": []config.Config{c}}
+	}
+	return []project.Project{{Id: "proj", Configs: configs}}
+}
+
+// newRolloutTestClient returns a client set whose deployments are recorded in calls, and fail if err is set
+func newRolloutTestClient(t *testing.T, env string, calls *[]string, err error) *client.ClientSet {
+	c := client.NewMockSettingsClient(gomock.NewController(t))
+	c.EXPECT().Cache(gomock.Any(), gomock.Any()).AnyTimes()
+	c.EXPECT().Upsert(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes().DoAndReturn(
+		func(_ any, _ dtclient.SettingsObject, _ dtclient.UpsertSettingsOptions) (dtclient.DynatraceEntity, error) {
+			*calls = append(*calls, "deploy "+env)
+			return dtclient.DynatraceEntity{Id: env + "-id"}, err
+		})
+	return &client.ClientSet{SettingsClient: c}
+}
+
+func TestDeploy_Stages(t *testing.T) {
+	t.Run("stages are deployed in order after their gates passed", func(t *testing.T) {
+		var calls []string
+		clients := dynatrace.EnvironmentClients{
+			dynatrace.EnvironmentInfo{Name: "prod"}:    newRolloutTestClient(t, "prod", &calls, nil),
+			dynatrace.EnvironmentInfo{Name: "staging"}: newRolloutTestClient(t, "staging", &calls, nil),
+			dynatrace.EnvironmentInfo{Name: "dev"}:     newRolloutTestClient(t, "dev", &calls, nil),
+		}
+		stages := []deploy.Stage{
+			{Name: "first", Environments: []string{"staging", "dev"}},
+			{Name: "second", Environments: []string{"prod"}, Gates: []deploy.Gate{recordingGate{calls: &calls}}},
+		}
+
+		err := deploy.Deploy(t.Context(), newRolloutTestProjects("dev", "staging", "prod"), clients, deploy.DeployConfigsOptions{Stages: stages})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"deploy dev", "deploy staging", "gate second", "deploy prod"}, calls)
+	})
+
+	t.Run("deployment stops after the first stage with errors", func(t *testing.T) {
+		var calls []string
+		clients := dynatrace.EnvironmentClients{
+			dynatrace.EnvironmentInfo{Name: "dev"}:  newRolloutTestClient(t, "dev", &calls, fmt.Errorf("failed")),
+			dynatrace.EnvironmentInfo{Name: "prod"}: newRolloutTestClient(t, "prod", &calls, nil),
+		}
+		stages := []deploy.Stage{
+			{Name: "first", Environments: []string{"dev"}},
+			{Name: "second", Environments: []string{"prod"}},
+		}
+
+		err := deploy.Deploy(t.Context(), newRolloutTestProjects("dev", "prod"), clients, deploy.DeployConfigsOptions{Stages: stages, ContinueOnErr: true})
+		assert.Error(t, err)
+		assert.Equal(t, []string{"deploy dev"}, calls)
+	})
+
+	t.Run("deployment stops if a gate does not pass", func(t *testing.T) {
+		var calls []string
+		clients := dynatrace.EnvironmentClients{
+			dynatrace.EnvironmentInfo{Name: "dev"}:  newRolloutTestClient(t, "dev", &calls, nil),
+			dynatrace.EnvironmentInfo{Name: "prod"}: newRolloutTestClient(t, "prod", &calls, nil),
+		}
+		stages := []deploy.Stage{
+			{Name: "first", Environments: []string{"dev"}},
+			{Name: "second", Environments: []string{"prod"}, Gates: []deploy.Gate{recordingGate{calls: &calls, err: errors.New("closed")}}},
+		}
+
+		err := deploy.Deploy(t.Context(), newRolloutTestProjects("dev", "prod"), clients, deploy.DeployConfigsOptions{Stages: stages})
+		assert.ErrorContains(t, err, `gate of stage "second" did not pass`)
+		assert.Equal(t, []string{"deploy dev", "gate second"}, calls)
+	})
+
+	t.Run("gates of stages without environments are not waited for", func(t *testing.T) {
+		var calls []string
+		clients := dynatrace.EnvironmentClients{
+			dynatrace.EnvironmentInfo{Name: "prod"}: newRolloutTestClient(t, "prod", &calls, nil),
+		}
+		stages := []deploy.Stage{
+			{Name: "first", Environments: []string{"dev"}, Gates: []deploy.Gate{recordingGate{calls: &calls}}},
+			{Name: "second", Environments: []string{"prod"}, Gates: []deploy.Gate{recordingGate{calls: &calls}}},
+		}
+
+		err := deploy.Deploy(t.Context(), newRolloutTestProjects("prod"), clients, deploy.DeployConfigsOptions{Stages: stages})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"gate second", "deploy prod"}, calls)
+	})
+
+	t.Run("gates are not waited for in dry-run", func(t *testing.T) {
+		var calls []string
+		clients := dynatrace.EnvironmentClients{
+			dynatrace.EnvironmentInfo{Name: "dev"}: &client.ClientSet{SettingsClient: &dtclient.DummySettingsClient{}},
+		}
+		stages := []deploy.Stage{
+			{Name: "first", Environments: []string{"dev"}, Gates: []deploy.Gate{recordingGate{calls: &calls}}},
+		}
+
+		err := deploy.Deploy(t.Context(), newRolloutTestProjects("dev"), clients, deploy.DeployConfigsOptions{Stages: stages, DryRun: true})
+		require.NoError(t, err)
+		assert.Empty(t, calls)
+	})
+
+	t.Run("environments must be part of a stage", func(t *testing.T) {
+		var calls []string
+		clients := dynatrace.EnvironmentClients{
+			dynatrace.EnvironmentInfo{Name: "dev"}:  newRolloutTestClient(t, "dev", &calls, nil),
+			dynatrace.EnvironmentInfo{Name: "prod"}: newRolloutTestClient(t, "prod", &calls, nil),
+		}
+		stages := []deploy.Stage{{Name: "first", Environments: []string{"dev"}}}
+
+		err := deploy.Deploy(t.Context(), newRolloutTestProjects("dev", "prod"), clients, deploy.DeployConfigsOptions{Stages: stages})
+		assert.ErrorContains(t, err, `environment "prod" is not part of any rollout stage`)
+		assert.Empty(t, calls)
+	})
+}
+
+func TestGates(t *testing.T) {
+	t.Run("delay", func(t *testing.T) {
+		assert.NoError(t, deploy.DelayGate{Delay: time.Millisecond}.Wait(t.Context(), "stage"))
+
+		ctx, cancel := context.WithCancel(t.Context())
+		cancel()
+		assert.Error(t, deploy.DelayGate{Delay: time.Hour}.Wait(ctx, "stage"))
+	})
+
+	t.Run("command", func(t *testing.T) {
+		assert.NoError(t, deploy.CommandGate{Command: "exit 0"}.Wait(t.Context(), "stage"))
+		assert.Error(t, deploy.CommandGate{Command: "exit 1"}.Wait(t.Context(), "stage"))
+	})
+
+	t.Run("confirmation", func(t *testing.T) {
+		var out bytes.Buffer
+		assert.NoError(t, deploy.ConfirmationGate{In: strings.NewReader("y\n"), Out: &out}.Wait(t.Context(), "stage"))
+		assert.Contains(t, out.String(), `"stage"`)
+
+		assert.Error(t, deploy.ConfirmationGate{In: strings.NewReader("n\n"), Out: &out}.Wait(t.Context(), "stage"))
+		assert.Error(t, deploy.ConfirmationGate{In: strings.NewReader(""), Out: &out}.Wait(t.Context(), "stage"))
+	})
+}
+
+func TestDeploy_ParallelEnvironments(t *testing.T) {
+	t.Run("environments are deployed concurrently up to the given limit", func(t *testing.T) {
+		var mu sync.Mutex
+		running, maxRunning := 0, 0
+
+		clients := dynatrace.EnvironmentClients{}
+		for _, env := range []string{"env1", "env2", "env3", "env4"} {
+			c := client.NewMockSettingsClient(gomock.NewController(t))
+			c.EXPECT().Cache(gomock.Any(), gomock.Any()).AnyTimes()
+			c.EXPECT().Upsert(gomock.Any(), gomock.Any(), gomock.Any()).Times(1).DoAndReturn(
+				func(_ any, _ dtclient.SettingsObject, _ dtclient.UpsertSettingsOptions) (dtclient.DynatraceEntity, error) {
+					mu.Lock()
+					running++
+					maxRunning = max(maxRunning, running)
+					mu.Unlock()
+
+					time.Sleep(50 * time.Millisecond)
+
+					mu.Lock()
+					running--
+					mu.Unlock()
+					return dtclient.DynatraceEntity{Id: env + "-id"}, nil
+				})
+			clients[dynatrace.EnvironmentInfo{Name: env}] = &client.ClientSet{SettingsClient: c}
+		}
+
+		err := deploy.Deploy(t.Context(), newRolloutTestProjects("env1", "env2", "env3", "env4"), clients, deploy.DeployConfigsOptions{ParallelEnvironments: 2})
+		require.NoError(t, err)
+		assert.Equal(t, 2, maxRunning)
+	})
+
+	t.Run("errors of all environments are returned", func(t *testing.T) {
+		var mu sync.Mutex
+		var calls []string
+		clients := dynatrace.EnvironmentClients{}
+		for _, env := range []string{"env1", "env2", "env3"} {
+			c := client.NewMockSettingsClient(gomock.NewController(t))
+			c.EXPECT().Cache(gomock.Any(), gomock.Any()).AnyTimes()
+			c.EXPECT().Upsert(gomock.Any(), gomock.Any(), gomock.Any()).Times(1).DoAndReturn(
+				func(_ any, _ dtclient.SettingsObject, _ dtclient.UpsertSettingsOptions) (dtclient.DynatraceEntity, error) {
+					mu.Lock()
+					defer mu.Unlock()
+					calls = append(calls, env)
+					if env == "env2" {
+						return dtclient.DynatraceEntity{Id: env + "-id"}, nil
+					}
+					return dtclient.DynatraceEntity{}, fmt.Errorf("failed")
+				})
+			clients[dynatrace.EnvironmentInfo{Name: env}] = &client.ClientSet{SettingsClient: c}
+		}
+
+		err := deploy.Deploy(t.Context(), newRolloutTestProjects("env1", "env2", "env3"), clients, deploy.DeployConfigsOptions{ParallelEnvironments: 3, ContinueOnErr: true})
+
+		var envErrs deployErrors.EnvironmentDeploymentErrors
+		require.ErrorAs(t, err, &envErrs)
+		assert.Len(t, envErrs, 2)
+		assert.Contains(t, envErrs, "env1")
+		assert.Contains(t, envErrs, "env3")
+		assert.ElementsMatch(t, []string{"env1", "env2", "env3"}, calls)
+	})
+
+	t.Run("no further environments are started after a failure", func(t *testing.T) {
+		var calls []string
+		clients := dynatrace.EnvironmentClients{
+			dynatrace.EnvironmentInfo{Name: "env1"}: newRolloutTestClient(t, "env1", &calls, fmt.Errorf("failed")),
+			dynatrace.EnvironmentInfo{Name: "env2"}: newRolloutTestClient(t, "env2", &calls, nil),
+			dynatrace.EnvironmentInfo{Name: "env3"}: newRolloutTestClient(t, "env3", &calls, nil),
+		}
+
+		err := deploy.Deploy(t.Context(), newRolloutTestProjects("env1", "env2", "env3"), clients, deploy.DeployConfigsOptions{ParallelEnvironments: 1})
+		assert.Error(t, err)
+		assert.Equal(t, []string{"deploy env1"}, calls)
+	})
+}
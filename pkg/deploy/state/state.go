@@ -0,0 +1,231 @@
+/*
+ * @license
+ * Copyright 2025 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package state implements the local deployment state of an environment. The state records the remote object each
+// config was deployed to, and allows finding that object again even if e.g. its name was changed on the environment.
+package state
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/spf13/afero"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/coordinate"
+)
+
+// Entry is the deployment state of a single config
+type Entry struct {
+	// Coordinate of the deployed config
+	Coordinate coordinate.Coordinate `json:"coordinate"`
+	// ID of the remote object the config was deployed to
+	ID string `json:"id"`
+	// Scope of the remote object, if the config has one (e.g. settings or sub-path classic APIs)
+	Scope string `json:"scope,omitempty"`
+	// Hash of the rendered payload that was deployed, see Hash
+	Hash string `json:"hash"`
+	// DeployedAt is the time of the last successful deployment
+	DeployedAt time.Time `json:"deployedAt"`
+}
+
+// State is the deployment state of a single environment. It is safe for concurrent use.
+// All methods can be called on a nil *State, which behaves like an empty state that does not record anything.
+type State struct {
+	environment string
+	mu          sync.RWMutex
+	entries     map[coordinate.Coordinate]Entry
+}
+
+type persistedState struct {
+	Environment string  `json:"environment"`
+	Configs     []Entry `json:"configs"`
+}
+
+// New returns an empty state for the given environment
+func New(environment string) *State {
+	return &State{
+		environment: environment,
+		entries:     map[coordinate.Coordinate]Entry{},
+	}
+}
+
+// Environment returns the name of the environment the state belongs to
+func (s *State) Environment() string {
+	if s == nil {
+		return ""
+	}
+	return s.environment
+}
+
+// Get returns the entry of the config with the given coordinate, if one is recorded
+func (s *State) Get(c coordinate.Coordinate) (Entry, bool) {
+	if s == nil {
+		return Entry{}, false
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	e, found := s.entries[c]
+	return e, found
+}
+
+// GetInScope works like Get, but only returns the entry if its object was deployed to the given scope. An entry of
+// another scope records the object of a previous definition of the config, which must not be updated in place of the
+// object in the config's current scope.
+func (s *State) GetInScope(c coordinate.Coordinate, scope string) (Entry, bool) {
+	e, found := s.Get(c)
+	if !found || e.Scope != scope {
+		return Entry{}, false
+	}
+	return e, true
+}
+
+// Put records the given entry, replacing any previous entry of the same coordinate
+func (s *State) Put(e Entry) {
+	if s == nil {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.entries[e.Coordinate] = e
+}
+
+// Remove removes the entry of the config with the given coordinate
+func (s *State) Remove(c coordinate.Coordinate) {
+	if s == nil {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.entries, c)
+}
+
+// Entries returns all recorded entries, sorted by their coordinate
+func (s *State) Entries() []Entry {
+	if s == nil {
+		return nil
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	entries := make([]Entry, 0, len(s.entries))
+	for _, e := range s.entries {
+		entries = append(entries, e)
+	}
+	slices.SortFunc(entries, func(a, b Entry) int { return strings.Compare(a.Coordinate.String(), b.Coordinate.String()) })
+	return entries
+}
+
+func (s *State) MarshalJSON() ([]byte, error) {
+	return json.Marshal(persistedState{Environment: s.Environment(), Configs: s.Entries()})
+}
+
+func (s *State) UnmarshalJSON(b []byte) error {
+	var p persistedState
+	if err := json.Unmarshal(b, &p); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.environment = p.Environment
+	s.entries = make(map[coordinate.Coordinate]Entry, len(p.Configs))
+	for _, e := range p.Configs {
+		s.entries[e.Coordinate] = e
+	}
+	return nil
+}
+
+// Hash returns the hash of a rendered config payload as it is recorded in an Entry
+func Hash(renderedConfig string) string {
+	h := sha256.Sum256([]byte(renderedConfig))
+	return hex.EncodeToString(h[:])
+}
+
+// FilePath returns the path of the state file of the given environment in the given directory
+func FilePath(dir string, environment string) string {
+	return filepath.Join(dir, environment+".json")
+}
+
+// Load reads the state of the given environment from its file in the given directory.
+// If no state file exists yet, an empty state is returned.
+func Load(fs afero.Fs, dir string, environment string) (*State, error) {
+	path := FilePath(dir, environment)
+
+	b, err := afero.ReadFile(fs, path)
+	if errors.Is(err, os.ErrNotExist) {
+		return New(environment), nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read state file %q: %w", path, err)
+	}
+
+	s := New(environment)
+	if err := json.Unmarshal(b, s); err != nil {
+		return nil, fmt.Errorf("failed to parse state file %q: %w", path, err)
+	}
+
+	if s.environment != environment {
+		return nil, fmt.Errorf("state file %q belongs to environment %q, not %q", path, s.environment, environment)
+	}
+	return s, nil
+}
+
+// Write writes the given state to its file in the given directory, creating the directory if needed
+func Write(fs afero.Fs, dir string, s *State) error {
+	if err := fs.MkdirAll(dir, 0777); err != nil {
+		return fmt.Errorf("failed to create state directory %q: %w", dir, err)
+	}
+
+	b, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to serialize state of environment %q: %w", s.Environment(), err)
+	}
+
+	path := FilePath(dir, s.Environment())
+	if err := afero.WriteFile(fs, path, b, 0644); err != nil {
+		return fmt.Errorf("failed to write state file %q: %w", path, err)
+	}
+	return nil
+}
+
+type ctxKeyState struct{}
+
+// NewContextWithState returns a new context with the given State attached
+func NewContextWithState(ctx context.Context, s *State) context.Context {
+	return context.WithValue(ctx, ctxKeyState{}, s)
+}
+
+// GetStateFromContext returns the State attached to the context, or nil if none is attached
+func GetStateFromContext(ctx context.Context) *State {
+	if s, ok := ctx.Value(ctxKeyState{}).(*State); ok {
+		return s
+	}
+	return nil
+}
@@ -0,0 +1,113 @@
+//go:build unit
+
+/*
+ * @license
+ * Copyright 2025 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package state_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/coordinate"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy/state"
+)
+
+func TestState_WriteAndLoad(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	deployedAt := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	s := state.New("dev")
+	s.Put(state.Entry{Coordinate: coordinate.Coordinate{Project: "p", Type: "t", ConfigId: "b"}, ID: "id-b", Hash: state.Hash("{}"), DeployedAt: deployedAt})
+	s.Put(state.Entry{Coordinate: coordinate.Coordinate{Project: "p", Type: "t", ConfigId: "a"}, ID: "id-a", Scope: "environment", Hash: state.Hash("{}"), DeployedAt: deployedAt})
+
+	require.NoError(t, state.Write(fs, "state", s))
+
+	exists, err := afero.Exists(fs, state.FilePath("state", "dev"))
+	require.NoError(t, err)
+	assert.True(t, exists)
+
+	loaded, err := state.Load(fs, "state", "dev")
+	require.NoError(t, err)
+	assert.Equal(t, "dev", loaded.Environment())
+	assert.Equal(t, s.Entries(), loaded.Entries())
+	assert.Equal(t, "id-a", loaded.Entries()[0].ID, "entries are sorted by coordinate")
+}
+
+func TestLoad_ReturnsEmptyStateIfFileDoesNotExist(t *testing.T) {
+	s, err := state.Load(afero.NewMemMapFs(), "state", "dev")
+	require.NoError(t, err)
+	assert.Equal(t, "dev", s.Environment())
+	assert.Empty(t, s.Entries())
+}
+
+func TestLoad_Errors(t *testing.T) {
+	t.Run("invalid JSON", func(t *testing.T) {
+		fs := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fs, state.FilePath("state", "dev"), []byte("{"), 0644))
+
+		_, err := state.Load(fs, "state", "dev")
+		assert.Error(t, err)
+	})
+
+	t.Run("state of other environment", func(t *testing.T) {
+		fs := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fs, state.FilePath("state", "dev"), []byte(`{"environment": "prod", "configs": []}`), 0644))
+
+		_, err := state.Load(fs, "state", "dev")
+		assert.Error(t, err)
+	})
+}
+
+func TestState_NilStateIsEmpty(t *testing.T) {
+	var s *state.State
+	s.Put(state.Entry{ID: "id"})
+
+	_, found := s.Get(coordinate.Coordinate{})
+	assert.False(t, found)
+	assert.Empty(t, s.Entries())
+	assert.Nil(t, state.GetStateFromContext(t.Context()))
+}
+
+func TestState_GetInScope(t *testing.T) {
+	c := coordinate.Coordinate{Project: "p", Type: "t", ConfigId: "a"}
+	s := state.New("dev")
+	s.Put(state.Entry{Coordinate: c, ID: "id", Scope: "HOST-1"})
+
+	e, found := s.GetInScope(c, "HOST-1")
+	assert.True(t, found)
+	assert.Equal(t, "id", e.ID)
+
+	_, found = s.GetInScope(c, "HOST-2")
+	assert.False(t, found, "entries of other scopes must be ignored")
+
+	_, found = s.GetInScope(c, "")
+	assert.False(t, found, "entries of other scopes must be ignored")
+}
+
+func TestState_Remove(t *testing.T) {
+	c := coordinate.Coordinate{Project: "p", Type: "t", ConfigId: "a"}
+	s := state.New("dev")
+	s.Put(state.Entry{Coordinate: c, ID: "id"})
+	s.Remove(c)
+
+	_, found := s.Get(c)
+	assert.False(t, found)
+}
@@ -0,0 +1,67 @@
+/*
+ * @license
+ * Copyright 2025 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package deploy
+
+import (
+	"context"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/api"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/client"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/entities"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy/internal/automation"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy/internal/bucket"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy/internal/classic"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy/internal/document"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy/internal/openpipeline"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy/internal/segment"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy/internal/setting"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy/internal/slo"
+)
+
+// init registers how configs of the built-in config types are deployed
+func init() {
+	for _, h := range []config.TypeHandler{
+		{ID: config.ClassicApiTypeID, Deploy: func(ctx context.Context, clients *client.ClientSet, properties parameter.Properties, renderedConfig string, c *config.Config) (entities.ResolvedEntity, error) {
+			return classic.Deploy(ctx, clients.ConfigClient, api.NewAPIs(), properties, renderedConfig, c)
+		}},
+		{ID: config.SettingsTypeID, Deploy: func(ctx context.Context, clients *client.ClientSet, properties parameter.Properties, renderedConfig string, c *config.Config) (entities.ResolvedEntity, error) {
+			return setting.Deploy(ctx, clients.SettingsClient, properties, renderedConfig, c)
+		}},
+		{ID: config.AutomationTypeID, Deploy: func(ctx context.Context, clients *client.ClientSet, properties parameter.Properties, renderedConfig string, c *config.Config) (entities.ResolvedEntity, error) {
+			return automation.Deploy(ctx, clients.AutClient, properties, renderedConfig, c)
+		}},
+		{ID: config.BucketTypeID, Deploy: func(ctx context.Context, clients *client.ClientSet, properties parameter.Properties, renderedConfig string, c *config.Config) (entities.ResolvedEntity, error) {
+			return bucket.Deploy(ctx, clients.BucketClient, properties, renderedConfig, c)
+		}},
+		{ID: config.DocumentTypeID, Deploy: func(ctx context.Context, clients *client.ClientSet, properties parameter.Properties, renderedConfig string, c *config.Config) (entities.ResolvedEntity, error) {
+			return document.Deploy(ctx, clients.DocumentClient, properties, renderedConfig, c)
+		}},
+		{ID: config.OpenPipelineTypeID, Deploy: func(ctx context.Context, clients *client.ClientSet, properties parameter.Properties, renderedConfig string, c *config.Config) (entities.ResolvedEntity, error) {
+			return openpipeline.Deploy(ctx, clients.OpenPipelineClient, properties, renderedConfig, c)
+		}},
+		{ID: config.SegmentID, Deploy: func(ctx context.Context, clients *client.ClientSet, properties parameter.Properties, renderedConfig string, c *config.Config) (entities.ResolvedEntity, error) {
+			return segment.Deploy(ctx, clients.SegmentClient, properties, renderedConfig, c)
+		}},
+		{ID: config.ServiceLevelObjectiveID, Deploy: func(ctx context.Context, clients *client.ClientSet, properties parameter.Properties, renderedConfig string, c *config.Config) (entities.ResolvedEntity, error) {
+			return slo.Deploy(ctx, clients.ServiceLevelObjectiveClient, properties, renderedConfig, c)
+		}},
+	} {
+		config.Types.Register(h)
+	}
+}
@@ -0,0 +1,175 @@
+/*
+ * @license
+ * Copyright 2025 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package deploy
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	jsonutils "github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/json"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/log"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/log/field"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/api"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/client"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/client/dtclient"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/entities"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy/internal/extract"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy/internal/remote"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy/internal/setting"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/report"
+)
+
+// getUnchangedEntity fetches the remote object of the given config and compares it with the rendered config.
+// If the remote object is equal, the resolved entity referencing the existing remote object is returned, and unchanged is true.
+// Any failure to fetch or compare the remote object is logged and treated as a change, so that the config is deployed as usual.
+func getUnchangedEntity(ctx context.Context, c *config.Config, clientset *client.ClientSet, apis api.APIs, properties parameter.Properties, renderedConfig string) (resolvedEntity entities.ResolvedEntity, unchanged bool) {
+	obj, found, err := remote.Get(ctx, clientset, properties, c)
+	if err != nil {
+		log.WithCtxFields(ctx).WithFields(field.Error(err)).Warn("Failed to fetch remote configuration for comparison, deploying it: %v", err)
+		return entities.ResolvedEntity{}, false
+	}
+	if !found {
+		return entities.ResolvedEntity{}, false
+	}
+
+	equal, err := isEqualToRemote(c, apis, renderedConfig, obj.Payload)
+	if err == nil && equal {
+		if t, ok := c.Type.(config.SettingsType); ok {
+			equal, err = isPlacedAsConfigured(ctx, clientset.SettingsClient, t, properties, obj)
+		}
+	}
+	if err != nil {
+		log.WithCtxFields(ctx).WithFields(field.Error(err)).Warn("Failed to compare with remote configuration, deploying it: %v", err)
+		return entities.ResolvedEntity{}, false
+	}
+	if !equal {
+		log.WithCtxFields(ctx).Debug("Remote configuration %q differs", obj.ID)
+		return entities.ResolvedEntity{}, false
+	}
+
+	id := obj.ID
+	if _, ok := c.Type.(config.SettingsType); ok {
+		if id, err = setting.EntityID(c, obj.ID); err != nil {
+			log.WithCtxFields(ctx).WithFields(field.Error(err)).Warn("Failed to determine ID of unchanged remote configuration, deploying it: %v", err)
+			return entities.ResolvedEntity{}, false
+		}
+	}
+
+	name, err := extract.ConfigName(c, properties)
+	if err != nil {
+		name = fmt.Sprintf("[UNKNOWN NAME]%s", obj.ID)
+	}
+
+	switch c.Type.(type) {
+	case config.SettingsType, config.ClassicApiType:
+		properties[config.NameParameter] = name
+	}
+	properties[config.IdParameter] = id
+
+	report.GetDetailerFromContextOrDiscard(ctx).Add(report.Detail{Type: report.DetailTypeInfo, Message: fmt.Sprintf("Remote object %q is equal to the rendered configuration", obj.ID)})
+
+	return entities.ResolvedEntity{
+		EntityName: name,
+		Coordinate: c.Coordinate,
+		Properties: properties,
+		Response:   obj.Payload,
+	}, true
+}
+
+// isEqualToRemote compares the rendered config with the payload of its remote object. As deployments replace the
+// whole remote object, they are only equal if neither contains values the other one lacks. The server-managed fields
+// of the remote object are not compared.
+// Classic API configs are normalised like the remote payload with the TweakResponseFunc of their API first. APIs that
+// define a CheckEqualFunc are compared with it, as their payloads are not expected to be equal as a whole.
+func isEqualToRemote(c *config.Config, apis api.APIs, renderedConfig string, payload []byte) (bool, error) {
+	rendered := []byte(renderedConfig)
+
+	if t, ok := c.Type.(config.ClassicApiType); ok {
+		a := apis[t.Api]
+		if a.TweakResponseFunc != nil || a.CheckEqualFunc != nil {
+			var renderedMap map[string]any
+			if err := json.Unmarshal(rendered, &renderedMap); err != nil {
+				return false, err
+			}
+			if a.TweakResponseFunc != nil {
+				a.TweakResponseFunc(renderedMap)
+			}
+
+			if a.CheckEqualFunc != nil {
+				var remoteMap map[string]any
+				if err := json.Unmarshal(payload, &remoteMap); err != nil {
+					return false, err
+				}
+				return a.CheckEqualFunc(remoteMap, renderedMap), nil
+			}
+
+			var err error
+			if rendered, err = json.Marshal(renderedMap); err != nil {
+				return false, err
+			}
+		}
+	}
+
+	payload, err := stripServerManagedFields(c.Type, payload)
+	if err != nil {
+		return false, err
+	}
+
+	missingRemotely, err := jsonutils.Diff(rendered, payload)
+	if err != nil {
+		return false, err
+	}
+	missingInRendered, err := jsonutils.Diff(payload, rendered)
+	if err != nil {
+		return false, err
+	}
+	return len(missingRemotely) == 0 && len(missingInRendered) == 0, nil
+}
+
+// isPlacedAsConfigured returns whether the remote settings object is in the scope of the config, and, if the config
+// defines an insertAfter, whether it directly follows the object it should be inserted after.
+func isPlacedAsConfigured(ctx context.Context, settingsClient client.SettingsClient, t config.SettingsType, properties parameter.Properties, obj remote.Object) (bool, error) {
+	scope, err := extract.Scope(properties)
+	if err != nil {
+		return false, err
+	}
+	if obj.Scope != scope {
+		return false, nil
+	}
+
+	insertAfter, _ := properties[config.InsertAfterParameter].(string)
+	if insertAfter == "" {
+		return true, nil
+	}
+
+	objects, err := settingsClient.List(ctx, t.SchemaId, dtclient.ListSettingsOptions{
+		DiscardValue: true,
+		Filter:       func(o dtclient.DownloadSettingsObject) bool { return o.Scope == scope },
+	})
+	if err != nil {
+		return false, err
+	}
+	for i, o := range objects {
+		if o.ObjectId == obj.ID {
+			return i > 0 && objects[i-1].ObjectId == insertAfter, nil
+		}
+	}
+	return false, nil
+}
@@ -0,0 +1,123 @@
+/*
+ * @license
+ * Copyright 2025 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package deploy
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+
+	jsonutils "github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/json"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/client"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy/internal/remote"
+)
+
+// verificationError is returned if the deployed object of a config doesn't pass the verification of the config
+type verificationError struct {
+	// failures describe all failed checks
+	failures []string
+}
+
+func (e verificationError) Error() string {
+	return fmt.Sprintf("verification of deployed object failed: %s", strings.Join(e.failures, "; "))
+}
+
+// verifyDeployment fetches the deployed object of the given config and checks it as defined by the verification of
+// the config. If any check fails, a verificationError is returned.
+func verifyDeployment(ctx context.Context, c *config.Config, clientset *client.ClientSet, properties parameter.Properties, renderedConfig string) error {
+	obj, found, err := remote.Get(ctx, clientset, properties, c)
+	if err != nil {
+		return verificationError{failures: []string{fmt.Sprintf("failed to fetch deployed object: %s", err)}}
+	}
+	if !found {
+		return verificationError{failures: []string{"deployed object not found"}}
+	}
+
+	var deployed any
+	if err := json.Unmarshal(obj.Payload, &deployed); err != nil {
+		return verificationError{failures: []string{fmt.Sprintf("failed to unmarshal deployed object: %s", err)}}
+	}
+
+	var failures []string
+	for _, e := range c.Verification.Expectations {
+		p, err := jsonutils.ParsePath(e.Path)
+		if err != nil {
+			failures = append(failures, err.Error())
+			continue
+		}
+
+		if v, found := p.Get(deployed); !found {
+			failures = append(failures, fmt.Sprintf("%s: value is missing", e.Path))
+		} else if !reflect.DeepEqual(v, e.Value) {
+			failures = append(failures, fmt.Sprintf("%s: expected %s, but got %s", e.Path, jsonutils.ToString(e.Value), jsonutils.ToString(v)))
+		}
+	}
+
+	if c.Verification.EqualsRendered {
+		diffFailures, err := compareWithRendered(c, properties, renderedConfig, obj.Payload)
+		if err != nil {
+			return verificationError{failures: []string{err.Error()}}
+		}
+		failures = append(failures, diffFailures...)
+	}
+
+	if len(failures) > 0 {
+		return verificationError{failures: failures}
+	}
+	return nil
+}
+
+// compareWithRendered returns a description of each value of the rendered config that differs in the deployed object,
+// except for values of the paths ignored by the verification of the config. Values rendered from secrets are masked.
+func compareWithRendered(c *config.Config, properties parameter.Properties, renderedConfig string, deployed []byte) ([]string, error) {
+	ignoredPaths := make([]jsonutils.Path, 0, len(c.Verification.Ignore))
+	for _, i := range c.Verification.Ignore {
+		p, err := jsonutils.ParsePath(i)
+		if err != nil {
+			return nil, err
+		}
+		ignoredPaths = append(ignoredPaths, p)
+	}
+
+	diffs, err := diffWithRemote(c, properties, renderedConfig, deployed)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compare deployed object with rendered configuration: %w", err)
+	}
+
+	var failures []string
+	for _, d := range diffs {
+		p, err := jsonutils.ParsePath(d.Path)
+		if err == nil && isIgnored(p, ignoredPaths) {
+			continue
+		}
+		failures = append(failures, fmt.Sprintf("%s: expected %s, but got %s", d.Path, jsonutils.ToString(d.Desired), jsonutils.ToString(d.Actual)))
+	}
+	return failures, nil
+}
+
+func isIgnored(p jsonutils.Path, ignoredPaths []jsonutils.Path) bool {
+	for _, i := range ignoredPaths {
+		if i.Contains(p) {
+			return true
+		}
+	}
+	return false
+}
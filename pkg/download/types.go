@@ -0,0 +1,68 @@
+/*
+ * @license
+ * Copyright 2025 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package download
+
+import (
+	"context"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/client"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/download/automation"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/download/bucket"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/download/classic"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/download/document"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/download/openpipeline"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/download/segment"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/download/settings"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/download/slo"
+)
+
+// init registers how configs of the built-in config types are downloaded
+func init() {
+	for _, h := range []config.TypeHandler{
+		{ID: config.ClassicApiTypeID, Download: func(ctx context.Context, clients *client.ClientSet, opts config.DownloadOptions) (map[string][]config.Config, error) {
+			return classic.Download(ctx, clients.ConfigClient, opts.ProjectName, opts.APIs, classic.ApiContentFilters)
+		}},
+		{ID: config.SettingsTypeID, Download: func(ctx context.Context, clients *client.ClientSet, opts config.DownloadOptions) (map[string][]config.Config, error) {
+			settingsTypes := make([]config.SettingsType, 0, len(opts.SchemaIDs))
+			for _, schemaID := range opts.SchemaIDs {
+				settingsTypes = append(settingsTypes, config.SettingsType{SchemaId: schemaID})
+			}
+			return settings.Download(ctx, clients.SettingsClient, opts.ProjectName, settings.DefaultSettingsFilters, settingsTypes...)
+		}},
+		{ID: config.AutomationTypeID, Download: func(ctx context.Context, clients *client.ClientSet, opts config.DownloadOptions) (map[string][]config.Config, error) {
+			return automation.Download(ctx, clients.AutClient, opts.ProjectName)
+		}},
+		{ID: config.BucketTypeID, Download: func(ctx context.Context, clients *client.ClientSet, opts config.DownloadOptions) (map[string][]config.Config, error) {
+			return bucket.Download(ctx, clients.BucketClient, opts.ProjectName)
+		}},
+		{ID: config.DocumentTypeID, Download: func(ctx context.Context, clients *client.ClientSet, opts config.DownloadOptions) (map[string][]config.Config, error) {
+			return document.Download(ctx, clients.DocumentClient, opts.ProjectName)
+		}},
+		{ID: config.OpenPipelineTypeID, Download: func(ctx context.Context, clients *client.ClientSet, opts config.DownloadOptions) (map[string][]config.Config, error) {
+			return openpipeline.Download(ctx, clients.OpenPipelineClient, opts.ProjectName)
+		}},
+		{ID: config.SegmentID, Download: func(ctx context.Context, clients *client.ClientSet, opts config.DownloadOptions) (map[string][]config.Config, error) {
+			return segment.Download(ctx, clients.SegmentClient, opts.ProjectName)
+		}},
+		{ID: config.ServiceLevelObjectiveID, Download: func(ctx context.Context, clients *client.ClientSet, opts config.DownloadOptions) (map[string][]config.Config, error) {
+			return slo.Download(ctx, clients.ServiceLevelObjectiveClient, opts.ProjectName)
+		}},
+	} {
+		config.Types.Register(h)
+	}
+}
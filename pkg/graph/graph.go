@@ -147,6 +147,64 @@ func (graphs ConfigGraphPerEnvironment) GetIndependentlySortedConfigs(environmen
 	return sortedComponents, nil
 }
 
+// GetDependencyClosure returns the given configs of the environment together with all configs they transitively depend
+// on. If includeDependents is set, all configs transitively depending on the given configs are returned as well,
+// together with their own dependencies. Coordinates of configs that are not part of the graph are ignored.
+func (graphs ConfigGraphPerEnvironment) GetDependencyClosure(environment string, coordinates []coordinate.Coordinate, includeDependents bool) (map[coordinate.Coordinate]struct{}, error) {
+	g, err := graphs.getGraphForEnvironment(environment)
+	if err != nil {
+		return nil, err
+	}
+
+	wanted := make(map[coordinate.Coordinate]struct{}, len(coordinates))
+	for _, c := range coordinates {
+		wanted[c] = struct{}{}
+	}
+
+	var start []graph.Node
+	nodes := g.Nodes()
+	for nodes.Next() {
+		if _, ok := wanted[nodes.Node().(ConfigNode).Config.Coordinate]; ok {
+			start = append(start, nodes.Node())
+		}
+	}
+
+	// edges point from a config to the configs depending on it
+	if includeDependents {
+		start = reachableNodes(start, g.From)
+	}
+	closure := reachableNodes(start, g.To)
+
+	result := make(map[coordinate.Coordinate]struct{}, len(closure))
+	for _, n := range closure {
+		result[n.(ConfigNode).Config.Coordinate] = struct{}{}
+	}
+	return result, nil
+}
+
+// reachableNodes returns the given nodes and all nodes transitively reachable from them using the given neighbors function
+func reachableNodes(start []graph.Node, neighbors func(id int64) graph.Nodes) []graph.Node {
+	visited := make(map[int64]struct{}, len(start))
+	var result []graph.Node
+
+	queue := append([]graph.Node{}, start...)
+	for len(queue) > 0 {
+		n := queue[0]
+		queue = queue[1:]
+		if _, seen := visited[n.ID()]; seen {
+			continue
+		}
+		visited[n.ID()] = struct{}{}
+		result = append(result, n)
+
+		next := neighbors(n.ID())
+		for next.Next() {
+			queue = append(queue, next.Node())
+		}
+	}
+	return result
+}
+
 func (graphs ConfigGraphPerEnvironment) getGraphForEnvironment(environment string) (*simple.DirectedGraph, error) {
 	g, ok := graphs[environment]
 	if !ok {
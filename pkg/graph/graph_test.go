@@ -533,3 +533,83 @@ func TestRoots(t *testing.T) {
 		})
 	}
 }
+
+func TestConfigGraphPerEnvironment_GetDependencyClosure(t *testing.T) {
+	env := "dev"
+	newConfig := func(id string, refs ...string) config.Config {
+		var references []parameter.ParameterReference
+		for _, r := range refs {
+			references = append(references, parameter.ParameterReference{Config: coordinate.Coordinate{Project: "p", Type: "t", ConfigId: r}, Property: "id"})
+		}
+		return config.Config{
+			Coordinate:  coordinate.Coordinate{Project: "p", Type: "t", ConfigId: id},
+			Environment: env,
+			Parameters:  map[string]parameter.Parameter{"ref": &parameter.DummyParameter{References: references}},
+		}
+	}
+	coord := func(id string) coordinate.Coordinate {
+		return coordinate.Coordinate{Project: "p", Type: "t", ConfigId: id}
+	}
+
+	// b depends on a, c and d depend on b, e depends on c and f, g is independent
+	projects := []project.Project{
+		{
+			Id: "p",
+			Configs: project.ConfigsPerTypePerEnvironments{
+				env: {
+					"t": []config.Config{
+						newConfig("a"), newConfig("b", "a"), newConfig("c", "b"), newConfig("d", "b"), newConfig("e", "c", "f"), newConfig("f"), newConfig("g"),
+					},
+				},
+			},
+		},
+	}
+	graphs := graph.New(projects, []string{env})
+
+	tests := []struct {
+		name              string
+		coordinates       []coordinate.Coordinate
+		includeDependents bool
+		want              []coordinate.Coordinate
+	}{
+		{
+			name:        "dependencies are included",
+			coordinates: []coordinate.Coordinate{coord("c")},
+			want:        []coordinate.Coordinate{coord("a"), coord("b"), coord("c")},
+		},
+		{
+			name:              "dependents and their dependencies are included",
+			coordinates:       []coordinate.Coordinate{coord("c")},
+			includeDependents: true,
+			want:              []coordinate.Coordinate{coord("a"), coord("b"), coord("c"), coord("e"), coord("f")},
+		},
+		{
+			name:              "all transitive dependents are included",
+			coordinates:       []coordinate.Coordinate{coord("a")},
+			includeDependents: true,
+			want:              []coordinate.Coordinate{coord("a"), coord("b"), coord("c"), coord("d"), coord("e"), coord("f")},
+		},
+		{
+			name:        "unknown configs are ignored",
+			coordinates: []coordinate.Coordinate{coord("g"), coord("unknown")},
+			want:        []coordinate.Coordinate{coord("g")},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := graphs.GetDependencyClosure(env, tt.coordinates, tt.includeDependents)
+			assert.NoError(t, err)
+
+			var gotCoordinates []coordinate.Coordinate
+			for c := range got {
+				gotCoordinates = append(gotCoordinates, c)
+			}
+			assert.ElementsMatch(t, tt.want, gotCoordinates)
+		})
+	}
+
+	t.Run("unknown environment", func(t *testing.T) {
+		_, err := graphs.GetDependencyClosure("unknown", []coordinate.Coordinate{coord("a")}, false)
+		assert.Error(t, err)
+	})
+}
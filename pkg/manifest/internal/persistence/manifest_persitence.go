@@ -28,6 +28,15 @@ type Project struct {
 	Name string `yaml:"name" json:"name" jsonschema:"required,description=The name of the project - if 'path' is not set the name will be used as path, otherwise this can be freely defined."`
 	Type string `yaml:"type,omitempty" json:"type" jsonschema:"enum=simple,enum=grouping,description=The type of project - either a 'simple' project folder containing configs, or a 'grouping' of projects in sub-folders."`
 	Path string `yaml:"path,omitempty" json:"path" jsonschema:"description=The file path to the project folder, relative to the manifest's location."`
+	// Hooks are run once around the deployment of the configs of the project to each environment
+	Hooks *Hooks `yaml:"hooks,omitempty" json:"hooks" jsonschema:"description=Local shell commands that are run around the deployment of each configuration of the project, before the hooks of the configuration itself."`
+}
+
+// Hooks define local shell commands that are run around a deployment
+type Hooks struct {
+	PreDeploy  string `yaml:"preDeploy,omitempty" json:"preDeploy" jsonschema:"description=A shell command that is run before deploying. If it fails, the deployment fails."`
+	PostDeploy string `yaml:"postDeploy,omitempty" json:"postDeploy" jsonschema:"description=A shell command that is run after a successful deployment."`
+	OnError    string `yaml:"onError,omitempty" json:"onError" jsonschema:"description=A shell command that is run after a failed deployment."`
 }
 
 type Type string
@@ -102,12 +111,30 @@ type Environment struct {
 	URL  TypedValue `yaml:"url" json:"url" jsonschema:"required,oneof_type=string;object,description=The URL of the environment."`
 
 	Auth Auth `yaml:"auth,omitempty" json:"auth" jsonschema:"required,description=This defines all information required for authenticated access to the environment's API."`
+
+	// Hooks are run around the deployment of the environment
+	Hooks *Hooks `yaml:"hooks,omitempty" json:"hooks" jsonschema:"description=Local shell commands that are run around the deployment of all configurations to the environment."`
+
+	// Limits restrict the load monaco puts on the environment
+	Limits *Limits `yaml:"limits,omitempty" json:"limits" jsonschema:"description=Limits for the load put on the environment. If not set, the limits defined by environment variables apply."`
+
+	// Variables can be used by configurations deployed to the environment
+	Variables map[string]interface{} `yaml:"variables,omitempty" json:"variables" jsonschema:"description=Variables that configurations can use with 'variable' parameters. They take precedence over variables of the group and the project."`
+}
+
+// Limits restrict the number of concurrent deployments and requests sent to an environment
+type Limits struct {
+	ConcurrentDeployments int     `yaml:"concurrentDeployments,omitempty" json:"concurrentDeployments" jsonschema:"minimum=0,description=The maximum number of configurations deployed to the environment concurrently. Takes precedence over MONACO_CONCURRENT_DEPLOYMENTS."`
+	RequestsPerSecond     float64 `yaml:"requestsPerSecond,omitempty" json:"requestsPerSecond" jsonschema:"minimum=0,description=The maximum number of requests per second sent to the environment."`
 }
 
 // Group defines a group of Environment
 type Group struct {
 	Name         string        `yaml:"name" json:"name" jsonschema:"required,description=The name of the group - this can be freely defined and will be used in logs, etc."`
 	Environments []Environment `yaml:"environments" json:"environments" jsonschema:"required,minItems=1,description=The environments that are part of this group."`
+
+	// Variables can be used by configurations deployed to the environments of the group
+	Variables map[string]interface{} `yaml:"variables,omitempty" json:"variables" jsonschema:"description=Variables that configurations can use with 'variable' parameters. They take precedence over variables of the project."`
 }
 
 type Manifest struct {
@@ -118,6 +145,45 @@ type Manifest struct {
 	EnvironmentGroups []Group `yaml:"environmentGroups" json:"environmentGroups" jsonschema:"minItems=1,description=A list of environment groups that configs in the defined 'projects' will be deployed to. Required when deploying environment configurations."`
 	// Accounts is a list of accounts that account resources in Projects will be deployed to
 	Accounts []Account `yaml:"accounts,omitempty" json:"accounts" jsonschema:"minItems=1,description=A list of of accounts that account resources defined in 'projects' will be deployed to. Required when deploying account resources."`
+	// Rollout optionally orders the EnvironmentGroups into stages that are deployed one after the other
+	Rollout *Rollout `yaml:"rollout,omitempty" json:"rollout" jsonschema:"description=Optionally orders the defined 'environmentGroups' into stages that are deployed one after the other."`
+	// Deployment optionally defines how configurations are retried and timed out when deploying them
+	Deployment *Deployment `yaml:"deployment,omitempty" json:"deployment" jsonschema:"description=Optionally defines how configurations are retried and timed out when deploying them."`
+}
+
+// Deployment defines the deployment policies of configurations
+type Deployment struct {
+	Default *DeploymentPolicy           `yaml:"default,omitempty" json:"default" jsonschema:"description=The deployment policy of all configurations."`
+	Types   map[string]DeploymentPolicy `yaml:"types,omitempty" json:"types" jsonschema:"description=Deployment policies by config type, e.g. a Settings 2.0 schema or a Config API like 'dashboard'. They take precedence over the default policy."`
+}
+
+// DeploymentPolicy defines how the deployment of a configuration is retried and timed out
+type DeploymentPolicy struct {
+	MaxRetries     *int   `yaml:"maxRetries,omitempty" json:"maxRetries" jsonschema:"minimum=0,description=The maximum number of retries of failed requests."`
+	Backoff        string `yaml:"backoff,omitempty" json:"backoff" jsonschema:"description=The duration to wait between retries, e.g. '5s'."`
+	RequestTimeout string `yaml:"requestTimeout,omitempty" json:"requestTimeout" jsonschema:"description=The maximum duration of each single request, e.g. '30s'."`
+	Deadline       string `yaml:"deadline,omitempty" json:"deadline" jsonschema:"description=The maximum duration of the whole deployment of a configuration including all retries, e.g. '5m'."`
+	MaxConcurrency int    `yaml:"maxConcurrency,omitempty" json:"maxConcurrency" jsonschema:"minimum=0,description=The maximum number of configurations of the type that are deployed concurrently to an environment. Only allowed for config types."`
+	Serial         bool   `yaml:"serial,omitempty" json:"serial" jsonschema:"description=Whether configurations of the type are deployed one after the other. This is the same as a 'maxConcurrency' of 1. Only allowed for config types."`
+}
+
+// Rollout defines the stages of a staged rollout
+type Rollout struct {
+	Stages []RolloutStage `yaml:"stages" json:"stages" jsonschema:"required,minItems=1,description=The stages of the rollout in the order they are deployed. Each environment group must be part of exactly one stage."`
+}
+
+// RolloutStage defines a set of environment groups that are deployed together
+type RolloutStage struct {
+	Name              string   `yaml:"name" json:"name" jsonschema:"required,description=The name of the stage - this can be freely defined and will be used in logs, etc."`
+	EnvironmentGroups []string `yaml:"environmentGroups" json:"environmentGroups" jsonschema:"required,minItems=1,description=The names of the environment groups that are part of this stage."`
+	Gate              *Gate    `yaml:"gate,omitempty" json:"gate" jsonschema:"description=Optionally defines conditions that must be met before this stage is deployed."`
+}
+
+// Gate defines conditions that must be met before a stage is deployed
+type Gate struct {
+	Delay   string `yaml:"delay,omitempty" json:"delay" jsonschema:"description=A duration to wait for before deploying the stage, e.g. '10m'."`
+	Command string `yaml:"command,omitempty" json:"command" jsonschema:"description=A shell command that must exit with code 0 before the stage is deployed."`
+	Confirm bool   `yaml:"confirm,omitempty" json:"confirm" jsonschema:"description=Whether the deployment of the stage must be confirmed interactively."`
 }
 
 type Account struct {
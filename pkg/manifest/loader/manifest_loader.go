@@ -33,6 +33,7 @@ import (
 	"path/filepath"
 	"slices"
 	"strings"
+	"time"
 )
 
 // Context holds all information for [Load]
@@ -194,6 +195,18 @@ func Load(context *Context) (manifest.Manifest, []error) {
 		errs = append(errs, newManifestLoaderError(context.ManifestPath, accErr.Error()))
 	}
 
+	// rollout
+	rollout, rolloutErrs := parseRollout(context, manifestYAML.Rollout, manifestYAML.EnvironmentGroups)
+	if rolloutErrs != nil {
+		errs = append(errs, rolloutErrs...)
+	}
+
+	// deployment policies
+	deployment, deploymentErrs := parseDeployment(context, manifestYAML.Deployment)
+	if deploymentErrs != nil {
+		errs = append(errs, deploymentErrs...)
+	}
+
 	// if any errors occurred up to now, return them
 	if errs != nil {
 		return manifest.Manifest{}, errs
@@ -203,6 +216,8 @@ func Load(context *Context) (manifest.Manifest, []error) {
 		Projects:     projectDefinitions,
 		Environments: environmentDefinitions,
 		Accounts:     accounts,
+		Rollout:      rollout,
+		Deployment:   deployment,
 	}, nil
 }
 
@@ -387,6 +402,8 @@ func parseEnvironments(context *Context, groups []persistence.Group) (map[string
 				errors = append(errors, configErrors...)
 				continue
 			}
+			parsedEnv.GroupVariables = group.Variables
+			parsedEnv.Variables = env.Variables
 
 			environments[parsedEnv.Name] = parsedEnv
 		}
@@ -412,6 +429,194 @@ func parseEnvironments(context *Context, groups []persistence.Group) (map[string
 	return environments, nil
 }
 
+// parseRollout parses and validates the rollout definition. Each environment group must be part of exactly one stage.
+func parseRollout(context *Context, r *persistence.Rollout, groups []persistence.Group) (*manifest.Rollout, []error) {
+	if r == nil {
+		return nil, nil
+	}
+
+	var errs []error
+	if len(r.Stages) == 0 {
+		return nil, []error{newManifestLoaderError(context.ManifestPath, "'rollout' requires at least one stage")}
+	}
+
+	stageOfGroup := make(map[string]string, len(groups))
+	for _, g := range groups {
+		stageOfGroup[g.Name] = ""
+	}
+
+	stageNames := make(map[string]bool, len(r.Stages))
+	stages := make([]manifest.RolloutStage, 0, len(r.Stages))
+	for i, stage := range r.Stages {
+		if stage.Name == "" {
+			errs = append(errs, newManifestLoaderError(context.ManifestPath, fmt.Sprintf("missing rollout stage name on index `%d`", i)))
+		} else if stageNames[stage.Name] {
+			errs = append(errs, newManifestLoaderError(context.ManifestPath, fmt.Sprintf("duplicated rollout stage name %q", stage.Name)))
+		}
+		stageNames[stage.Name] = true
+
+		if len(stage.EnvironmentGroups) == 0 {
+			errs = append(errs, newManifestLoaderError(context.ManifestPath, fmt.Sprintf("rollout stage %q has no environment groups", stage.Name)))
+		}
+
+		for _, g := range stage.EnvironmentGroups {
+			other, found := stageOfGroup[g]
+			switch {
+			case !found:
+				errs = append(errs, newManifestLoaderError(context.ManifestPath, fmt.Sprintf("rollout stage %q references unknown environment group %q", stage.Name, g)))
+			case other != "":
+				errs = append(errs, newManifestLoaderError(context.ManifestPath, fmt.Sprintf("environment group %q is part of rollout stages %q and %q", g, other, stage.Name)))
+			default:
+				stageOfGroup[g] = stage.Name
+			}
+		}
+
+		gate, err := parseGate(stage.Gate)
+		if err != nil {
+			errs = append(errs, newManifestLoaderError(context.ManifestPath, fmt.Sprintf("invalid gate of rollout stage %q: %s", stage.Name, err)))
+		}
+
+		stages = append(stages, manifest.RolloutStage{
+			Name:   stage.Name,
+			Groups: stage.EnvironmentGroups,
+			Gate:   gate,
+		})
+	}
+
+	for _, g := range groups {
+		if stageOfGroup[g.Name] == "" {
+			errs = append(errs, newManifestLoaderError(context.ManifestPath, fmt.Sprintf("environment group %q is not part of any rollout stage", g.Name)))
+		}
+	}
+
+	if errs != nil {
+		return nil, errs
+	}
+	return &manifest.Rollout{Stages: stages}, nil
+}
+
+func parseGate(g *persistence.Gate) (manifest.Gate, error) {
+	if g == nil {
+		return manifest.Gate{}, nil
+	}
+
+	var delay time.Duration
+	if g.Delay != "" {
+		var err error
+		if delay, err = time.ParseDuration(g.Delay); err != nil {
+			return manifest.Gate{}, fmt.Errorf("invalid delay %q: %w", g.Delay, err)
+		}
+		if delay < 0 {
+			return manifest.Gate{}, fmt.Errorf("delay %q must not be negative", g.Delay)
+		}
+	}
+
+	return manifest.Gate{
+		Delay:   delay,
+		Command: g.Command,
+		Confirm: g.Confirm,
+	}, nil
+}
+
+// parseDeployment parses and validates the deployment policies
+func parseDeployment(context *Context, d *persistence.Deployment) (manifest.Deployment, []error) {
+	if d == nil {
+		return manifest.Deployment{}, nil
+	}
+
+	var errs []error
+	var result manifest.Deployment
+	if d.Default != nil {
+		p, err := parseDeploymentPolicy(*d.Default)
+		if err == nil && p.MaxConcurrency > 0 {
+			err = errors.New("'maxConcurrency' and 'serial' are only allowed for config types")
+		}
+		if err != nil {
+			errs = append(errs, newManifestLoaderError(context.ManifestPath, fmt.Sprintf("invalid default deployment policy: %s", err)))
+		}
+		result.Default = p
+	}
+
+	if len(d.Types) > 0 {
+		result.Types = make(map[string]manifest.DeploymentPolicy, len(d.Types))
+	}
+	for t, policy := range d.Types {
+		p, err := parseDeploymentPolicy(policy)
+		if err != nil {
+			errs = append(errs, newManifestLoaderError(context.ManifestPath, fmt.Sprintf("invalid deployment policy of type %q: %s", t, err)))
+		}
+		result.Types[t] = p
+	}
+
+	if errs != nil {
+		return manifest.Deployment{}, errs
+	}
+	return result, nil
+}
+
+func parseDeploymentPolicy(p persistence.DeploymentPolicy) (manifest.DeploymentPolicy, error) {
+	if p.MaxRetries != nil && *p.MaxRetries < 0 {
+		return manifest.DeploymentPolicy{}, errors.New("'maxRetries' must not be negative")
+	}
+	if p.MaxConcurrency < 0 {
+		return manifest.DeploymentPolicy{}, errors.New("'maxConcurrency' must not be negative")
+	}
+	maxConcurrency := p.MaxConcurrency
+	if p.Serial {
+		if maxConcurrency > 1 {
+			return manifest.DeploymentPolicy{}, errors.New("'serial' can't be combined with a 'maxConcurrency' greater than 1")
+		}
+		maxConcurrency = 1
+	}
+
+	backoff, err := parsePolicyDuration("backoff", p.Backoff)
+	if err != nil {
+		return manifest.DeploymentPolicy{}, err
+	}
+	requestTimeout, err := parsePolicyDuration("requestTimeout", p.RequestTimeout)
+	if err != nil {
+		return manifest.DeploymentPolicy{}, err
+	}
+	deadline, err := parsePolicyDuration("deadline", p.Deadline)
+	if err != nil {
+		return manifest.DeploymentPolicy{}, err
+	}
+
+	return manifest.DeploymentPolicy{
+		MaxRetries:     p.MaxRetries,
+		Backoff:        backoff,
+		RequestTimeout: requestTimeout,
+		Deadline:       deadline,
+		MaxConcurrency: maxConcurrency,
+	}, nil
+}
+
+func parsePolicyDuration(name, value string) (time.Duration, error) {
+	if value == "" {
+		return 0, nil
+	}
+
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid '%s' %q: %w", name, value, err)
+	}
+	if d < 0 {
+		return 0, fmt.Errorf("'%s' %q must not be negative", name, value)
+	}
+	return d, nil
+}
+
+func parseHooks(h *persistence.Hooks) manifest.Hooks {
+	if h == nil {
+		return manifest.Hooks{}
+	}
+	return manifest.Hooks{
+		PreDeploy:  h.PreDeploy,
+		PostDeploy: h.PostDeploy,
+		OnError:    h.OnError,
+	}
+}
+
 func shouldSkipEnv(context *Context, group persistence.Group, env persistence.Environment) bool {
 	// if nothing is restricted, everything is allowed
 	if len(context.Groups) == 0 && len(context.Environments) == 0 {
@@ -442,15 +647,38 @@ func parseSingleEnvironment(context *Context, config persistence.Environment, gr
 		errs = append(errs, newManifestEnvironmentLoaderError(context.ManifestPath, group, config.Name, err.Error()))
 	}
 
+	limits, err := parseLimits(config.Limits)
+	if err != nil {
+		errs = append(errs, newManifestEnvironmentLoaderError(context.ManifestPath, group, config.Name, fmt.Sprintf("invalid limits: %s", err)))
+	}
+
 	if len(errs) > 0 {
 		return manifest.EnvironmentDefinition{}, errs
 	}
 
 	return manifest.EnvironmentDefinition{
-		Name:  config.Name,
-		URL:   urlDef,
-		Auth:  a,
-		Group: group,
+		Name:   config.Name,
+		URL:    urlDef,
+		Auth:   a,
+		Group:  group,
+		Hooks:  parseHooks(config.Hooks),
+		Limits: limits,
+	}, nil
+}
+
+func parseLimits(l *persistence.Limits) (manifest.Limits, error) {
+	if l == nil {
+		return manifest.Limits{}, nil
+	}
+	if l.ConcurrentDeployments < 0 {
+		return manifest.Limits{}, errors.New("'concurrentDeployments' must not be negative")
+	}
+	if l.RequestsPerSecond < 0 {
+		return manifest.Limits{}, errors.New("'requestsPerSecond' must not be negative")
+	}
+	return manifest.Limits{
+		ConcurrentDeployments: l.ConcurrentDeployments,
+		RequestsPerSecond:     l.RequestsPerSecond,
 	}, nil
 }
 
@@ -586,16 +814,18 @@ func parseSimpleProjectDefinition(context *projectLoaderContext, project persist
 	if project.Path == "" {
 		return []manifest.ProjectDefinition{
 			{
-				Name: project.Name,
-				Path: project.Name,
+				Name:  project.Name,
+				Path:  project.Name,
+				Hooks: parseHooks(project.Hooks),
 			},
 		}, nil
 	}
 
 	return []manifest.ProjectDefinition{
 		{
-			Name: project.Name,
-			Path: project.Path,
+			Name:  project.Name,
+			Path:  project.Path,
+			Hooks: parseHooks(project.Hooks),
 		},
 	}, nil
 }
@@ -620,6 +850,7 @@ func parseGroupingProjectDefinition(context *projectLoaderContext, project persi
 			Name:  project.Name + "." + file.Name(),
 			Group: project.Name,
 			Path:  filepath.Join(projectPath, file.Name()),
+			Hooks: parseHooks(project.Hooks),
 		})
 	}
 
@@ -31,6 +31,7 @@ import (
 	"path/filepath"
 	"reflect"
 	"testing"
+	"time"
 )
 
 func Test_extractUrlType(t *testing.T) {
@@ -1751,6 +1752,273 @@ environmentGroups: [{name: b, environments: [{name: c, url: {value: d}, auth: {t
 `,
 			errsContain: []string{`environment-variable "not-found" was not found`},
 		},
+		{
+			name: "Rollout with stages and gates",
+			manifestContent: `
+manifestVersion: 1.0
+projects: [{name: a, path: p}]
+environmentGroups:
+- {name: dev, environments: [{name: dev-env, url: {value: d}, auth: {token: {name: e}}}]}
+- {name: prod, environments: [{name: prod-env, url: {value: d}, auth: {token: {name: e}}}]}
+rollout:
+  stages:
+  - {name: first, environmentGroups: [dev]}
+  - {name: second, environmentGroups: [prod], gate: {delay: 10m, command: ./check.sh, confirm: true}}
+`,
+			expectedManifest: manifest.Manifest{
+				Projects: map[string]manifest.ProjectDefinition{
+					"a": {Name: "a", Path: "p"},
+				},
+				Environments: map[string]manifest.EnvironmentDefinition{
+					"dev-env": {
+						Name:  "dev-env",
+						URL:   manifest.URLDefinition{Type: manifest.ValueURLType, Value: "d"},
+						Group: "dev",
+						Auth:  manifest.Auth{Token: &manifest.AuthSecret{Name: "e", Value: "mock token"}},
+					},
+					"prod-env": {
+						Name:  "prod-env",
+						URL:   manifest.URLDefinition{Type: manifest.ValueURLType, Value: "d"},
+						Group: "prod",
+						Auth:  manifest.Auth{Token: &manifest.AuthSecret{Name: "e", Value: "mock token"}},
+					},
+				},
+				Accounts: map[string]manifest.Account{},
+				Rollout: &manifest.Rollout{
+					Stages: []manifest.RolloutStage{
+						{Name: "first", Groups: []string{"dev"}},
+						{Name: "second", Groups: []string{"prod"}, Gate: manifest.Gate{Delay: 10 * time.Minute, Command: "./check.sh", Confirm: true}},
+					},
+				},
+			},
+		},
+		{
+			name: "Hooks of projects and environments",
+			manifestContent: `
+manifestVersion: 1.0
+projects: [{name: a, path: p, hooks: {preDeploy: ./notify.sh}}]
+environmentGroups: [{name: b, environments: [{name: c, url: {value: d}, auth: {token: {name: e}}, hooks: {postDeploy: ./flush.sh, onError: ./alert.sh}}]}]
+`,
+			expectedManifest: manifest.Manifest{
+				Projects: map[string]manifest.ProjectDefinition{
+					"a": {Name: "a", Path: "p", Hooks: manifest.Hooks{PreDeploy: "./notify.sh"}},
+				},
+				Environments: map[string]manifest.EnvironmentDefinition{
+					"c": {
+						Name:  "c",
+						URL:   manifest.URLDefinition{Type: manifest.ValueURLType, Value: "d"},
+						Group: "b",
+						Auth:  manifest.Auth{Token: &manifest.AuthSecret{Name: "e", Value: "mock token"}},
+						Hooks: manifest.Hooks{PostDeploy: "./flush.sh", OnError: "./alert.sh"},
+					},
+				},
+				Accounts: map[string]manifest.Account{},
+			},
+		},
+		{
+			name: "Deployment policies",
+			manifestContent: `
+manifestVersion: 1.0
+projects: [{name: a, path: p}]
+environmentGroups: [{name: b, environments: [{name: c, url: {value: d}, auth: {token: {name: e}}}]}]
+deployment:
+  default: {maxRetries: 5, backoff: 2s}
+  types:
+    dashboard: {requestTimeout: 30s, deadline: 10m}
+`,
+			expectedManifest: manifest.Manifest{
+				Projects: map[string]manifest.ProjectDefinition{
+					"a": {Name: "a", Path: "p"},
+				},
+				Environments: map[string]manifest.EnvironmentDefinition{
+					"c": {
+						Name:  "c",
+						URL:   manifest.URLDefinition{Type: manifest.ValueURLType, Value: "d"},
+						Group: "b",
+						Auth:  manifest.Auth{Token: &manifest.AuthSecret{Name: "e", Value: "mock token"}},
+					},
+				},
+				Accounts: map[string]manifest.Account{},
+				Deployment: manifest.Deployment{
+					Default: manifest.DeploymentPolicy{MaxRetries: func() *int { i := 5; return &i }(), Backoff: 2 * time.Second},
+					Types: map[string]manifest.DeploymentPolicy{
+						"dashboard": {RequestTimeout: 30 * time.Second, Deadline: 10 * time.Minute},
+					},
+				},
+			},
+		},
+		{
+			name: "Deployment policy with invalid duration",
+			manifestContent: `
+manifestVersion: 1.0
+projects: [{name: a, path: p}]
+environmentGroups: [{name: b, environments: [{name: c, url: {value: d}, auth: {token: {name: e}}}]}]
+deployment: {types: {dashboard: {deadline: -1m}}}
+`,
+			errsContain: []string{`invalid deployment policy of type "dashboard": 'deadline' "-1m" must not be negative`},
+		},
+		{
+			name: "Deployment concurrency of config types",
+			manifestContent: `
+manifestVersion: 1.0
+projects: [{name: a, path: p}]
+environmentGroups: [{name: b, environments: [{name: c, url: {value: d}, auth: {token: {name: e}}}]}]
+deployment:
+  types:
+    dashboard: {maxConcurrency: 3}
+    builtin:tags.auto-tagging: {serial: true}
+`,
+			expectedManifest: manifest.Manifest{
+				Projects: map[string]manifest.ProjectDefinition{
+					"a": {Name: "a", Path: "p"},
+				},
+				Environments: map[string]manifest.EnvironmentDefinition{
+					"c": {
+						Name:  "c",
+						URL:   manifest.URLDefinition{Type: manifest.ValueURLType, Value: "d"},
+						Group: "b",
+						Auth:  manifest.Auth{Token: &manifest.AuthSecret{Name: "e", Value: "mock token"}},
+					},
+				},
+				Accounts: map[string]manifest.Account{},
+				Deployment: manifest.Deployment{
+					Types: map[string]manifest.DeploymentPolicy{
+						"dashboard":                 {MaxConcurrency: 3},
+						"builtin:tags.auto-tagging": {MaxConcurrency: 1},
+					},
+				},
+			},
+		},
+		{
+			name: "Serial deployment combined with higher concurrency",
+			manifestContent: `
+manifestVersion: 1.0
+projects: [{name: a, path: p}]
+environmentGroups: [{name: b, environments: [{name: c, url: {value: d}, auth: {token: {name: e}}}]}]
+deployment: {types: {dashboard: {serial: true, maxConcurrency: 2}}}
+`,
+			errsContain: []string{`invalid deployment policy of type "dashboard": 'serial' can't be combined with a 'maxConcurrency' greater than 1`},
+		},
+		{
+			name: "Deployment concurrency in default policy",
+			manifestContent: `
+manifestVersion: 1.0
+projects: [{name: a, path: p}]
+environmentGroups: [{name: b, environments: [{name: c, url: {value: d}, auth: {token: {name: e}}}]}]
+deployment: {default: {serial: true}}
+`,
+			errsContain: []string{`invalid default deployment policy: 'maxConcurrency' and 'serial' are only allowed for config types`},
+		},
+		{
+			name: "Limits of environments",
+			manifestContent: `
+manifestVersion: 1.0
+projects: [{name: a, path: p}]
+environmentGroups: [{name: b, environments: [{name: c, url: {value: d}, auth: {token: {name: e}}, limits: {concurrentDeployments: 2, requestsPerSecond: 0.5}}]}]
+`,
+			expectedManifest: manifest.Manifest{
+				Projects: map[string]manifest.ProjectDefinition{
+					"a": {Name: "a", Path: "p"},
+				},
+				Environments: map[string]manifest.EnvironmentDefinition{
+					"c": {
+						Name:   "c",
+						URL:    manifest.URLDefinition{Type: manifest.ValueURLType, Value: "d"},
+						Group:  "b",
+						Auth:   manifest.Auth{Token: &manifest.AuthSecret{Name: "e", Value: "mock token"}},
+						Limits: manifest.Limits{ConcurrentDeployments: 2, RequestsPerSecond: 0.5},
+					},
+				},
+				Accounts: map[string]manifest.Account{},
+			},
+		},
+		{
+			name: "Variables of groups and environments",
+			manifestContent: `
+manifestVersion: 1.0
+projects: [{name: a, path: p}]
+environmentGroups:
+- name: b
+  variables: {team: platform, threshold: 10}
+  environments:
+  - {name: c, url: {value: d}, auth: {token: {name: e}}, variables: {threshold: 20, owner: someone@example.com}}
+  - {name: f, url: {value: d}, auth: {token: {name: e}}}
+`,
+			expectedManifest: manifest.Manifest{
+				Projects: map[string]manifest.ProjectDefinition{
+					"a": {Name: "a", Path: "p"},
+				},
+				Environments: map[string]manifest.EnvironmentDefinition{
+					"c": {
+						Name:           "c",
+						URL:            manifest.URLDefinition{Type: manifest.ValueURLType, Value: "d"},
+						Group:          "b",
+						Auth:           manifest.Auth{Token: &manifest.AuthSecret{Name: "e", Value: "mock token"}},
+						GroupVariables: map[string]interface{}{"team": "platform", "threshold": 10},
+						Variables:      map[string]interface{}{"threshold": 20, "owner": "someone@example.com"},
+					},
+					"f": {
+						Name:           "f",
+						URL:            manifest.URLDefinition{Type: manifest.ValueURLType, Value: "d"},
+						Group:          "b",
+						Auth:           manifest.Auth{Token: &manifest.AuthSecret{Name: "e", Value: "mock token"}},
+						GroupVariables: map[string]interface{}{"team": "platform", "threshold": 10},
+					},
+				},
+				Accounts: map[string]manifest.Account{},
+			},
+		},
+		{
+			name: "Negative limits of environments",
+			manifestContent: `
+manifestVersion: 1.0
+projects: [{name: a, path: p}]
+environmentGroups: [{name: b, environments: [{name: c, url: {value: d}, auth: {token: {name: e}}, limits: {requestsPerSecond: -1}}]}]
+`,
+			errsContain: []string{`invalid limits: 'requestsPerSecond' must not be negative`},
+		},
+		{
+			name: "Rollout stage references unknown group",
+			manifestContent: `
+manifestVersion: 1.0
+projects: [{name: a, path: p}]
+environmentGroups: [{name: b, environments: [{name: c, url: {value: d}, auth: {token: {name: e}}}]}]
+rollout: {stages: [{name: first, environmentGroups: [b, unknown]}]}
+`,
+			errsContain: []string{`rollout stage "first" references unknown environment group "unknown"`},
+		},
+		{
+			name: "Rollout misses group",
+			manifestContent: `
+manifestVersion: 1.0
+projects: [{name: a, path: p}]
+environmentGroups:
+- {name: b, environments: [{name: c, url: {value: d}, auth: {token: {name: e}}}]}
+- {name: f, environments: [{name: g, url: {value: d}, auth: {token: {name: e}}}]}
+rollout: {stages: [{name: first, environmentGroups: [b]}]}
+`,
+			errsContain: []string{`environment group "f" is not part of any rollout stage`},
+		},
+		{
+			name: "Rollout group in multiple stages",
+			manifestContent: `
+manifestVersion: 1.0
+projects: [{name: a, path: p}]
+environmentGroups: [{name: b, environments: [{name: c, url: {value: d}, auth: {token: {name: e}}}]}]
+rollout: {stages: [{name: first, environmentGroups: [b]}, {name: second, environmentGroups: [b]}]}
+`,
+			errsContain: []string{`environment group "b" is part of rollout stages "first" and "second"`},
+		},
+		{
+			name: "Rollout gate with invalid delay",
+			manifestContent: `
+manifestVersion: 1.0
+projects: [{name: a, path: p}]
+environmentGroups: [{name: b, environments: [{name: c, url: {value: d}, auth: {token: {name: e}}}]}]
+rollout: {stages: [{name: first, environmentGroups: [b], gate: {delay: soon}}]}
+`,
+			errsContain: []string{`invalid gate of rollout stage "first"`},
+		},
 	}
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
@@ -19,6 +19,7 @@ package manifest
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/google/uuid"
 	"golang.org/x/exp/maps"
@@ -31,6 +32,16 @@ type ProjectDefinition struct {
 	Name  string
 	Group string
 	Path  string
+
+	// Hooks are run once around the deployment of the configs of the project to each environment
+	Hooks Hooks
+}
+
+// Hooks are local shell commands that are run around a deployment. Empty commands are not run.
+type Hooks struct {
+	PreDeploy  string
+	PostDeploy string
+	OnError    string
 }
 
 func (p ProjectDefinition) String() string {
@@ -65,6 +76,39 @@ type EnvironmentDefinition struct {
 	Group string
 	URL   URLDefinition
 	Auth  Auth
+
+	// Hooks are run around the deployment of the environment
+	Hooks Hooks
+
+	// Limits restrict the load put on the environment
+	Limits Limits
+
+	// GroupVariables are the variables defined by the group of the environment
+	GroupVariables map[string]interface{}
+
+	// Variables are the variables defined by the environment. They override GroupVariables with the same name.
+	Variables map[string]interface{}
+}
+
+// AllVariables returns the variables that can be used by the configs deployed to the environment, which are the
+// GroupVariables overridden by the Variables of the environment. It returns nil if neither defines variables.
+func (e EnvironmentDefinition) AllVariables() map[string]interface{} {
+	if len(e.GroupVariables) == 0 && len(e.Variables) == 0 {
+		return nil
+	}
+
+	result := make(map[string]interface{}, len(e.GroupVariables)+len(e.Variables))
+	maps.Copy(result, e.GroupVariables)
+	maps.Copy(result, e.Variables)
+	return result
+}
+
+// Limits restrict the load put on an environment. Zero values mean that no limit is defined for the environment.
+type Limits struct {
+	// ConcurrentDeployments is the maximum number of configs deployed to the environment concurrently
+	ConcurrentDeployments int
+	// RequestsPerSecond is the maximum number of requests per second sent to the environment
+	RequestsPerSecond float64
 }
 
 // URLType describes from where the url is loaded.
@@ -139,4 +183,61 @@ type Manifest struct {
 
 	// Accounts holds all accounts defined in the manifest. Key is the user-defined account name.
 	Accounts map[string]Account
+
+	// Rollout holds the stages of a staged rollout, if one is defined in the manifest
+	Rollout *Rollout
+
+	// Deployment holds the deployment policies of configurations defined in the manifest
+	Deployment Deployment
+}
+
+// Deployment holds the policies that define how configurations are retried, timed out, and parallelized when deploying
+// them
+type Deployment struct {
+	// Default is the policy of all configurations
+	Default DeploymentPolicy
+
+	// Types holds the policies by config type. They take precedence over the Default policy.
+	Types map[string]DeploymentPolicy
+}
+
+// DeploymentPolicy defines how the deployment of a config is retried and timed out. Values that are not set keep the
+// default behaviour.
+type DeploymentPolicy struct {
+	MaxRetries     *int
+	Backoff        time.Duration
+	RequestTimeout time.Duration
+	Deadline       time.Duration
+
+	// MaxConcurrency is the maximum number of configs of a type that are deployed concurrently. It is only set for
+	// policies of config types. If it is 0, the limit of the config type is kept.
+	MaxConcurrency int
+}
+
+// Rollout orders the environment groups of a manifest into stages, which are deployed one after the other
+type Rollout struct {
+	Stages []RolloutStage
+}
+
+// RolloutStage is a set of environment groups that are deployed together
+type RolloutStage struct {
+	Name string
+
+	// Groups are the names of all environment groups of the stage
+	Groups []string
+
+	// Gate defines the conditions that must be met before the stage is deployed
+	Gate Gate
+}
+
+// Gate defines conditions that must be met before a RolloutStage is deployed. All set conditions must be met.
+type Gate struct {
+	// Delay to wait for
+	Delay time.Duration
+
+	// Command is a shell command that must exit with code 0
+	Command string
+
+	// Confirm defines whether the deployment must be confirmed interactively
+	Confirm bool
 }
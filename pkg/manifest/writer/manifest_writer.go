@@ -23,6 +23,7 @@ import (
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/version"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/spf13/afero"
 	"gopkg.in/yaml.v2"
@@ -77,6 +78,8 @@ func Write(context *Context, manifestToWrite manifest.Manifest) error {
 		ManifestVersion:   version.ManifestVersion,
 		Projects:          projects,
 		EnvironmentGroups: groups,
+		Rollout:           toWriteableRollout(manifestToWrite.Rollout),
+		Deployment:        toWriteableDeployment(manifestToWrite.Deployment),
 	}
 
 	m.Accounts = toWriteableAccounts(manifestToWrite.Accounts)
@@ -107,14 +110,15 @@ func toWriteableProjects(projects map[string]manifest.ProjectDefinition) (result
 			groupName, groupPath := extractGroupedProjectDetails(projectDefinition)
 
 			groups[groupName] = persistence.Project{
-				Name: groupName,
-				Path: groupPath,
-				Type: persistence.GroupProjectType,
+				Name:  groupName,
+				Path:  groupPath,
+				Type:  persistence.GroupProjectType,
+				Hooks: toWriteableHooks(projectDefinition.Hooks),
 			}
 			continue
 		}
 
-		p := persistence.Project{Name: projectDefinition.Name}
+		p := persistence.Project{Name: projectDefinition.Name, Hooks: toWriteableHooks(projectDefinition.Hooks)}
 
 		if projectDefinition.Name != projectDefinition.Path {
 			p.Path = projectDefinition.Path
@@ -146,24 +150,118 @@ func extractGroupedProjectDetails(projectDefinition manifest.ProjectDefinition)
 
 func toWriteableEnvironmentGroups(environments map[string]manifest.EnvironmentDefinition) (result []persistence.Group) {
 	environmentPerGroup := make(map[string][]persistence.Environment)
+	variablesPerGroup := make(map[string]map[string]interface{})
 
 	for name, env := range environments {
 		e := persistence.Environment{
-			Name: name,
-			URL:  toWriteableURL(env.URL),
-			Auth: getAuth(env),
+			Name:      name,
+			URL:       toWriteableURL(env.URL),
+			Auth:      getAuth(env),
+			Hooks:     toWriteableHooks(env.Hooks),
+			Limits:    toWriteableLimits(env.Limits),
+			Variables: env.Variables,
 		}
 
 		environmentPerGroup[env.Group] = append(environmentPerGroup[env.Group], e)
+		if len(env.GroupVariables) > 0 {
+			variablesPerGroup[env.Group] = env.GroupVariables
+		}
 	}
 
 	for g, envs := range environmentPerGroup {
-		result = append(result, persistence.Group{Name: g, Environments: envs})
+		result = append(result, persistence.Group{Name: g, Variables: variablesPerGroup[g], Environments: envs})
 	}
 
 	return result
 }
 
+func toWriteableHooks(h manifest.Hooks) *persistence.Hooks {
+	if h == (manifest.Hooks{}) {
+		return nil
+	}
+	return &persistence.Hooks{
+		PreDeploy:  h.PreDeploy,
+		PostDeploy: h.PostDeploy,
+		OnError:    h.OnError,
+	}
+}
+
+func toWriteableLimits(l manifest.Limits) *persistence.Limits {
+	if l == (manifest.Limits{}) {
+		return nil
+	}
+	return &persistence.Limits{
+		ConcurrentDeployments: l.ConcurrentDeployments,
+		RequestsPerSecond:     l.RequestsPerSecond,
+	}
+}
+
+func toWriteableRollout(r *manifest.Rollout) *persistence.Rollout {
+	if r == nil {
+		return nil
+	}
+
+	stages := make([]persistence.RolloutStage, 0, len(r.Stages))
+	for _, s := range r.Stages {
+		stages = append(stages, persistence.RolloutStage{
+			Name:              s.Name,
+			EnvironmentGroups: s.Groups,
+			Gate:              toWriteableGate(s.Gate),
+		})
+	}
+	return &persistence.Rollout{Stages: stages}
+}
+
+func toWriteableGate(g manifest.Gate) *persistence.Gate {
+	if g == (manifest.Gate{}) {
+		return nil
+	}
+
+	gate := &persistence.Gate{
+		Command: g.Command,
+		Confirm: g.Confirm,
+	}
+	gate.Delay = toWriteableDuration(g.Delay)
+	return gate
+}
+
+func toWriteableDeployment(d manifest.Deployment) *persistence.Deployment {
+	if d.Default == (manifest.DeploymentPolicy{}) && len(d.Types) == 0 {
+		return nil
+	}
+
+	result := &persistence.Deployment{}
+	if d.Default != (manifest.DeploymentPolicy{}) {
+		p := toWriteableDeploymentPolicy(d.Default)
+		result.Default = &p
+	}
+	if len(d.Types) > 0 {
+		result.Types = make(map[string]persistence.DeploymentPolicy, len(d.Types))
+		for t, p := range d.Types {
+			result.Types[t] = toWriteableDeploymentPolicy(p)
+		}
+	}
+	return result
+}
+
+func toWriteableDeploymentPolicy(p manifest.DeploymentPolicy) persistence.DeploymentPolicy {
+	return persistence.DeploymentPolicy{
+		MaxRetries:     p.MaxRetries,
+		Backoff:        toWriteableDuration(p.Backoff),
+		RequestTimeout: toWriteableDuration(p.RequestTimeout),
+		Deadline:       toWriteableDuration(p.Deadline),
+		MaxConcurrency: p.MaxConcurrency,
+	}
+}
+
+// toWriteableDuration returns the given duration as string, or an empty string if it is not set
+func toWriteableDuration(d time.Duration) string {
+	if d <= 0 {
+		return ""
+	}
+	return d.String()
+}
+
 func getAuth(env manifest.EnvironmentDefinition) persistence.Auth {
 	return persistence.Auth{
 		Token: getTokenSecret(env.Auth, env.Name),
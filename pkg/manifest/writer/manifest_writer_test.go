@@ -28,6 +28,7 @@ import (
 	"reflect"
 	"sort"
 	"testing"
+	"time"
 )
 
 func Test_toWriteableProjects(t *testing.T) {
@@ -330,8 +331,8 @@ func Test_toWriteableEnvironmentGroups(t *testing.T) {
 					},
 				},
 				{
-					"group2",
-					[]persistence.Environment{
+					Name: "group2",
+					Environments: []persistence.Environment{
 						{
 							Name: "env3",
 							URL:  persistence.TypedValue{Value: "www.an.Url"},
@@ -346,6 +347,45 @@ func Test_toWriteableEnvironmentGroups(t *testing.T) {
 				},
 			},
 		},
+		{
+			"writes variables at the level they are defined",
+			map[string]manifest.EnvironmentDefinition{
+				"env1": {
+					Name:           "env1",
+					URL:            manifest.URLDefinition{Value: "www.an.Url"},
+					Group:          "group1",
+					Auth:           manifest.Auth{Token: &manifest.AuthSecret{Name: "env1_TOKEN"}},
+					GroupVariables: map[string]interface{}{"team": "platform", "threshold": 10},
+					Variables:      map[string]interface{}{"threshold": 20},
+				},
+				"env2": {
+					Name:           "env2",
+					URL:            manifest.URLDefinition{Value: "www.an.Url"},
+					Group:          "group1",
+					Auth:           manifest.Auth{Token: &manifest.AuthSecret{Name: "env2_TOKEN"}},
+					GroupVariables: map[string]interface{}{"team": "platform", "threshold": 10},
+				},
+			},
+			[]persistence.Group{
+				{
+					Name:      "group1",
+					Variables: map[string]interface{}{"team": "platform", "threshold": 10},
+					Environments: []persistence.Environment{
+						{
+							Name:      "env1",
+							URL:       persistence.TypedValue{Value: "www.an.Url"},
+							Auth:      persistence.Auth{Token: &persistence.AuthSecret{Name: "env1_TOKEN", Type: "environment"}},
+							Variables: map[string]interface{}{"threshold": 20},
+						},
+						{
+							Name: "env2",
+							URL:  persistence.TypedValue{Value: "www.an.Url"},
+							Auth: persistence.Auth{Token: &persistence.AuthSecret{Name: "env2_TOKEN", Type: "environment"}},
+						},
+					},
+				},
+			},
+		},
 		{
 			"returns empty groups for empty env definition",
 			map[string]manifest.EnvironmentDefinition{},
@@ -573,6 +613,88 @@ func Test_toWriteableAccounts(t *testing.T) {
 	}
 }
 
+func Test_toWriteableRollout(t *testing.T) {
+	tests := []struct {
+		name  string
+		given *manifest.Rollout
+		want  *persistence.Rollout
+	}{
+		{
+			"no rollout",
+			nil,
+			nil,
+		},
+		{
+			"stages with and without gates",
+			&manifest.Rollout{
+				Stages: []manifest.RolloutStage{
+					{Name: "dev", Groups: []string{"development"}},
+					{Name: "prod", Groups: []string{"production", "canary"}, Gate: manifest.Gate{Delay: 10 * time.Minute, Command: "./check.sh", Confirm: true}},
+				},
+			},
+			&persistence.Rollout{
+				Stages: []persistence.RolloutStage{
+					{Name: "dev", EnvironmentGroups: []string{"development"}},
+					{Name: "prod", EnvironmentGroups: []string{"production", "canary"}, Gate: &persistence.Gate{Delay: "10m0s", Command: "./check.sh", Confirm: true}},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := toWriteableRollout(tt.given)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_toWriteableDeployment(t *testing.T) {
+	maxRetries := 3
+	tests := []struct {
+		name  string
+		given manifest.Deployment
+		want  *persistence.Deployment
+	}{
+		{
+			"no deployment policies",
+			manifest.Deployment{},
+			nil,
+		},
+		{
+			"default and type policies",
+			manifest.Deployment{
+				Default: manifest.DeploymentPolicy{MaxRetries: &maxRetries, Backoff: 5 * time.Second, Deadline: 5 * time.Minute},
+				Types: map[string]manifest.DeploymentPolicy{
+					"dashboard":        {RequestTimeout: 30 * time.Second},
+					"builtin:alerting": {MaxConcurrency: 1},
+				},
+			},
+			&persistence.Deployment{
+				Default: &persistence.DeploymentPolicy{MaxRetries: &maxRetries, Backoff: "5s", Deadline: "5m0s"},
+				Types: map[string]persistence.DeploymentPolicy{
+					"dashboard":        {RequestTimeout: "30s"},
+					"builtin:alerting": {MaxConcurrency: 1},
+				},
+			},
+		},
+		{
+			"only type policies",
+			manifest.Deployment{
+				Types: map[string]manifest.DeploymentPolicy{"dashboard": {MaxConcurrency: 2}},
+			},
+			&persistence.Deployment{
+				Types: map[string]persistence.DeploymentPolicy{"dashboard": {MaxConcurrency: 2}},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := toWriteableDeployment(tt.given)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
 func TestWrite(t *testing.T) {
 	tests := []struct {
 		name          string
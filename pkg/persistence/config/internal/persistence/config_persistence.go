@@ -31,9 +31,43 @@ type ConfigDefinition struct {
 	Parameters     map[string]ConfigParameter `yaml:"parameters,omitempty" json:"parameters,omitempty" jsonschema:"description=Parameters for this configuration."`
 	Template       string                     `yaml:"template,omitempty" json:"template,omitempty" jsonschema:"required,description=The filepath to the JSON template used for this configuration"`
 	Skip           ConfigParameter            `yaml:"skip,omitempty" json:"skip,omitempty" jsonschema:"description=Defines whether this config should be skipped when deploying."`
+	Labels         []string                   `yaml:"labels,omitempty" json:"labels,omitempty" jsonschema:"description=Free-form labels of this configuration. They can be used to select configurations to deploy."`
+	Hooks          *Hooks                     `yaml:"hooks,omitempty" json:"hooks,omitempty" jsonschema:"description=Local shell commands that are run around the deployment of this configuration."`
+	Deployment     *DeploymentPolicy          `yaml:"deployment,omitempty" json:"deployment,omitempty" jsonschema:"description=Overrides how the deployment of this configuration is retried and timed out."`
+	Verify         *Verification              `yaml:"verify,omitempty" json:"verify,omitempty" jsonschema:"description=Checks of the deployed object after deploying this configuration. If any check fails, the deployment fails."`
 	OriginObjectId string                     `yaml:"originObjectId,omitempty" json:"originObjectId,omitempty" jsonschema:"description=description=The identifier of the Dynatrace object this config originated from - this is filled when downloading, but can also be set to tie a config to a specific object."`
 }
 
+// Hooks define local shell commands that are run around the deployment of a configuration
+type Hooks struct {
+	PreDeploy  string `yaml:"preDeploy,omitempty" json:"preDeploy,omitempty" jsonschema:"description=A shell command that is run before the configuration is deployed. If it fails, the deployment of the configuration fails."`
+	PostDeploy string `yaml:"postDeploy,omitempty" json:"postDeploy,omitempty" jsonschema:"description=A shell command that is run after the configuration was deployed successfully."`
+	OnError    string `yaml:"onError,omitempty" json:"onError,omitempty" jsonschema:"description=A shell command that is run if the deployment of the configuration failed."`
+}
+
+// DeploymentPolicy defines how the deployment of a configuration is retried and timed out
+type DeploymentPolicy struct {
+	MaxRetries     *int   `yaml:"maxRetries,omitempty" json:"maxRetries,omitempty" jsonschema:"minimum=0,description=The maximum number of retries of failed requests."`
+	Backoff        string `yaml:"backoff,omitempty" json:"backoff,omitempty" jsonschema:"description=The duration to wait between retries, e.g. '5s'."`
+	RequestTimeout string `yaml:"requestTimeout,omitempty" json:"requestTimeout,omitempty" jsonschema:"description=The maximum duration of each single request, e.g. '30s'."`
+	Deadline       string `yaml:"deadline,omitempty" json:"deadline,omitempty" jsonschema:"description=The maximum duration of the whole deployment of the configuration including all retries, e.g. '5m'."`
+	MaxConcurrency int    `yaml:"maxConcurrency,omitempty" json:"maxConcurrency,omitempty" jsonschema:"minimum=0,description=The maximum number of configurations of the Settings 2.0 schema that are deployed concurrently to an environment. It applies to all configurations of the schema. Only allowed for Settings 2.0 configurations."`
+	Serial         bool   `yaml:"serial,omitempty" json:"serial,omitempty" jsonschema:"description=Whether configurations of the Settings 2.0 schema are deployed one after the other. This is the same as a 'maxConcurrency' of 1. Only allowed for Settings 2.0 configurations."`
+}
+
+// Verification defines the checks of the deployed object after deploying a configuration
+type Verification struct {
+	Expect         []Expectation `yaml:"expect,omitempty" json:"expect,omitempty" jsonschema:"description=Values the deployed object must contain."`
+	EqualsRendered bool          `yaml:"equalsRendered,omitempty" json:"equalsRendered,omitempty" jsonschema:"description=Whether all values of the rendered configuration must be equal in the deployed object."`
+	Ignore         []string      `yaml:"ignore,omitempty" json:"ignore,omitempty" jsonschema:"description=Paths of values of the rendered configuration that are not compared if 'equalsRendered' is set, e.g. '$.tiles[0].bounds'."`
+}
+
+// Expectation is a value the deployed object must contain
+type Expectation struct {
+	Path  string `yaml:"path" json:"path" jsonschema:"required,description=The JSONPath-like expression pointing to the value, e.g. '$.rules[0].enabled'."`
+	Value any    `yaml:"value" json:"value" jsonschema:"description=The expected value."`
+}
+
 type TopLevelConfigDefinition struct {
 	Id     string           `yaml:"id" json:"id" jsonschema:"required,description=The monaco identifier for this config - is used in references and for some generated IDs in Dynatrace environments."`
 	Config ConfigDefinition `yaml:"config" json:"config" jsonschema:"required,description=The actual configuration to be applied"`
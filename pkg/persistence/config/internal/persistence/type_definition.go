@@ -74,23 +74,13 @@ func (c *TypeDefinition) UnmarshalYAML(unmarshal func(interface{}) error) error
 	// To catch that, let's try to unmarshal directly into a string. If it works, we know the shorthand is used.
 	var str string
 	if err := unmarshal(&str); err == nil {
-		switch str {
-		case BucketType:
-			c.Type = config.BucketType{}
-		case SegmentType:
-			if !featureflags.Segments.Enabled() {
-				return fmt.Errorf("unknown config-type %q", str)
-			}
-			c.Type = config.Segment{}
-		case ServiceLevelObjectiveType:
-			if !featureflags.ServiceLevelObjective.Enabled() {
-				return fmt.Errorf("unknown config-type %q", str)
-			}
-			c.Type = config.ServiceLevelObjective{}
-		default:
-			c.Type = config.ClassicApiType{Api: str}
+		if h, found := config.Types.GetByKey(str, true); found {
+			return c.parse(h, nil)
 		}
-		return nil
+
+		// any other string is the name of a classic API
+		h, _ := config.Types.Get(config.ClassicApiTypeID)
+		return c.parse(h, str)
 	}
 
 	// If the shorthand is not used, we need to unmarshal into the more complex map and unmarshal it later into the specific types.
@@ -110,149 +100,164 @@ func (c *TypeDefinition) UnmarshalYAML(unmarshal func(interface{}) error) error
 
 	ttype := types[0]
 
-	// Now we know the one type and can call the unmarshalers.
-	// The unmarshalers write to the type directly to update it, which is a design choice, not a requirement.
-	unmarshalers := map[string]func(data any) error{
-		"api":        c.parseApiType,
-		"settings":   c.parseSettingsType,
-		"automation": c.parseAutomation,
-		"document":   c.parseDocumentType,
+	// Now we know the one type and can call the parser registered for it.
+	h, found := config.Types.GetByKey(ttype, false)
+	if !found {
+		return fmt.Errorf("unknown config-type %q", ttype)
 	}
+	return c.parse(h, data[ttype])
+}
 
-	if featureflags.OpenPipeline.Enabled() {
-		unmarshalers["openpipeline"] = c.parseOpenPipelineType
+// parse sets the type definition to the result of the parser of the given type handler
+func (c *TypeDefinition) parse(h config.TypeHandler, data any) error {
+	if !h.IsEnabled() || h.Parse == nil {
+		return fmt.Errorf("unknown config-type %q", h.Key)
 	}
 
-	if unm, f := unmarshalers[ttype]; !f {
-		return fmt.Errorf("unknown config-type %q", ttype)
-	} else {
-		return unm(data[ttype])
+	t, err := h.Parse(data)
+	if err != nil {
+		return err
+	}
+	c.Type = t.Type
+	c.Scope = t.Scope
+	c.InsertAfter = t.InsertAfter
+	return nil
+}
+
+func (c *TypeDefinition) toConfigTypeDefinition() config.TypeDefinition {
+	return config.TypeDefinition{
+		Type:        c.Type,
+		Scope:       c.Scope,
+		InsertAfter: c.InsertAfter,
 	}
 }
 
-func (c *TypeDefinition) parseApiType(a any) error {
+func parseApiType(a any) (config.TypeDefinition, error) {
 	// shorthand
 	if str, ok := a.(string); ok {
-		c.Type = config.ClassicApiType{Api: str}
-		return nil
+		return config.TypeDefinition{Type: config.ClassicApiType{Api: str}}, nil
 	}
 
 	// full definition
 	var r ComplexApiDefinition
 	err := mapstructure.Decode(a, &r)
 	if err != nil {
-		return fmt.Errorf("failed to unmarshal api-type: %w", err)
+		return config.TypeDefinition{}, fmt.Errorf("failed to unmarshal api-type: %w", err)
 	}
 
-	c.Type = config.ClassicApiType{Api: r.Name}
-	c.Scope = r.Scope
-	return nil
+	return config.TypeDefinition{
+		Type:  config.ClassicApiType{Api: r.Name},
+		Scope: r.Scope,
+	}, nil
 }
 
-func (c *TypeDefinition) parseSettingsType(a any) error {
+func parseSettingsType(a any) (config.TypeDefinition, error) {
 	var r SettingsDefinition
 	err := mapstructure.Decode(a, &r)
 	if err != nil {
-		return fmt.Errorf("failed to unmarshal settings-type: %w", err)
+		return config.TypeDefinition{}, fmt.Errorf("failed to unmarshal settings-type: %w", err)
 	}
 
-	c.Type = config.SettingsType{
-		SchemaId:      r.Schema,
-		SchemaVersion: r.SchemaVersion,
-	}
-	c.Scope = r.Scope
-	c.InsertAfter = r.InsertAfter
-	return nil
+	return config.TypeDefinition{
+		Type: config.SettingsType{
+			SchemaId:      r.Schema,
+			SchemaVersion: r.SchemaVersion,
+		},
+		Scope:       r.Scope,
+		InsertAfter: r.InsertAfter,
+	}, nil
 }
 
-func (c *TypeDefinition) parseAutomation(a any) error {
+func parseAutomation(a any) (config.TypeDefinition, error) {
 	var r AutomationDefinition
 	err := mapstructure.Decode(a, &r)
 	if err != nil {
-		return fmt.Errorf("failed to unmarshal automation-type: %w", err)
+		return config.TypeDefinition{}, fmt.Errorf("failed to unmarshal automation-type: %w", err)
 	}
 
-	c.Type = config.AutomationType{Resource: r.Resource}
-
-	return nil
+	return config.TypeDefinition{Type: config.AutomationType{Resource: r.Resource}}, nil
 }
 
-func (c *TypeDefinition) parseDocumentType(a any) error {
+func parseDocumentType(a any) (config.TypeDefinition, error) {
 	var r DocumentDefinition
 	err := mapstructure.Decode(a, &r)
 	if err != nil {
-		return fmt.Errorf("failed to unmarshal document-type: %w", err)
-	}
-
-	c.Type = config.DocumentType{
-		Kind:    r.Kind,
-		Private: r.Private,
+		return config.TypeDefinition{}, fmt.Errorf("failed to unmarshal document-type: %w", err)
 	}
 
-	return nil
+	return config.TypeDefinition{
+		Type: config.DocumentType{
+			Kind:    r.Kind,
+			Private: r.Private,
+		},
+	}, nil
 }
 
-func (c *TypeDefinition) parseOpenPipelineType(a any) error {
+func parseOpenPipelineType(a any) (config.TypeDefinition, error) {
 	var r OpenPipelineDefinition
 	err := mapstructure.Decode(a, &r)
 	if err != nil {
-		return fmt.Errorf("failed to unmarshal openpipeline-type: %w", err)
-	}
-
-	c.Type = config.OpenPipelineType{
-		Kind: r.Kind,
+		return config.TypeDefinition{}, fmt.Errorf("failed to unmarshal openpipeline-type: %w", err)
 	}
 
-	return nil
+	return config.TypeDefinition{Type: config.OpenPipelineType{Kind: r.Kind}}, nil
 }
 
 // Validate verifies whether the given type definition is valid (correct APIs, fields set, etc)
 func (c *TypeDefinition) Validate(apis map[string]struct{}) error {
-	switch t := c.Type.(type) {
-	case config.ClassicApiType:
+	if t, ok := c.Type.(config.ClassicApiType); ok {
 		if _, f := apis[t.Api]; !f {
 			return fmt.Errorf("unknown API: %s", t.Api)
 		}
+	}
 
-	case config.SettingsType:
-		if t.SchemaId == "" {
-			return errors.New("missing settings schemaId")
-		}
+	if h, found := config.Types.Get(c.Type.ID()); found && h.Validate != nil {
+		return h.Validate(c.toConfigTypeDefinition())
+	}
+	return nil
+}
 
-		if c.Scope == nil {
-			return errors.New("missing settings scope")
-		}
+func validateSettingsType(c config.TypeDefinition) error {
+	if c.Type.(config.SettingsType).SchemaId == "" {
+		return errors.New("missing settings schemaId")
+	}
 
-	case config.AutomationType:
-		switch t.Resource {
-		case "":
-			return errors.New("missing automation resource property")
+	if c.Scope == nil {
+		return errors.New("missing settings scope")
+	}
+	return nil
+}
 
-		case config.Workflow, config.BusinessCalendar, config.SchedulingRule:
-			return nil
+func validateAutomationType(c config.TypeDefinition) error {
+	switch r := c.Type.(config.AutomationType).Resource; r {
+	case "":
+		return errors.New("missing automation resource property")
 
-		default:
-			return fmt.Errorf("unknown automation resource %q", t.Resource)
-		}
+	case config.Workflow, config.BusinessCalendar, config.SchedulingRule:
+		return nil
 
-	case config.DocumentType:
+	default:
+		return fmt.Errorf("unknown automation resource %q", r)
+	}
+}
 
-		if t.Kind == "" {
-			return errors.New("missing document kind property")
-		}
+func validateDocumentType(c config.TypeDefinition) error {
+	kind := c.Type.(config.DocumentType).Kind
+	if kind == "" {
+		return errors.New("missing document kind property")
+	}
 
-		if slices.Contains(config.KnownDocumentKinds, t.Kind) {
-			return nil
-		}
+	if slices.Contains(config.KnownDocumentKinds, kind) {
+		return nil
+	}
 
-		return fmt.Errorf("unknown document kind %q", t.Kind)
+	return fmt.Errorf("unknown document kind %q", kind)
+}
 
-	case config.OpenPipelineType:
-		if t.Kind == "" {
-			return errors.New("missing openpipeline kind property")
-		}
+func validateOpenPipelineType(c config.TypeDefinition) error {
+	if c.Type.(config.OpenPipelineType).Kind == "" {
+		return errors.New("missing openpipeline kind property")
 	}
-
 	return nil
 }
 
@@ -280,74 +285,75 @@ func (c *TypeDefinition) GetApiType() string {
 }
 
 func (c TypeDefinition) MarshalYAML() (interface{}, error) {
-	switch t := c.Type.(type) {
-
-	case config.ClassicApiType:
-		// if the scope is empty we can return the simple object.
-		if c.Scope == nil {
-			return map[string]string{
-				"api": t.Api,
-			}, nil
+	if c.Type != nil {
+		if h, found := config.Types.Get(c.Type.ID()); found && h.Marshal != nil {
+			return h.Marshal(c.toConfigTypeDefinition())
 		}
+	}
+	return nil, fmt.Errorf("unknown type: %T", c.Type)
+}
 
-		return map[string]any{
-			"api": ComplexApiDefinition{
-				Name:  t.Api,
-				Scope: c.Scope,
-			},
+func marshalApiType(c config.TypeDefinition) (any, error) {
+	// if the scope is empty we can return the simple object.
+	if c.Scope == nil {
+		return map[string]string{
+			"api": c.Type.(config.ClassicApiType).Api,
 		}, nil
+	}
 
-	case config.SettingsType:
-		var insertAfterValue ConfigParameter
-		if featureflags.PersistSettingsOrder.Enabled() {
-			insertAfterValue = c.InsertAfter
-		}
-
-		return map[string]any{
-			"settings": SettingsDefinition{
-				Schema:        t.SchemaId,
-				SchemaVersion: t.SchemaVersion,
-				Scope:         c.Scope,
-				InsertAfter:   insertAfterValue,
-			},
-		}, nil
+	return map[string]any{
+		"api": ComplexApiDefinition{
+			Name:  c.Type.(config.ClassicApiType).Api,
+			Scope: c.Scope,
+		},
+	}, nil
+}
 
-	case config.AutomationType:
-		return map[string]any{
-			"automation": AutomationDefinition{
-				Resource: t.Resource,
-			},
-		}, nil
+func marshalSettingsType(c config.TypeDefinition) (any, error) {
+	t := c.Type.(config.SettingsType)
+	var insertAfterValue ConfigParameter
+	if featureflags.PersistSettingsOrder.Enabled() {
+		insertAfterValue = c.InsertAfter
+	}
 
-	case config.BucketType:
-		return BucketType, nil
+	return map[string]any{
+		"settings": SettingsDefinition{
+			Schema:        t.SchemaId,
+			SchemaVersion: t.SchemaVersion,
+			Scope:         c.Scope,
+			InsertAfter:   insertAfterValue,
+		},
+	}, nil
+}
 
-	case config.DocumentType:
-		return map[string]any{
-			"document": DocumentDefinition{
-				Kind:    t.Kind,
-				Private: t.Private,
-			},
-		}, nil
+func marshalAutomationType(c config.TypeDefinition) (any, error) {
+	return map[string]any{
+		"automation": AutomationDefinition{
+			Resource: c.Type.(config.AutomationType).Resource,
+		},
+	}, nil
+}
 
-	case config.OpenPipelineType:
-		if featureflags.OpenPipeline.Enabled() {
-			return map[string]any{
-				"openpipeline": OpenPipelineDefinition{
-					Kind: t.Kind,
-				},
-			}, nil
-		}
+func marshalDocumentType(c config.TypeDefinition) (any, error) {
+	t := c.Type.(config.DocumentType)
+	return map[string]any{
+		"document": DocumentDefinition{
+			Kind:    t.Kind,
+			Private: t.Private,
+		},
+	}, nil
+}
 
-	case config.Segment:
-		if featureflags.Segments.Enabled() {
-			return SegmentType, nil
-		}
+func marshalOpenPipelineType(c config.TypeDefinition) (any, error) {
+	return map[string]any{
+		"openpipeline": OpenPipelineDefinition{
+			Kind: c.Type.(config.OpenPipelineType).Kind,
+		},
+	}, nil
+}
 
-	case config.ServiceLevelObjective:
-		if featureflags.ServiceLevelObjective.Enabled() {
-			return ServiceLevelObjectiveType, nil
-		}
-	}
-	return nil, fmt.Errorf("unknown type: %T", c.Type)
+// shorthandType returns the parser and marshaller of a type that is defined by its key alone
+func shorthandType(t config.Type, key string) (func(any) (config.TypeDefinition, error), func(config.TypeDefinition) (any, error)) {
+	return func(any) (config.TypeDefinition, error) { return config.TypeDefinition{Type: t}, nil },
+		func(config.TypeDefinition) (any, error) { return key, nil }
 }
@@ -0,0 +1,39 @@
+/*
+ * @license
+ * Copyright 2025 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package persistence
+
+import "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config"
+
+// init registers how the built-in config types are read from and written to the 'type' section of a config
+func init() {
+	parseBucket, marshalBucket := shorthandType(config.BucketType{}, BucketType)
+	parseSegment, marshalSegment := shorthandType(config.Segment{}, SegmentType)
+	parseSLO, marshalSLO := shorthandType(config.ServiceLevelObjective{}, ServiceLevelObjectiveType)
+
+	for _, h := range []config.TypeHandler{
+		{ID: config.ClassicApiTypeID, Parse: parseApiType, Marshal: marshalApiType},
+		{ID: config.SettingsTypeID, Parse: parseSettingsType, Marshal: marshalSettingsType, Validate: validateSettingsType},
+		{ID: config.AutomationTypeID, Parse: parseAutomation, Marshal: marshalAutomationType, Validate: validateAutomationType},
+		{ID: config.BucketTypeID, Parse: parseBucket, Marshal: marshalBucket},
+		{ID: config.DocumentTypeID, Parse: parseDocumentType, Marshal: marshalDocumentType, Validate: validateDocumentType},
+		{ID: config.OpenPipelineTypeID, Parse: parseOpenPipelineType, Marshal: marshalOpenPipelineType, Validate: validateOpenPipelineType},
+		{ID: config.SegmentID, Parse: parseSegment, Marshal: marshalSegment},
+		{ID: config.ServiceLevelObjectiveID, Parse: parseSLO, Marshal: marshalSLO},
+	} {
+		config.Types.Register(h)
+	}
+}
@@ -17,14 +17,18 @@
 package loader
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"path/filepath"
 	"slices"
 	"strconv"
+	"time"
 
 	"github.com/spf13/afero"
 
+	jsonutils "github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/json"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/maps"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/api"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/coordinate"
@@ -142,6 +146,22 @@ func applyOverrides(base *persistence.ConfigDefinition, override persistence.Con
 		base.Skip = override.Skip
 	}
 
+	if override.Labels != nil {
+		base.Labels = override.Labels
+	}
+
+	if override.Hooks != nil {
+		base.Hooks = override.Hooks
+	}
+
+	if override.Deployment != nil {
+		base.Deployment = override.Deployment
+	}
+
+	if override.Verify != nil {
+		base.Verify = override.Verify
+	}
+
 	if override.OriginObjectId != "" {
 		base.OriginObjectId = override.OriginObjectId
 	}
@@ -210,6 +230,16 @@ func getConfigFromDefinition(
 		errs = append(errs, newDetailedDefinitionParserError(configId, context, environment, "missing parameter `name`"))
 	}
 
+	deploymentPolicy, err := parseDeploymentPolicy(definition.Deployment, configType.Type)
+	if err != nil {
+		errs = append(errs, newDetailedDefinitionParserError(configId, context, environment, fmt.Sprintf("invalid deployment policy: %s", err)))
+	}
+
+	verification, err := parseVerification(definition.Verify)
+	if err != nil {
+		errs = append(errs, newDetailedDefinitionParserError(configId, context, environment, fmt.Sprintf("invalid verification: %s", err)))
+	}
+
 	if errs != nil {
 		return config.Config{}, errs
 	}
@@ -246,12 +276,16 @@ func getConfigFromDefinition(
 			Type:     context.Type,
 			ConfigId: configId,
 		},
-		Type:           configType.Type,
-		Group:          environment.Group,
-		Environment:    environment.Name,
-		Parameters:     parameters,
-		Skip:           skipConfig,
-		OriginObjectId: definition.OriginObjectId,
+		Type:             configType.Type,
+		Group:            environment.Group,
+		Environment:      environment.Name,
+		Parameters:       parameters,
+		Skip:             skipConfig,
+		Labels:           definition.Labels,
+		Hooks:            toHooks(definition.Hooks),
+		DeploymentPolicy: deploymentPolicy,
+		Verification:     verification,
+		OriginObjectId:   definition.OriginObjectId,
 	}, nil
 }
 
@@ -291,3 +325,133 @@ func parseSkip(fs afero.Fs,
 
 	return retVal, err
 }
+
+func toHooks(h *persistence.Hooks) config.Hooks {
+	if h == nil {
+		return config.Hooks{}
+	}
+	return config.Hooks{
+		PreDeploy:  h.PreDeploy,
+		PostDeploy: h.PostDeploy,
+		OnError:    h.OnError,
+	}
+}
+
+func parseDeploymentPolicy(p *persistence.DeploymentPolicy, configType config.Type) (config.DeploymentPolicy, error) {
+	if p == nil {
+		return config.DeploymentPolicy{}, nil
+	}
+
+	if p.MaxRetries != nil && *p.MaxRetries < 0 {
+		return config.DeploymentPolicy{}, fmt.Errorf("'maxRetries' must not be negative")
+	}
+
+	maxConcurrency, err := parseMaxConcurrency(p, configType)
+	if err != nil {
+		return config.DeploymentPolicy{}, err
+	}
+
+	backoff, err := parsePolicyDuration("backoff", p.Backoff)
+	if err != nil {
+		return config.DeploymentPolicy{}, err
+	}
+	requestTimeout, err := parsePolicyDuration("requestTimeout", p.RequestTimeout)
+	if err != nil {
+		return config.DeploymentPolicy{}, err
+	}
+	deadline, err := parsePolicyDuration("deadline", p.Deadline)
+	if err != nil {
+		return config.DeploymentPolicy{}, err
+	}
+
+	return config.DeploymentPolicy{
+		MaxRetries:     p.MaxRetries,
+		Backoff:        backoff,
+		RequestTimeout: requestTimeout,
+		Deadline:       deadline,
+		MaxConcurrency: maxConcurrency,
+	}, nil
+}
+
+// parseMaxConcurrency returns the maximum number of concurrently deployed configs of the Settings 2.0 schema defined
+// by the given policy, which is 1 for serial deployments
+func parseMaxConcurrency(p *persistence.DeploymentPolicy, configType config.Type) (int, error) {
+	if p.MaxConcurrency == 0 && !p.Serial {
+		return 0, nil
+	}
+	if _, ok := configType.(config.SettingsType); !ok {
+		return 0, fmt.Errorf("'maxConcurrency' and 'serial' are only allowed for Settings 2.0 configurations")
+	}
+	if p.MaxConcurrency < 0 {
+		return 0, fmt.Errorf("'maxConcurrency' must not be negative")
+	}
+	if p.Serial {
+		if p.MaxConcurrency > 1 {
+			return 0, fmt.Errorf("'serial' can't be combined with a 'maxConcurrency' greater than 1")
+		}
+		return 1, nil
+	}
+	return p.MaxConcurrency, nil
+}
+
+func parseVerification(v *persistence.Verification) (config.Verification, error) {
+	if v == nil {
+		return config.Verification{}, nil
+	}
+
+	if len(v.Ignore) > 0 && !v.EqualsRendered {
+		return config.Verification{}, errors.New("'ignore' requires 'equalsRendered'")
+	}
+	for _, p := range v.Ignore {
+		if _, err := jsonutils.ParsePath(p); err != nil {
+			return config.Verification{}, err
+		}
+	}
+
+	expectations := make([]config.Expectation, 0, len(v.Expect))
+	for _, e := range v.Expect {
+		if _, err := jsonutils.ParsePath(e.Path); err != nil {
+			return config.Verification{}, err
+		}
+
+		// the value is compared to unmarshalled JSON, so it needs to be of the same types
+		value, err := toJSONValue(e.Value)
+		if err != nil {
+			return config.Verification{}, fmt.Errorf("invalid value of %q: %w", e.Path, err)
+		}
+		expectations = append(expectations, config.Expectation{Path: e.Path, Value: value})
+	}
+
+	return config.Verification{
+		Expectations:   expectations,
+		EqualsRendered: v.EqualsRendered,
+		Ignore:         v.Ignore,
+	}, nil
+}
+
+// toJSONValue converts the given YAML value to the types of unmarshalled JSON
+func toJSONValue(v any) (any, error) {
+	b, err := json.Marshal(maps.ToStringKeys(v))
+	if err != nil {
+		return nil, err
+	}
+
+	var result any
+	err = json.Unmarshal(b, &result)
+	return result, err
+}
+
+func parsePolicyDuration(name, value string) (time.Duration, error) {
+	if value == "" {
+		return 0, nil
+	}
+
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid '%s' %q: %w", name, value, err)
+	}
+	if d < 0 {
+		return 0, fmt.Errorf("'%s' %q must not be negative", name, value)
+	}
+	return d, nil
+}
@@ -41,6 +41,8 @@ type LoaderContext struct {
 	Environments    []manifest.EnvironmentDefinition
 	KnownApis       map[string]struct{}
 	ParametersSerDe map[string]parameter.ParameterSerDe
+	// Variables are the variables defined for the project. Variables of the environments take precedence over them.
+	Variables map[string]interface{}
 }
 
 // configFileLoaderContext is a context for each config-file
@@ -23,6 +23,7 @@ import (
 	"fmt"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/spf13/afero"
@@ -405,6 +406,279 @@ configs:
 				},
 			},
 		},
+		{
+			name:             "loads labels and overrides them per environment",
+			filePathArgument: "test-file.yaml",
+			filePathOnDisk:   "test-file.yaml",
+			fileContentOnDisk: `
+configs:
+- id: profile-id
+  config:
+    name: 'Star Trek > Star Wars'
+    template: 'profile.json'
+    labels: [team-a, critical]
+  type:
+    settings:
+      schema: 'builtin:profile.test'
+      scope: 'tenant'
+  environmentOverrides:
+  - environment: env name
+    override:
+      labels: [team-b]`,
+			wantConfigs: []config.Config{
+				{
+					Coordinate: coordinate.Coordinate{
+						Project:  "project",
+						Type:     "builtin:profile.test",
+						ConfigId: "profile-id",
+					},
+					Type: config.SettingsType{
+						SchemaId: "builtin:profile.test",
+					},
+					Template: template.NewInMemoryTemplate("profile.json", "{}"),
+					Parameters: config.Parameters{
+						"name":                &value.ValueParameter{Value: "Star Trek > Star Wars"},
+						config.ScopeParameter: &value.ValueParameter{Value: "tenant"},
+					},
+					Skip:        false,
+					Labels:      []string{"team-b"},
+					Environment: "env name",
+					Group:       "default",
+				},
+			},
+		},
+		{
+			name:             "loads hooks and overrides them per environment",
+			filePathArgument: "test-file.yaml",
+			filePathOnDisk:   "test-file.yaml",
+			fileContentOnDisk: `
+configs:
+- id: profile-id
+  config:
+    name: 'Star Trek > Star Wars'
+    template: 'profile.json'
+    hooks:
+      preDeploy: ./pre.sh
+      onError: ./error.sh
+  type:
+    settings:
+      schema: 'builtin:profile.test'
+      scope: 'tenant'
+  environmentOverrides:
+  - environment: env name
+    override:
+      hooks:
+        postDeploy: ./post.sh`,
+			wantConfigs: []config.Config{
+				{
+					Coordinate: coordinate.Coordinate{
+						Project:  "project",
+						Type:     "builtin:profile.test",
+						ConfigId: "profile-id",
+					},
+					Type: config.SettingsType{
+						SchemaId: "builtin:profile.test",
+					},
+					Template: template.NewInMemoryTemplate("profile.json", "{}"),
+					Parameters: config.Parameters{
+						"name":                &value.ValueParameter{Value: "Star Trek > Star Wars"},
+						config.ScopeParameter: &value.ValueParameter{Value: "tenant"},
+					},
+					Skip:        false,
+					Hooks:       config.Hooks{PostDeploy: "./post.sh"},
+					Environment: "env name",
+					Group:       "default",
+				},
+			},
+		},
+		{
+			name:             "loads deployment policy and overrides it per environment",
+			filePathArgument: "test-file.yaml",
+			filePathOnDisk:   "test-file.yaml",
+			fileContentOnDisk: `
+configs:
+- id: profile-id
+  config:
+    name: 'Star Trek > Star Wars'
+    template: 'profile.json'
+    deployment:
+      maxRetries: 3
+      backoff: 2s
+  type:
+    settings:
+      schema: 'builtin:profile.test'
+      scope: 'tenant'
+  environmentOverrides:
+  - environment: env name
+    override:
+      deployment:
+        maxRetries: 0
+        requestTimeout: 30s
+        deadline: 5m`,
+			wantConfigs: []config.Config{
+				{
+					Coordinate: coordinate.Coordinate{
+						Project:  "project",
+						Type:     "builtin:profile.test",
+						ConfigId: "profile-id",
+					},
+					Type: config.SettingsType{
+						SchemaId: "builtin:profile.test",
+					},
+					Template: template.NewInMemoryTemplate("profile.json", "{}"),
+					Parameters: config.Parameters{
+						"name":                &value.ValueParameter{Value: "Star Trek > Star Wars"},
+						config.ScopeParameter: &value.ValueParameter{Value: "tenant"},
+					},
+					Skip:             false,
+					DeploymentPolicy: config.DeploymentPolicy{MaxRetries: new(int), RequestTimeout: 30 * time.Second, Deadline: 5 * time.Minute},
+					Environment:      "env name",
+					Group:            "default",
+				},
+			},
+		},
+		{
+			name:             "loads serial deployment of settings schema",
+			filePathArgument: "test-file.yaml",
+			filePathOnDisk:   "test-file.yaml",
+			fileContentOnDisk: `
+configs:
+- id: profile-id
+  config:
+    name: 'Star Trek > Star Wars'
+    template: 'profile.json'
+    deployment:
+      serial: true
+  type:
+    settings:
+      schema: 'builtin:profile.test'
+      scope: 'tenant'`,
+			wantConfigs: []config.Config{
+				{
+					Coordinate: coordinate.Coordinate{
+						Project:  "project",
+						Type:     "builtin:profile.test",
+						ConfigId: "profile-id",
+					},
+					Type: config.SettingsType{
+						SchemaId: "builtin:profile.test",
+					},
+					Template: template.NewInMemoryTemplate("profile.json", "{}"),
+					Parameters: config.Parameters{
+						"name":                &value.ValueParameter{Value: "Star Trek > Star Wars"},
+						config.ScopeParameter: &value.ValueParameter{Value: "tenant"},
+					},
+					Skip:             false,
+					DeploymentPolicy: config.DeploymentPolicy{MaxConcurrency: 1},
+					Environment:      "env name",
+					Group:            "default",
+				},
+			},
+		},
+		{
+			name:             "fails to load max concurrency of classic config",
+			filePathArgument: "test-file.yaml",
+			filePathOnDisk:   "test-file.yaml",
+			fileContentOnDisk: `
+configs:
+- id: profile-id
+  config:
+    name: 'Star Trek > Star Wars'
+    template: 'profile.json'
+    deployment:
+      maxConcurrency: 2
+  type:
+    api: some-api`,
+			wantErrorsContain: []string{"invalid deployment policy: 'maxConcurrency' and 'serial' are only allowed for Settings 2.0 configurations"},
+		},
+		{
+			name:             "fails to load invalid deployment policy",
+			filePathArgument: "test-file.yaml",
+			filePathOnDisk:   "test-file.yaml",
+			fileContentOnDisk: `
+configs:
+- id: profile-id
+  config:
+    name: 'Star Trek > Star Wars'
+    template: 'profile.json'
+    deployment:
+      backoff: soon
+  type:
+    settings:
+      schema: 'builtin:profile.test'
+      scope: 'tenant'`,
+			wantErrorsContain: []string{"invalid deployment policy: invalid 'backoff' \"soon\""},
+		},
+		{
+			name:             "loads verification",
+			filePathArgument: "test-file.yaml",
+			filePathOnDisk:   "test-file.yaml",
+			fileContentOnDisk: `
+configs:
+- id: profile-id
+  config:
+    name: 'Star Trek > Star Wars'
+    template: 'profile.json'
+    verify:
+      expect:
+      - path: $.enabled
+        value: true
+      - path: $.rules[0]
+        value: {priority: 1, tags: [a]}
+      equalsRendered: true
+      ignore: [$.metadata]
+  type:
+    settings:
+      schema: 'builtin:profile.test'
+      scope: 'tenant'`,
+			wantConfigs: []config.Config{
+				{
+					Coordinate: coordinate.Coordinate{
+						Project:  "project",
+						Type:     "builtin:profile.test",
+						ConfigId: "profile-id",
+					},
+					Type: config.SettingsType{
+						SchemaId: "builtin:profile.test",
+					},
+					Template: template.NewInMemoryTemplate("profile.json", "{}"),
+					Parameters: config.Parameters{
+						"name":                &value.ValueParameter{Value: "Star Trek > Star Wars"},
+						config.ScopeParameter: &value.ValueParameter{Value: "tenant"},
+					},
+					Verification: config.Verification{
+						Expectations: []config.Expectation{
+							{Path: "$.enabled", Value: true},
+							{Path: "$.rules[0]", Value: map[string]any{"priority": float64(1), "tags": []any{"a"}}},
+						},
+						EqualsRendered: true,
+						Ignore:         []string{"$.metadata"},
+					},
+					Environment: "env name",
+					Group:       "default",
+				},
+			},
+		},
+		{
+			name:             "fails to load verification with invalid path",
+			filePathArgument: "test-file.yaml",
+			filePathOnDisk:   "test-file.yaml",
+			fileContentOnDisk: `
+configs:
+- id: profile-id
+  config:
+    name: 'Star Trek > Star Wars'
+    template: 'profile.json'
+    verify:
+      expect:
+      - path: enabled
+        value: true
+  type:
+    settings:
+      schema: 'builtin:profile.test'
+      scope: 'tenant'`,
+			wantErrorsContain: []string{"invalid verification: invalid path \"enabled\": must start with '$'"},
+		},
 		{
 			name:             "loads settings 2.0 config with full value parameter as scope",
 			filePathArgument: "test-file.yaml",
@@ -29,6 +29,7 @@ import (
 	envParam "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter/environment"
 	refParam "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter/reference"
 	valueParam "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter/value"
+	variableParam "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter/variable"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/manifest"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/persistence/config/internal/persistence"
 )
@@ -37,6 +38,7 @@ var allowedScopeParameterTypes = []string{
 	refParam.ReferenceParameterType,
 	valueParam.ValueParameterType,
 	envParam.EnvironmentVariableParameterType,
+	variableParam.VariableParameterType,
 }
 
 // isSupportedParamTypeForSkip check is 'skip' section of configuration supports specified param type
@@ -132,15 +134,34 @@ func parseParameter(fs afero.Fs, context *singleConfigEntryLoadContext, environm
 				Type:     context.Type,
 				ConfigId: configId,
 			},
+			Group:         environment.Group,
+			Environment:   environment.Name,
 			Fs:            afero.NewBasePathFs(fs, context.Folder),
 			ParameterName: name,
 			Value:         maps.ToStringMap(val),
+			Variables:     variablesOf(context.Variables, environment.AllVariables()),
 		})
 	}
 
 	return valueParam.New(param), nil
 }
 
+// variablesOf returns the variables of the project, overridden by the variables of the environment and its group
+func variablesOf(projectVariables map[string]interface{}, environmentVariables map[string]interface{}) map[string]interface{} {
+	if len(environmentVariables) == 0 {
+		return projectVariables
+	}
+
+	result := make(map[string]interface{}, len(projectVariables)+len(environmentVariables))
+	for k, v := range projectVariables {
+		result[k] = v
+	}
+	for k, v := range environmentVariables {
+		result[k] = v
+	}
+	return result
+}
+
 // TODO come up with better way to handle this, as this is a hack
 func arrayToReferenceParameter(context *singleConfigEntryLoadContext, environment manifest.EnvironmentDefinition,
 	configId string, parameterName string, arr []interface{}) (parameter.Parameter, error) {
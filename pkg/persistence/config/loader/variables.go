@@ -0,0 +1,58 @@
+/*
+ * @license
+ * Copyright 2025 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package loader
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/spf13/afero"
+	"gopkg.in/yaml.v2"
+)
+
+// VariablesFileName is the name of the file defining the variables of a project. It is located in the root folder of
+// the project and is not loaded as config file.
+const VariablesFileName = "_variables.yaml"
+
+type variablesDefinition struct {
+	Variables map[string]interface{} `yaml:"variables"`
+}
+
+// VariablesFilePath returns the path of the variables file of the project located at the given path
+func VariablesFilePath(projectPath string) string {
+	return filepath.Join(projectPath, VariablesFileName)
+}
+
+// LoadVariables loads the variables defined in the variables file of the project located at the given path. If the
+// project has no variables file, no variables are returned.
+func LoadVariables(fs afero.Fs, projectPath string) (map[string]interface{}, error) {
+	path := VariablesFilePath(projectPath)
+	if exists, err := afero.Exists(fs, path); err != nil || !exists {
+		return nil, err
+	}
+
+	data, err := afero.ReadFile(fs, path)
+	if err != nil {
+		return nil, newLoadError(path, err)
+	}
+
+	var definition variablesDefinition
+	if err := yaml.UnmarshalStrict(data, &definition); err != nil {
+		return nil, newLoadError(path, fmt.Errorf("failed to parse variables: %w", err))
+	}
+	return definition.Variables, nil
+}
@@ -584,10 +584,53 @@ func toConfigDefinition(context *serializerContext, cfg config.Config) (persiste
 		Parameters:     params,
 		Template:       filepath.ToSlash(configTemplatePath),
 		Skip:           cfg.Skip,
+		Labels:         cfg.Labels,
+		Hooks:          toPersistenceHooks(cfg.Hooks),
+		Deployment:     toPersistenceDeploymentPolicy(cfg.DeploymentPolicy),
+		Verify:         toPersistenceVerification(cfg.Verification),
 		OriginObjectId: cfg.OriginObjectId,
 	}, templ, nil
 }
 
+func toPersistenceDeploymentPolicy(p config.DeploymentPolicy) *persistence.DeploymentPolicy {
+	if p.IsEmpty() {
+		return nil
+	}
+	result := &persistence.DeploymentPolicy{MaxRetries: p.MaxRetries, MaxConcurrency: p.MaxConcurrency}
+	if p.Backoff > 0 {
+		result.Backoff = p.Backoff.String()
+	}
+	if p.RequestTimeout > 0 {
+		result.RequestTimeout = p.RequestTimeout.String()
+	}
+	if p.Deadline > 0 {
+		result.Deadline = p.Deadline.String()
+	}
+	return result
+}
+
+func toPersistenceVerification(v config.Verification) *persistence.Verification {
+	if v.IsEmpty() {
+		return nil
+	}
+	result := &persistence.Verification{EqualsRendered: v.EqualsRendered, Ignore: v.Ignore}
+	for _, e := range v.Expectations {
+		result.Expect = append(result.Expect, persistence.Expectation{Path: e.Path, Value: e.Value})
+	}
+	return result
+}
+
+func toPersistenceHooks(h config.Hooks) *persistence.Hooks {
+	if h == (config.Hooks{}) {
+		return nil
+	}
+	return &persistence.Hooks{
+		PreDeploy:  h.PreDeploy,
+		PostDeploy: h.PostDeploy,
+		OnError:    h.OnError,
+	}
+}
+
 func extractTemplate(context *detailedSerializerContext, cfg config.Config) (string, configTemplate, error) {
 	var name, path string
 	switch t := cfg.Template.(type) {
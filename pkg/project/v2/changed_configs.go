@@ -0,0 +1,182 @@
+/*
+ * @license
+ * Copyright 2025 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package v2
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/afero"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/files"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/log"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/log/field"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/coordinate"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter/file"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter/variable"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/template"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/persistence/config/loader"
+)
+
+// FindConfigsOfFiles returns the coordinates of all configs of the given projects that are affected by changes to the
+// given files. A config is affected if it is defined in one of the YAML files, if its template or one of its file
+// parameters is one of the files, or if it uses variables and either the variables file of its project or the manifest
+// file, which defines the environment and group variables, is one of the files. File paths, including manifestFile, are
+// relative to the working directory of the loader context. Files that don't exist anymore, e.g. deleted YAML files, are
+// ignored.
+func FindConfigsOfFiles(ctx context.Context, fs afero.Fs, loaderContext ProjectLoaderContext, projects []Project, manifestFile string, changedFiles []string) map[coordinate.Coordinate]struct{} {
+	workingDirFs := fs
+	if loaderContext.WorkingDir != "." {
+		workingDirFs = afero.NewBasePathFs(fs, loaderContext.WorkingDir)
+	}
+
+	changed := make(map[string]struct{}, len(changedFiles))
+	for _, f := range changedFiles {
+		changed[filepath.Clean(f)] = struct{}{}
+	}
+	isChanged := func(path string) bool {
+		_, found := changed[filepath.Clean(path)]
+		return found
+	}
+
+	manifestChanged := isChanged(manifestFile)
+
+	result := map[coordinate.Coordinate]struct{}{}
+	for _, p := range projects {
+		p.ForEveryConfigDo(func(c config.Config) {
+			if manifestChanged && usesVariables(c) {
+				result[c.Coordinate] = struct{}{}
+				return
+			}
+			if t, ok := c.Template.(*template.FileBasedTemplate); ok && isChanged(t.FilePath()) {
+				result[c.Coordinate] = struct{}{}
+				return
+			}
+			for _, param := range c.Parameters {
+				if fp, ok := param.(*file.FileParameter); ok && isChanged(filePathOf(fp)) {
+					result[c.Coordinate] = struct{}{}
+					return
+				}
+			}
+		})
+	}
+
+	environments := toEnvironmentSlice(loaderContext.Manifest.Environments)
+	for _, p := range projects {
+		projectDefinition, found := loaderContext.Manifest.Projects[p.Id]
+		if !found {
+			continue
+		}
+
+		variablesFile := loader.VariablesFilePath(projectDefinition.Path)
+		if isChanged(variablesFile) {
+			p.ForEveryConfigDo(func(c config.Config) {
+				if usesVariables(c) {
+					result[c.Coordinate] = struct{}{}
+				}
+			})
+		}
+
+		variables, err := loader.LoadVariables(workingDirFs, projectDefinition.Path)
+		if err != nil {
+			log.WithFields(field.F("file", variablesFile), field.Error(err)).Debug("Failed to load variables file %q: %v", variablesFile, err)
+		}
+
+		for _, f := range changedFiles {
+			if !files.IsYamlFileExtension(f) || !isInDirectory(f, projectDefinition.Path) || filepath.Clean(f) == variablesFile || filepath.Clean(f) == filepath.Clean(manifestFile) {
+				continue
+			}
+			if exists, err := afero.Exists(workingDirFs, f); err != nil || !exists {
+				continue
+			}
+
+			configs, errs := loader.LoadConfigFile(ctx, workingDirFs, &loader.LoaderContext{
+				ProjectId:       projectDefinition.Name,
+				Environments:    environments,
+				Path:            projectDefinition.Path,
+				KnownApis:       loaderContext.KnownApis,
+				ParametersSerDe: loaderContext.ParametersSerde,
+				Variables:       variables,
+			}, f)
+			for _, err := range errs {
+				log.WithFields(field.F("file", f), field.Error(err)).Debug("Failed to load changed configuration file %q: %v", f, err)
+			}
+			for _, c := range configs {
+				result[c.Coordinate] = struct{}{}
+			}
+		}
+	}
+
+	return result
+}
+
+// usesVariables returns whether any parameter of the given config is a variable parameter
+func usesVariables(c config.Config) bool {
+	for _, param := range c.Parameters {
+		if _, ok := param.(*variable.VariableParameter); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// ReferencedFiles returns the paths of all templates and file parameters used by the configs of the given projects.
+// Paths are relative to the working directory the projects were loaded from.
+func ReferencedFiles(projects []Project) []string {
+	seen := map[string]struct{}{}
+	var result []string
+	add := func(path string) {
+		path = filepath.Clean(path)
+		if _, found := seen[path]; !found {
+			seen[path] = struct{}{}
+			result = append(result, path)
+		}
+	}
+
+	for _, p := range projects {
+		p.ForEveryConfigDo(func(c config.Config) {
+			if t, ok := c.Template.(*template.FileBasedTemplate); ok {
+				add(t.FilePath())
+			}
+			for _, param := range c.Parameters {
+				if fp, ok := param.(*file.FileParameter); ok {
+					add(filePathOf(fp))
+				}
+			}
+		})
+	}
+	return result
+}
+
+// filePathOf returns the path of the file of the given parameter. File parameters are loaded relative to the folder of
+// their config file, which is the base path of their file system.
+func filePathOf(p *file.FileParameter) string {
+	if bp, ok := p.Fs.(*afero.BasePathFs); ok {
+		if path, err := bp.RealPath(p.Path); err == nil {
+			return path
+		}
+	}
+	return p.Path
+}
+
+// isInDirectory returns whether the given path is located in the given directory or any of its subdirectories
+func isInDirectory(path, dir string) bool {
+	rel, err := filepath.Rel(filepath.Clean(dir), filepath.Clean(path))
+	return err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
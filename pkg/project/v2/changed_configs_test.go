@@ -0,0 +1,144 @@
+//go:build unit
+
+/*
+ * @license
+ * Copyright 2025 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package v2
+
+import (
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/testutils"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/coordinate"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/manifest"
+)
+
+func TestFindConfigsOfFiles(t *testing.T) {
+	configYaml := []byte(`configs:
+- id: mz1
+  config:
+    template: mz1.json
+    parameters:
+      team:
+        type: variable
+        name: team
+  type:
+    settings:
+      schema: builtin:management-zones
+      scope: environment
+- id: mz2
+  config:
+    template: shared.json
+    parameters:
+      description:
+        type: file
+        path: description.txt
+  type:
+    settings:
+      schema: builtin:management-zones
+      scope: environment`)
+
+	otherYaml := []byte(`configs:
+- id: mz3
+  config:
+    template: ../builtinmanagement-zones/shared.json
+  type:
+    settings:
+      schema: builtin:management-zones
+      scope: environment`)
+
+	testFs := testutils.TempFs(t)
+	require.NoError(t, testFs.MkdirAll("a/builtinmanagement-zones", testDirectoryFileMode))
+	require.NoError(t, afero.WriteFile(testFs, "a/builtinmanagement-zones/config.yaml", configYaml, testFileFileMode))
+	require.NoError(t, afero.WriteFile(testFs, "a/builtinmanagement-zones/mz1.json", []byte(`{}`), testFileFileMode))
+	require.NoError(t, afero.WriteFile(testFs, "a/builtinmanagement-zones/shared.json", []byte(`{}`), testFileFileMode))
+	require.NoError(t, afero.WriteFile(testFs, "a/builtinmanagement-zones/description.txt", []byte(`text`), testFileFileMode))
+	require.NoError(t, testFs.MkdirAll("a/other", testDirectoryFileMode))
+	require.NoError(t, afero.WriteFile(testFs, "a/other/other.yaml", otherYaml, testFileFileMode))
+
+	loaderContext := ProjectLoaderContext{
+		KnownApis:  map[string]struct{}{"builtin:management-zones": {}},
+		WorkingDir: ".",
+		Manifest: manifest.Manifest{
+			Projects: manifest.ProjectDefinitionByProjectID{
+				"a": {Name: "a", Path: "a/"},
+			},
+			Environments: manifest.Environments{
+				"default": {Name: "default", Auth: manifest.Auth{Token: &manifest.AuthSecret{Name: "ENV_VAR"}}, Variables: map[string]interface{}{"team": "platform"}},
+			},
+		},
+		ParametersSerde: config.DefaultParameterParsers,
+	}
+
+	projects, errs := LoadProjects(t.Context(), testFs, loaderContext, nil)
+	require.Empty(t, errs)
+
+	mz := func(id string) coordinate.Coordinate {
+		return coordinate.Coordinate{Project: "a", Type: "builtin:management-zones", ConfigId: id}
+	}
+
+	tests := []struct {
+		name  string
+		files []string
+		want  []coordinate.Coordinate
+	}{
+		{
+			name:  "changed template",
+			files: []string{"a/builtinmanagement-zones/mz1.json"},
+			want:  []coordinate.Coordinate{mz("mz1")},
+		},
+		{
+			name:  "template shared by configs",
+			files: []string{"a/builtinmanagement-zones/shared.json"},
+			want:  []coordinate.Coordinate{mz("mz2"), mz("mz3")},
+		},
+		{
+			name:  "file of parameter",
+			files: []string{"a/builtinmanagement-zones/description.txt"},
+			want:  []coordinate.Coordinate{mz("mz2")},
+		},
+		{
+			name:  "config file",
+			files: []string{"a/other/other.yaml"},
+			want:  []coordinate.Coordinate{mz("mz3")},
+		},
+		{
+			name:  "manifest",
+			files: []string{"manifest.yaml"},
+			want:  []coordinate.Coordinate{mz("mz1")},
+		},
+		{
+			name:  "deleted and unrelated files are ignored",
+			files: []string{"a/other/deleted.yaml", "b/config.yaml", "README.md"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := FindConfigsOfFiles(t.Context(), testFs, loaderContext, projects, "manifest.yaml", tt.files)
+
+			var gotCoordinates []coordinate.Coordinate
+			for c := range got {
+				gotCoordinates = append(gotCoordinates, c)
+			}
+			assert.ElementsMatch(t, tt.want, gotCoordinates)
+		})
+	}
+}
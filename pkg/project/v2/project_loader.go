@@ -17,6 +17,7 @@ package v2
 import (
 	"context"
 	"fmt"
+	"path/filepath"
 	"slices"
 
 	"github.com/spf13/afero"
@@ -320,6 +321,11 @@ func loadConfigsOfProject(ctx context.Context, fs afero.Fs, loadingContext Proje
 		return nil, []error{fmt.Errorf("failed to walk files: %w", err)}
 	}
 
+	variables, err := loader.LoadVariables(fs, projectDefinition.Path)
+	if err != nil {
+		return nil, []error{err}
+	}
+
 	var configs []config.Config
 	var errs []error
 
@@ -329,9 +335,15 @@ func loadConfigsOfProject(ctx context.Context, fs afero.Fs, loadingContext Proje
 		Path:            projectDefinition.Path,
 		KnownApis:       loadingContext.KnownApis,
 		ParametersSerDe: loadingContext.ParametersSerde,
+		Variables:       variables,
 	}
 
+	variablesFile := loader.VariablesFilePath(projectDefinition.Path)
 	for _, file := range configFiles {
+		if filepath.Clean(file) == variablesFile {
+			continue
+		}
+
 		log.WithFields(field.F("file", file)).Debug("Loading configuration file %s", file)
 		loadedConfigs, configErrs := loader.LoadConfigFile(ctx, fs, loaderContext, file)
 
@@ -34,6 +34,7 @@ import (
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter/reference"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter/value"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter/variable"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/manifest"
 )
 
@@ -1487,3 +1488,100 @@ type propResolver func(coordinate.Coordinate, string) (any, bool)
 func (p propResolver) GetResolvedProperty(coordinate coordinate.Coordinate, propertyName string) (any, bool) {
 	return p(coordinate, propertyName)
 }
+
+func TestLoadProjects_ResolvesVariables(t *testing.T) {
+	managementZoneConfig := []byte(`configs:
+- id: mz
+  config:
+    template: mz.json
+    parameters:
+      team:
+        type: variable
+        name: team
+      threshold:
+        type: variable
+        name: threshold
+  type:
+    settings:
+      schema: builtin:management-zones
+      schemaVersion: 1.0.9
+      scope: environment`)
+	variables := []byte(`variables:
+  team: platform
+  threshold: 10`)
+
+	testFs := testutils.TempFs(t)
+	require.NoError(t, testFs.MkdirAll("a/builtinmanagement-zones", testDirectoryFileMode))
+	require.NoError(t, afero.WriteFile(testFs, "a/_variables.yaml", variables, testFileFileMode))
+	require.NoError(t, afero.WriteFile(testFs, "a/builtinmanagement-zones/config.yaml", managementZoneConfig, testFileFileMode))
+	require.NoError(t, afero.WriteFile(testFs, "a/builtinmanagement-zones/mz.json", []byte(`{}`), testFileFileMode))
+
+	testContext := ProjectLoaderContext{
+		KnownApis:  map[string]struct{}{"builtin:management-zones": {}},
+		WorkingDir: ".",
+		Manifest: manifest.Manifest{
+			Projects: manifest.ProjectDefinitionByProjectID{
+				"a": {Name: "a", Path: "a/"},
+			},
+			Environments: manifest.Environments{
+				"dev": {Name: "dev", Group: "dev-group", Auth: manifest.Auth{Token: &manifest.AuthSecret{Name: "ENV_VAR"}}},
+				"prod": {Name: "prod", Group: "prod-group", Auth: manifest.Auth{Token: &manifest.AuthSecret{Name: "ENV_VAR"}},
+					Variables: map[string]interface{}{"threshold": 50}},
+			},
+		},
+		ParametersSerde: config.DefaultParameterParsers,
+	}
+
+	gotProjects, gotErrs := LoadProjects(context.TODO(), testFs, testContext, nil)
+	require.Empty(t, gotErrs)
+	require.Len(t, gotProjects, 1)
+
+	dev := gotProjects[0].Configs["dev"]["builtin:management-zones"]
+	require.Len(t, dev, 1, "variables file must not be loaded as config file")
+	assert.Equal(t, &variable.VariableParameter{Name: "team", Value: "platform"}, dev[0].Parameters["team"])
+	assert.Equal(t, &variable.VariableParameter{Name: "threshold", Value: 10}, dev[0].Parameters["threshold"])
+
+	prod := gotProjects[0].Configs["prod"]["builtin:management-zones"]
+	require.Len(t, prod, 1)
+	assert.Equal(t, &variable.VariableParameter{Name: "team", Value: "platform"}, prod[0].Parameters["team"])
+	assert.Equal(t, &variable.VariableParameter{Name: "threshold", Value: 50}, prod[0].Parameters["threshold"])
+}
+
+func TestLoadProjects_FailsOnUndefinedVariable(t *testing.T) {
+	managementZoneConfig := []byte(`configs:
+- id: mz
+  config:
+    template: mz.json
+    parameters:
+      team:
+        type: variable
+        name: team
+  type:
+    settings:
+      schema: builtin:management-zones
+      schemaVersion: 1.0.9
+      scope: environment`)
+
+	testFs := testutils.TempFs(t)
+	require.NoError(t, testFs.MkdirAll("a/builtinmanagement-zones", testDirectoryFileMode))
+	require.NoError(t, afero.WriteFile(testFs, "a/builtinmanagement-zones/config.yaml", managementZoneConfig, testFileFileMode))
+	require.NoError(t, afero.WriteFile(testFs, "a/builtinmanagement-zones/mz.json", []byte(`{}`), testFileFileMode))
+
+	testContext := ProjectLoaderContext{
+		KnownApis:  map[string]struct{}{"builtin:management-zones": {}},
+		WorkingDir: ".",
+		Manifest: manifest.Manifest{
+			Projects: manifest.ProjectDefinitionByProjectID{
+				"a": {Name: "a", Path: "a/"},
+			},
+			Environments: manifest.Environments{
+				"dev": {Name: "dev", Group: "dev-group", Auth: manifest.Auth{Token: &manifest.AuthSecret{Name: "ENV_VAR"}}},
+			},
+		},
+		ParametersSerde: config.DefaultParameterParsers,
+	}
+
+	_, gotErrs := LoadProjects(context.TODO(), testFs, testContext, nil)
+	require.Len(t, gotErrs, 1)
+	assert.ErrorContains(t, gotErrs[0], `variable "team" is not defined for environment "dev"`)
+}
@@ -54,6 +54,12 @@ const (
 
 	// StateSkipped indicates no attempt was made to deploy a config because one or more dependencies were skipped or excluded.
 	StateSkipped RecordState = "SKIPPED"
+
+	// StateUnchanged indicates no attempt was made to deploy a config because the remote object is already equal to it.
+	StateUnchanged RecordState = "UNCHANGED"
+
+	// StateVerificationFailed indicates a config was deployed, but the deployed object did not pass the verification of the config.
+	StateVerificationFailed RecordState = "VERIFICATION_FAILED"
 )
 
 // Record is a single entry in a report.
@@ -67,7 +73,10 @@ type Record struct {
 	// Config provides the config ID, project and type of the config associated with the Record.
 	Config *coordinate.Coordinate `json:"config,omitempty"`
 
-	// State is the result of the deployment of the config, currently StateSuccess, StateInfo, StateError, StateExcluded, StateSkipped.
+	// Environment optionally provides the name of the environment a config was deployed to.
+	Environment string `json:"environment,omitempty"`
+
+	// State is the result of the deployment of the config, currently StateSuccess, StateInfo, StateError, StateExcluded, StateSkipped, StateUnchanged, StateVerificationFailed.
 	State RecordState `json:"state"`
 
 	// Details optionally provides Detail log entries associated with the record.
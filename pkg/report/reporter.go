@@ -40,12 +40,18 @@ func NewContextWithReporter(ctx context.Context, r Reporter) context.Context {
 }
 
 // GetReporterFromContextOrDiscard gets the Reporter associated with the Context or returns a discarding Reporter if none is available.
+// If the Context holds environment information (via [log.CtxKeyEnv]), deployments are reported for that environment.
 func GetReporterFromContextOrDiscard(ctx context.Context) Reporter {
 	v := ctx.Value(reporterContextKey{})
 	if v == nil {
 		return &discardReporter{}
 	}
 	switch v := v.(type) {
+	case *defaultReporter:
+		if env, ok := ctx.Value(log.CtxKeyEnv{}).(log.CtxValEnv); ok {
+			return &environmentReporter{defaultReporter: v, environment: env.Name}
+		}
+		return v
 	case Reporter:
 		return v
 	default:
@@ -71,16 +77,18 @@ type Reporter interface {
 
 // defaultReporter is a Reporter that writes events to a file.
 type defaultReporter struct {
-	queue                    chan Record
-	mu                       sync.Mutex
-	wg                       sync.WaitGroup
-	clockFunc                func() time.Time
-	started                  time.Time
-	ended                    time.Time
-	deploymentsSuccessCount  int
-	deploymentsErrorCount    int
-	deploymentsExcludedCount int
-	deploymentsSkippedCount  int
+	queue                              chan Record
+	mu                                 sync.Mutex
+	wg                                 sync.WaitGroup
+	clockFunc                          func() time.Time
+	started                            time.Time
+	ended                              time.Time
+	deploymentsSuccessCount            int
+	deploymentsErrorCount              int
+	deploymentsExcludedCount           int
+	deploymentsSkippedCount            int
+	deploymentsUnchangedCount          int
+	deploymentsVerificationFailedCount int
 }
 
 // NewDefaultReporter creates a new Reporter that writes events as records as objects in a JSON lines file specified by reportFilePath.
@@ -164,8 +172,12 @@ func (d *defaultReporter) updateSummaryFromRecord(r Record) {
 		d.deploymentsExcludedCount++
 	case StateSkipped:
 		d.deploymentsSkippedCount++
+	case StateUnchanged:
+		d.deploymentsUnchangedCount++
 	case StateError:
 		d.deploymentsErrorCount++
+	case StateVerificationFailed:
+		d.deploymentsVerificationFailedCount++
 	default:
 		panic(fmt.Sprintf("unexpected state for deployment event: %s", r.State))
 	}
@@ -173,13 +185,18 @@ func (d *defaultReporter) updateSummaryFromRecord(r Record) {
 
 // ReportDeployment reports the result of deploying a config.
 func (d *defaultReporter) ReportDeployment(config coordinate.Coordinate, state RecordState, details []Detail, err error) {
+	d.reportDeployment(config, "", state, details, err)
+}
+
+func (d *defaultReporter) reportDeployment(config coordinate.Coordinate, environment string, state RecordState, details []Detail, err error) {
 	record := Record{
-		Type:    TypeDeploy,
-		Time:    JSONTime(d.clockFunc()),
-		Config:  &config,
-		State:   state,
-		Details: details,
-		Error:   convertErrorToString(err),
+		Type:        TypeDeploy,
+		Time:        JSONTime(d.clockFunc()),
+		Config:      &config,
+		Environment: environment,
+		State:       state,
+		Details:     details,
+		Error:       convertErrorToString(err),
 	}
 
 	d.updateSummaryFromRecord(record)
@@ -225,6 +242,8 @@ func (d *defaultReporter) GetSummary() string {
 	sb.WriteString(fmt.Sprintf("Deployments errored: %d\n", d.deploymentsErrorCount))
 	sb.WriteString(fmt.Sprintf("Deployments excluded: %d\n", d.deploymentsExcludedCount))
 	sb.WriteString(fmt.Sprintf("Deployments skipped: %d\n", d.deploymentsSkippedCount))
+	sb.WriteString(fmt.Sprintf("Deployments unchanged: %d\n", d.deploymentsUnchangedCount))
+	sb.WriteString(fmt.Sprintf("Deployments failed verification: %d\n", d.deploymentsVerificationFailedCount))
 	sb.WriteString(fmt.Sprintf("Deploy Start Time: %v\n", d.started.Format("20060102-150405")))
 	sb.WriteString(fmt.Sprintf("Deploy End Time: %v\n", d.ended.Format("20060102-150405")))
 	sb.WriteString(fmt.Sprintf("Deploy Duration: %v\n", d.ended.Sub(d.started)))
@@ -237,6 +256,17 @@ func (d *defaultReporter) Stop() {
 	d.wg.Wait()
 }
 
+// environmentReporter is a defaultReporter that reports all deployments for a single environment.
+type environmentReporter struct {
+	*defaultReporter
+	environment string
+}
+
+// ReportDeployment reports the result of deploying a config to the environment of the Reporter.
+func (e *environmentReporter) ReportDeployment(config coordinate.Coordinate, state RecordState, details []Detail, err error) {
+	e.reportDeployment(config, e.environment, state, details, err)
+}
+
 type discardReporter struct{}
 
 func (_ *discardReporter) ReportDeployment(config coordinate.Coordinate, state RecordState, details []Detail, err error) {
@@ -19,6 +19,7 @@
 package report_test
 
 import (
+	"context"
 	"errors"
 	"testing"
 	"time"
@@ -27,6 +28,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/log"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/testutils"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/testutils/matcher"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/coordinate"
@@ -64,6 +66,8 @@ func TestReporter_ContextWithDefaultReporterCollectsEvents(t *testing.T) {
 	reporter.ReportDeployment(coordinate.Coordinate{Project: "test", Type: "dashboard", ConfigId: "my-dashboard2"}, report.StateError, []report.Detail{report.Detail{Type: report.DetailTypeError, Message: "error"}}, errors.New("an error"))
 	reporter.ReportDeployment(coordinate.Coordinate{Project: "test", Type: "dashboard", ConfigId: "my-dashboard3"}, report.StateSkipped, []report.Detail{report.Detail{Type: report.DetailTypeInfo, Message: "skipped"}}, nil)
 	reporter.ReportDeployment(coordinate.Coordinate{Project: "test", Type: "dashboard", ConfigId: "my-dashboard4"}, report.StateExcluded, nil, nil)
+	reporter.ReportDeployment(coordinate.Coordinate{Project: "test", Type: "dashboard", ConfigId: "my-dashboard5"}, report.StateUnchanged, nil, nil)
+	reporter.ReportDeployment(coordinate.Coordinate{Project: "test", Type: "dashboard", ConfigId: "my-dashboard6"}, report.StateVerificationFailed, nil, errors.New("verification failed"))
 
 	reporter.Stop()
 
@@ -75,7 +79,7 @@ func TestReporter_ContextWithDefaultReporterCollectsEvents(t *testing.T) {
 	records, err := report.ReadReportFile(fs, reportFilename)
 	require.NoError(t, err)
 
-	require.Len(t, records, 7)
+	require.Len(t, records, 9)
 	anError := "an error"
 
 	matcher.ContainsRecord(t, records, report.Record{Type: "INFO", Time: report.JSONTime(testTime), State: "INFO", Message: "startup"}, true)
@@ -85,4 +89,32 @@ func TestReporter_ContextWithDefaultReporterCollectsEvents(t *testing.T) {
 	matcher.ContainsRecord(t, records, report.Record{Type: "DEPLOY", Time: report.JSONTime(testTime), Config: &coordinate.Coordinate{Project: "test", Type: "dashboard", ConfigId: "my-dashboard2"}, State: "ERROR", Details: []report.Detail{{Type: report.DetailTypeError, Message: "error"}}, Error: anError}, true)
 	matcher.ContainsRecord(t, records, report.Record{Type: "DEPLOY", Time: report.JSONTime(testTime), Config: &coordinate.Coordinate{Project: "test", Type: "dashboard", ConfigId: "my-dashboard3"}, State: "SKIPPED", Details: []report.Detail{{Type: report.DetailTypeInfo, Message: "skipped"}}, Error: ""}, true)
 	matcher.ContainsRecord(t, records, report.Record{Type: "DEPLOY", Time: report.JSONTime(testTime), Config: &coordinate.Coordinate{Project: "test", Type: "dashboard", ConfigId: "my-dashboard4"}, State: "EXCLUDED", Details: nil, Error: ""}, true)
+	matcher.ContainsRecord(t, records, report.Record{Type: "DEPLOY", Time: report.JSONTime(testTime), Config: &coordinate.Coordinate{Project: "test", Type: "dashboard", ConfigId: "my-dashboard5"}, State: "UNCHANGED", Details: nil, Error: ""}, true)
+	matcher.ContainsRecord(t, records, report.Record{Type: "DEPLOY", Time: report.JSONTime(testTime), Config: &coordinate.Coordinate{Project: "test", Type: "dashboard", ConfigId: "my-dashboard6"}, State: "VERIFICATION_FAILED", Details: nil, Error: "verification failed"}, true)
+	assert.Contains(t, reporter.GetSummary(), "Deployments failed verification: 1")
+}
+
+// TestReporter_ContextWithEnvironmentReportsDeploymentsForEnvironment tests that deployments are reported for the environment of the context.
+func TestReporter_ContextWithEnvironmentReportsDeploymentsForEnvironment(t *testing.T) {
+	reportFilename := "test_report.jsonl"
+	fs := testutils.TempFs(t)
+
+	testTime := time.Unix(time.Now().Unix(), 0).UTC()
+
+	r := report.NewDefaultReporterWithClockFunc(fs, reportFilename, func() time.Time { return testTime })
+	ctx := report.NewContextWithReporter(t.Context(), r)
+
+	c := coordinate.Coordinate{Project: "test", Type: "dashboard", ConfigId: "my-dashboard"}
+	report.GetReporterFromContextOrDiscard(context.WithValue(ctx, log.CtxKeyEnv{}, log.CtxValEnv{Name: "env1"})).ReportDeployment(c, report.StateSuccess, nil, nil)
+	report.GetReporterFromContextOrDiscard(context.WithValue(ctx, log.CtxKeyEnv{}, log.CtxValEnv{Name: "env2"})).ReportDeployment(c, report.StateError, nil, errors.New("an error"))
+	report.GetReporterFromContextOrDiscard(ctx).Stop()
+
+	records, err := report.ReadReportFile(fs, reportFilename)
+	require.NoError(t, err)
+
+	assert.ElementsMatch(t, []report.Record{
+		{Type: "DEPLOY", Time: report.JSONTime(testTime), Config: &c, Environment: "env1", State: "SUCCESS"},
+		{Type: "DEPLOY", Time: report.JSONTime(testTime), Config: &c, Environment: "env2", State: "ERROR", Error: "an error"},
+	}, records)
+	assert.Contains(t, r.GetSummary(), "Deployments errored: 1")
 }